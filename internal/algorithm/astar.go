@@ -1,89 +1,34 @@
 package algorithm
 
-import (
-	"container/heap"
-	"math"
-
-	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
-)
+import "github.com/JoshuaPangaribuan/pathfinder/internal/maze"
 
 // AStar performs A* search on the given grid from start to goal.
-// Uses Manhattan distance as the heuristic, guaranteeing the shortest path in an unweighted grid.
+// Nodes are ranked by f = g + w·h, where h is the heuristic chosen with WithHeuristic (Manhattan
+// distance, octile distance when WithMovement allows diagonals, or hex distance on hex grids)
+// scaled by the cheapest cell cost, and w is the weight set with WithWeight (1 by default). With
+// an admissible heuristic and w ≤ 1 it finds the cheapest path; Result.Optimal reports whether
+// that guarantee holds.
 // Returns a Result with path information and visited order.
-func AStar(grid maze.Grid, start, goal maze.Point) (*Result, error) {
-	if !inBounds(grid, start) || !inBounds(grid, goal) {
-		return nil, ErrOutOfBounds
-	}
-	if !isWalkable(grid, start) || !isWalkable(grid, goal) {
-		return nil, ErrBlocked
-	}
-
-	openSet := newPriorityQueue()
-	heap.Push(openSet, &node{point: start, priority: 0})
-
-	gScore := map[maze.Point]float64{start: 0}
-	parents := make(map[maze.Point]maze.Point)
-	visitedOrder := make([]maze.Point, 0, len(grid)*len(grid[0]))
-	closed := make(map[maze.Point]bool)
-
-	var found bool
-
-	for openSet.Len() > 0 {
-		currentNode := heap.Pop(openSet).(*node)
-		current := currentNode.point
-
-		if closed[current] {
-			continue
-		}
-
-		closed[current] = true
-		visitedOrder = append(visitedOrder, current)
-
-		if current == goal {
-			found = true
-			break
-		}
-
-		for _, dir := range directions {
-			neighbor := maze.Point{X: current.X + dir.X, Y: current.Y + dir.Y}
-			if !inBounds(grid, neighbor) || !isWalkable(grid, neighbor) {
-				continue
-			}
-			if closed[neighbor] {
-				continue
-			}
-
-			tentative := gScore[current] + 1
-			if score, ok := gScore[neighbor]; ok && tentative >= score {
-				continue
-			}
-
-			parents[neighbor] = current
-			gScore[neighbor] = tentative
-			fScore := tentative + heuristic(neighbor, goal)
-			heap.Push(openSet, &node{point: neighbor, priority: fScore})
-		}
-	}
-
-	result := &Result{
-		Found:         found,
-		VisitedOrder:  visitedOrder,
-		ExpandedNodes: len(visitedOrder),
-	}
-
-	if found {
-		path := buildPath(parents, start, goal)
-		result.Path = path
-		if len(path) > 0 {
-			result.PathLength = len(path) - 1
-		}
+func AStar(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Result, error) {
+	search, err := NewAStarSearch(grid, start, goal, opts...)
+	if err != nil {
+		return nil, err
 	}
+	return search.Run(), nil
+}
 
-	return result, nil
+// NewAStarSearch prepares a step-by-step A* search. See AStar.
+func NewAStarSearch(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Search, error) {
+	return newSearch(grid, start, goal, opts, searchSpec{
+		open:     newHeapFrontier(),
+		informed: true,
+		relax:    true,
+		optimal:  informedOptimal,
+	})
 }
 
-func heuristic(a, b maze.Point) float64 {
-	dx := math.Abs(float64(a.X - b.X))
-	dy := math.Abs(float64(a.Y - b.Y))
-	return dx + dy
+// informedOptimal reports whether a heuristic-guided search is guaranteed to
+// return the cheapest path under cfg.
+func informedOptimal(cfg *config) bool {
+	return cfg.heuristic.admissible(cfg.movement, cfg.topology) && cfg.weight <= 1
 }
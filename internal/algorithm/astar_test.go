@@ -144,3 +144,28 @@ func TestAStar_ExpandedNodes(t *testing.T) {
 	assert.Greater(t, result.ExpandedNodes, 0)
 }
 
+func TestAStar_WeightedMatchesDijkstra(t *testing.T) {
+	grid := createTestGrid(6, 6, []maze.Point{
+		{X: 2, Y: 1},
+		{X: 2, Y: 2},
+		{X: 2, Y: 3},
+	})
+	costs := createTestCosts(6, 6, map[maze.Point]float64{
+		{X: 1, Y: 0}: 5,
+		{X: 3, Y: 0}: 5,
+		{X: 1, Y: 4}: 3,
+		{X: 4, Y: 4}: 0.5,
+		{X: 4, Y: 3}: 0.5,
+	})
+	start := maze.Point{X: 0, Y: 2}
+	goal := maze.Point{X: 5, Y: 2}
+
+	astar, err := AStar(grid, start, goal, WithCosts(costs))
+	require.NoError(t, err)
+	dijkstra, err := Dijkstra(grid, start, goal, WithCosts(costs))
+	require.NoError(t, err)
+
+	assert.True(t, astar.Found)
+	assert.InDelta(t, dijkstra.PathCost, astar.PathCost, 1e-9)
+	assert.LessOrEqual(t, astar.ExpandedNodes, dijkstra.ExpandedNodes)
+}
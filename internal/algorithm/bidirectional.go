@@ -0,0 +1,198 @@
+package algorithm
+
+import (
+	"math"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+)
+
+// Side names the half of a bidirectional search that emitted an event or
+// expanded a node.
+type Side string
+
+const (
+	// SideForward is the half searching from the start towards the goal.
+	SideForward Side = "forward"
+	// SideBackward is the half searching from the goal back towards the start.
+	SideBackward Side = "backward"
+)
+
+// bidirectional drives two half searches, one from each end, taking turns to
+// expand a node. Whenever a node gains a score on one side while the other side
+// has already reached it, the joined path is a candidate; the search stops once
+// neither frontier can still lead to a cheaper one.
+type bidirectional struct {
+	forward  *Search
+	backward *Search
+	// informed switches the stopping rule: heuristic-guided halves stop when
+	// either frontier's lowest f reaches the best candidate, uninformed ones when
+	// the two lowest g values add up to it.
+	informed bool
+	turn     Side
+
+	best    float64
+	meeting maze.Point
+	met     bool
+}
+
+// newBidirectionalSearch builds a Search out of a forward half from start and a
+// backward half from goal. spec is called once per half so that each gets its
+// own open set; the backward half walks edges in reverse. The backward half
+// cannot tell which keys a walker reaches the goal with or how many walls it
+// has broken by then, so grids with keys and doors and wall breaks are
+// rejected.
+func newBidirectionalSearch(grid maze.Grid, start, goal maze.Point, opts []Option, spec func() searchSpec) (*Search, error) {
+	forwardSpec := spec()
+	forwardSpec.positional = true
+	forward, err := newSearch(grid, start, goal, opts, forwardSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	backwardSpec := spec()
+	backwardSpec.successors = reverseGridSuccessors
+	backwardSpec.positional = true
+	backward, err := newSearch(grid, goal, start, opts, backwardSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Search{
+		grid:         grid,
+		start:        start,
+		goal:         goal,
+		cfg:          forward.cfg,
+		weight:       forward.weight,
+		guide:        forward.guide,
+		optimal:      forward.optimal,
+		visitedOrder: make([]maze.Point, 0, len(grid)*len(grid[0])),
+		pair: &bidirectional{
+			forward:  forward,
+			backward: backward,
+			informed: forwardSpec.informed,
+			turn:     SideForward,
+			best:     math.Inf(1),
+		},
+	}
+	s.collect(SideForward)
+	s.collect(SideBackward)
+
+	return s, nil
+}
+
+// expandPair lets the half whose turn it is expand one node, or finishes the
+// search once the best candidate can no longer be beaten.
+func (s *Search) expandPair() {
+	b := s.pair
+	if b.settled() {
+		s.done = true
+		if b.met {
+			s.found = true
+			s.emit(Event{Kind: EventGoalReached, Point: b.meeting, G: b.best, F: b.best})
+		}
+		return
+	}
+
+	side := b.turn
+	b.half(side).expand()
+	s.collect(side)
+
+	if side == SideForward {
+		b.turn = SideBackward
+	} else {
+		b.turn = SideForward
+	}
+}
+
+// collect forwards the pending events of one half, tagged with its side, and
+// records expansions and meeting points along the way.
+func (s *Search) collect(side Side) {
+	b := s.pair
+	half, other := b.half(side), b.half(opposite(side))
+
+	for _, ev := range half.pending {
+		switch ev.Kind {
+		case EventGoalReached:
+			// A half reaching the far end is covered by the meeting check.
+			continue
+		case EventPop:
+			s.visitedOrder = append(s.visitedOrder, ev.Point)
+			s.visitedSides = append(s.visitedSides, side)
+			s.expanded++
+		case EventPush, EventRelax:
+			if g, ok := other.gScore[state{Point: ev.Point}]; ok && ev.G+g < b.best {
+				b.best = ev.G + g
+				b.meeting = ev.Point
+				b.met = true
+			}
+		}
+		ev.Side = side
+		s.emit(ev)
+	}
+	half.pending = half.pending[:0]
+}
+
+// settled reports whether no path cheaper than the best candidate remains. An
+// exhausted half has an infinite lower bound.
+func (b *bidirectional) settled() bool {
+	forward, backward := b.forward.lowestPriority(), b.backward.lowestPriority()
+	if b.informed {
+		return math.Max(forward, backward) >= b.best
+	}
+	return forward+backward >= b.best
+}
+
+func (b *bidirectional) half(side Side) *Search {
+	if side == SideForward {
+		return b.forward
+	}
+	return b.backward
+}
+
+// path joins the forward path to the meeting point with the reversed backward
+// path from it, and lists the indices of the points on it reached through a
+// portal.
+func (b *bidirectional) path() ([]maze.Point, []int) {
+	meeting := state{Point: b.meeting}
+	forward := buildPath(b.forward.parents, b.forward.origin, meeting)
+	path := squares(forward)
+	teleports := b.forward.teleports(forward)
+	back := buildPath(b.backward.parents, b.backward.origin, meeting)
+	for i := len(back) - 2; i >= 0; i-- {
+		path = append(path, back[i].Point)
+		// The backward half reached back[i+1] from back[i], through the same
+		// portal the path now takes the other way.
+		if b.backward.teleported[back[i+1]] {
+			teleports = append(teleports, len(path)-1)
+		}
+	}
+	return path, teleports
+}
+
+// lowestPriority is a lower bound on the priority of the next node s expands.
+func (s *Search) lowestPriority() float64 {
+	if s.open.len() == 0 {
+		return math.Inf(1)
+	}
+	return s.open.peek().priority
+}
+
+func opposite(side Side) Side {
+	if side == SideForward {
+		return SideBackward
+	}
+	return SideForward
+}
+
+// reverseGridSuccessors steps to every neighbour from which p can be entered,
+// charging what moving from that neighbour into p costs.
+func reverseGridSuccessors(s *Search, at state) []edge {
+	// Every topology lets a step be taken back the way it came.
+	edges := s.cfg.moves(s.grid, at)
+	for i, e := range edges {
+		if !e.teleport {
+			edges[i].cost = e.cost * s.cfg.costs.Cost(at.Point)
+		}
+	}
+	return edges
+}
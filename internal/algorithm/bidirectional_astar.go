@@ -0,0 +1,29 @@
+package algorithm
+
+import "github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+
+// BidirectionalAStar runs A* from start towards goal and from goal back towards start,
+// alternating between the two. The backward half uses the same heuristic measured to the
+// start. It stops once the lowest f on either frontier reaches the cheapest joined path,
+// which keeps the result optimal under the same conditions as AStar (Result.Optimal).
+// VisitedSides tells which half expanded each node.
+func BidirectionalAStar(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Result, error) {
+	search, err := NewBidirectionalAStarSearch(grid, start, goal, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return search.Run(), nil
+}
+
+// NewBidirectionalAStarSearch prepares a step-by-step bidirectional A* search. Every event
+// carries the side that emitted it. See BidirectionalAStar.
+func NewBidirectionalAStarSearch(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Search, error) {
+	return newBidirectionalSearch(grid, start, goal, opts, func() searchSpec {
+		return searchSpec{
+			open:     newHeapFrontier(),
+			informed: true,
+			relax:    true,
+			optimal:  informedOptimal,
+		}
+	})
+}
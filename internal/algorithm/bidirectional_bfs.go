@@ -0,0 +1,28 @@
+package algorithm
+
+import "github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+
+// BidirectionalBFS runs two breadth-first searches at once, one from start and one from goal,
+// and joins them where their explored regions meet. It stops only once the two frontiers'
+// depths add up to the shortest meeting found, so it returns as short a path as BFS while
+// usually expanding far fewer nodes. VisitedSides tells which half expanded each node.
+func BidirectionalBFS(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Result, error) {
+	search, err := NewBidirectionalBFSSearch(grid, start, goal, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return search.Run(), nil
+}
+
+// NewBidirectionalBFSSearch prepares a step-by-step bidirectional BFS. Every event carries
+// the side that emitted it. See BidirectionalBFS.
+func NewBidirectionalBFSSearch(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Search, error) {
+	return newBidirectionalSearch(grid, start, goal, opts, func() searchSpec {
+		return searchSpec{
+			open: &queueFrontier{},
+			optimal: func(cfg *config) bool {
+				return cfg.costs == nil && !cfg.movement.Diagonal() && cfg.unitPortals()
+			},
+		}
+	})
+}
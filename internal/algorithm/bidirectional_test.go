@@ -0,0 +1,162 @@
+package algorithm
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomTestGrid(rng *rand.Rand, start, goal *maze.Point) maze.Grid {
+	width, height := 2+rng.Intn(14), 2+rng.Intn(14)
+	grid := createTestGrid(width, height, nil)
+	for y := range grid {
+		for x := range grid[y] {
+			if rng.Float64() < 0.3 {
+				grid[y][x] = 1
+			}
+		}
+	}
+	*start = maze.Point{X: rng.Intn(width), Y: rng.Intn(height)}
+	*goal = maze.Point{X: rng.Intn(width), Y: rng.Intn(height)}
+	grid[start.Y][start.X] = 0
+	grid[goal.Y][goal.X] = 0
+	return grid
+}
+
+func TestBidirectionalBFS_ValidPath(t *testing.T) {
+	grid := createTestGrid(5, 5, []maze.Point{{X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 2, Y: 3}})
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 4, Y: 0}
+
+	result, err := BidirectionalBFS(grid, start, goal)
+	require.NoError(t, err)
+	assert.True(t, result.Found)
+	assert.Equal(t, 12, result.PathLength)
+	assert.Equal(t, start, result.Path[0])
+	assert.Equal(t, goal, result.Path[len(result.Path)-1])
+	assertStepwisePath(t, grid, result.Path, MovementFourWay)
+
+	require.Len(t, result.VisitedSides, len(result.VisitedOrder))
+	assert.Contains(t, result.VisitedSides, SideForward)
+	assert.Contains(t, result.VisitedSides, SideBackward)
+	assert.Equal(t, SideForward, result.VisitedSides[0])
+	assert.Equal(t, start, result.VisitedOrder[0])
+	assert.Equal(t, SideBackward, result.VisitedSides[1])
+	assert.Equal(t, goal, result.VisitedOrder[1])
+}
+
+func TestBidirectional_NoPath(t *testing.T) {
+	grid := createTestGrid(3, 3, []maze.Point{{X: 1, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 2}})
+	start := maze.Point{X: 0, Y: 1}
+	goal := maze.Point{X: 2, Y: 1}
+
+	for _, solve := range []func(maze.Grid, maze.Point, maze.Point, ...Option) (*Result, error){BidirectionalBFS, BidirectionalAStar} {
+		result, err := solve(grid, start, goal)
+		require.NoError(t, err)
+		assert.False(t, result.Found)
+		assert.Empty(t, result.Path)
+	}
+}
+
+func TestBidirectional_SameStartAndGoal(t *testing.T) {
+	grid := createTestGrid(3, 3, nil)
+	p := maze.Point{X: 1, Y: 1}
+
+	result, err := BidirectionalAStar(grid, p, p)
+	require.NoError(t, err)
+	assert.True(t, result.Found)
+	assert.Equal(t, []maze.Point{p}, result.Path)
+	assert.Equal(t, 0, result.PathLength)
+}
+
+func TestBidirectional_InvalidPoints(t *testing.T) {
+	grid := createTestGrid(3, 3, []maze.Point{{X: 1, Y: 1}})
+
+	_, err := BidirectionalBFS(grid, maze.Point{X: 0, Y: 0}, maze.Point{X: 5, Y: 5})
+	assert.ErrorIs(t, err, ErrOutOfBounds)
+	_, err = BidirectionalAStar(grid, maze.Point{X: 0, Y: 0}, maze.Point{X: 1, Y: 1})
+	assert.ErrorIs(t, err, ErrBlocked)
+}
+
+func TestBidirectionalBFS_MatchesBFSOnRandomGrids(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	for i := 0; i < 300; i++ {
+		var start, goal maze.Point
+		grid := randomTestGrid(rng, &start, &goal)
+
+		bfs, err := BFS(grid, start, goal)
+		require.NoError(t, err)
+		bi, err := BidirectionalBFS(grid, start, goal)
+		require.NoError(t, err)
+
+		require.Equal(t, bfs.Found, bi.Found, "grid %d: %v", i, grid)
+		require.Equal(t, bfs.PathLength, bi.PathLength, "grid %d: %v", i, grid)
+		if bi.Found {
+			assertStepwisePath(t, grid, bi.Path, MovementFourWay)
+			assert.Equal(t, start, bi.Path[0])
+			assert.Equal(t, goal, bi.Path[len(bi.Path)-1])
+		}
+	}
+}
+
+func TestBidirectionalAStar_MatchesAStarOnRandomGrids(t *testing.T) {
+	rng := rand.New(rand.NewSource(13))
+	for i := 0; i < 300; i++ {
+		var start, goal maze.Point
+		grid := randomTestGrid(rng, &start, &goal)
+		costs := make(maze.CostGrid, len(grid))
+		for y := range costs {
+			costs[y] = make([]float64, len(grid[y]))
+			for x := range costs[y] {
+				costs[y][x] = 1 + float64(rng.Intn(5))
+			}
+		}
+
+		for _, movement := range Movements {
+			opts := []Option{WithMovement(movement), WithCosts(costs)}
+			astar, err := AStar(grid, start, goal, opts...)
+			require.NoError(t, err)
+			bi, err := BidirectionalAStar(grid, start, goal, opts...)
+			require.NoError(t, err)
+
+			require.Equal(t, astar.Found, bi.Found, "grid %d (%s): %v", i, movement, grid)
+			require.InDelta(t, astar.PathCost, bi.PathCost, 1e-9, "grid %d (%s): %v", i, movement, grid)
+			assert.True(t, bi.Optimal)
+			if bi.Found {
+				assertStepwisePath(t, grid, bi.Path, movement)
+				assert.Equal(t, start, bi.Path[0])
+				assert.Equal(t, goal, bi.Path[len(bi.Path)-1])
+			}
+		}
+	}
+}
+
+func TestBidirectionalAStar_EventsCarrySide(t *testing.T) {
+	grid := createTestGrid(6, 6, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 5, Y: 5}
+
+	search, err := NewBidirectionalAStarSearch(grid, start, goal)
+	require.NoError(t, err)
+
+	events := collectEvents(t, search)
+	require.NotEmpty(t, events)
+	last := events[len(events)-1]
+	assert.Equal(t, EventGoalReached, last.Kind)
+	assert.Equal(t, 10.0, last.G)
+
+	pops := []maze.Point{}
+	for _, ev := range events[:len(events)-1] {
+		assert.Contains(t, []Side{SideForward, SideBackward}, ev.Side)
+		if ev.Kind == EventPop {
+			pops = append(pops, ev.Point)
+		}
+	}
+
+	result := search.Result()
+	assert.Equal(t, result.VisitedOrder, pops)
+	assert.Equal(t, 10.0, result.PathCost)
+}
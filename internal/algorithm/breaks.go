@@ -0,0 +1,26 @@
+package algorithm
+
+// wall is the grid value of a wall square, which WithWallBreaks lets a path
+// break through.
+const wall = 1
+
+// breaks lists the indices of the states on path that break a wall.
+func breaks(path []state) []int {
+	var indices []int
+	broken := 0
+	for i, st := range path {
+		if st.breaks != broken {
+			indices = append(indices, i)
+			broken = st.breaks
+		}
+	}
+	return indices
+}
+
+// BreakBudget is the outcome of a search allowed to break up to Breaks walls.
+type BreakBudget struct {
+	Breaks     int     `json:"breaks"`
+	Found      bool    `json:"found"`
+	PathLength int     `json:"pathLength"`
+	PathCost   float64 `json:"pathCost"`
+}
@@ -0,0 +1,118 @@
+package algorithm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// walledRooms is three corridors parted by two walls that only open at the
+// bottom, so the way from the top left to the top right is 12 steps long but
+// only 4 with both walls broken.
+func walledRooms() maze.Grid {
+	return maze.Grid{
+		{0, 1, 0, 1, 0},
+		{0, 1, 0, 1, 0},
+		{0, 1, 0, 1, 0},
+		{0, 1, 0, 1, 0},
+		{0, 0, 0, 0, 0},
+	}
+}
+
+// assertBreakPath checks that path steps between touching squares and that
+// breaks lists exactly the walls on it, no more than budget of them.
+func assertBreakPath(t *testing.T, grid maze.Grid, path []maze.Point, breaks []int, budget int) {
+	t.Helper()
+	var walls []int
+	for i, p := range path {
+		require.LessOrEqual(t, manhattan(path[max(i-1, 0)], p), 1.0, "step %d jumps", i)
+		if grid[p.Y][p.X] == wall {
+			walls = append(walls, i)
+		}
+	}
+	assert.Equal(t, walls, breaks)
+	assert.LessOrEqual(t, len(breaks), budget)
+}
+
+func TestSolvers_WallBreakBudgets(t *testing.T) {
+	grid := walledRooms()
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 4, Y: 0}
+
+	// A single break saves nothing here: the second wall still has to be
+	// walked around.
+	for k, want := range []int{12, 12, 4, 4} {
+		result, err := BFS(grid, start, goal, WithWallBreaks(k))
+		require.NoError(t, err)
+		require.True(t, result.Found)
+		assert.Equal(t, want, result.PathLength, "%d breaks", k)
+		assertBreakPath(t, grid, result.Path, result.Breaks, k)
+	}
+}
+
+func TestAStar_WallBreaksMatchBFS(t *testing.T) {
+	seed := int64(11)
+	generated, err := maze.NewGenerator().Generate(context.Background(), 9, 7, &seed)
+	require.NoError(t, err)
+	grid := generated.Grid
+
+	start := maze.Point{X: 1, Y: 1}
+	goal := maze.Point{X: 17, Y: 13}
+	var lengths []int
+	for k := 0; k <= 4; k++ {
+		want, err := BFS(grid, start, goal, WithWallBreaks(k))
+		require.NoError(t, err)
+		require.True(t, want.Found)
+
+		result, err := AStar(grid, start, goal, WithWallBreaks(k))
+		require.NoError(t, err)
+		assert.Equal(t, want.PathLength, result.PathLength, "%d breaks", k)
+		assertBreakPath(t, grid, result.Path, result.Breaks, k)
+		if k > 0 {
+			assert.LessOrEqual(t, result.PathLength, lengths[k-1], "a bigger budget never makes the path longer")
+		}
+		lengths = append(lengths, result.PathLength)
+	}
+	assert.Less(t, lengths[4], lengths[0], "breaking walls must shorten a perfect maze")
+}
+
+func TestSolvers_DiagonalsKeepWalls(t *testing.T) {
+	grid := maze.Grid{
+		{0, 1},
+		{1, 0},
+	}
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 1, Y: 1}
+
+	result, err := AStar(grid, start, goal, WithMovement(MovementEightWay))
+	require.NoError(t, err)
+	assert.False(t, result.Found, "the diagonal clips two walls")
+
+	result, err = AStar(grid, start, goal, WithMovement(MovementEightWay), WithWallBreaks(1))
+	require.NoError(t, err)
+	require.True(t, result.Found)
+	assert.Equal(t, 2, result.PathLength, "the wall is broken by a straight step")
+	assert.Equal(t, []int{1}, result.Breaks)
+}
+
+func TestSolvers_WallBreakErrors(t *testing.T) {
+	grid := walledRooms()
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 4, Y: 0}
+
+	_, err := BFS(grid, start, goal, WithWallBreaks(-1))
+	assert.ErrorIs(t, err, ErrInvalidWallBreaks)
+
+	_, err = BFS(grid, start, maze.Point{X: 1, Y: 0}, WithWallBreaks(1))
+	assert.ErrorIs(t, err, ErrBlocked, "a wall is no place to stop")
+
+	costs := createTestCosts(5, 5, nil)
+	costs[0][1] = 0
+	_, err = Dijkstra(grid, start, goal, WithCosts(costs))
+	assert.NoError(t, err, "solid walls need no cost")
+	_, err = Dijkstra(grid, start, goal, WithCosts(costs), WithWallBreaks(1))
+	assert.ErrorIs(t, err, ErrInvalidCosts, "walls that may be broken need a cost")
+}
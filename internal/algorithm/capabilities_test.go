@@ -0,0 +1,114 @@
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSolvers_Capabilities runs every registered solver on one fixture per
+// optional capability. Solvers whose Info lacks the capability must reject the
+// fixture with the matching error; the others must find a legal path, and an
+// optimal one if they claim optimality.
+func TestSolvers_Capabilities(t *testing.T) {
+	obstacleGrid, obstacles := alcoveCorridor()
+
+	cases := []struct {
+		name        string
+		grid        maze.Grid
+		start, goal maze.Point
+		opts        []Option
+		supported   func(Info) bool
+		unsupported error
+		check       func(t *testing.T, grid maze.Grid, result *Result, optimal bool)
+	}{
+		{
+			name:  "portals",
+			grid:  portalRooms(),
+			start: maze.Point{X: 1, Y: 1},
+			goal:  maze.Point{X: 5, Y: 1},
+			check: func(t *testing.T, grid maze.Grid, result *Result, optimal bool) {
+				assertPortalPath(t, grid, result.Path, result.Teleports)
+				require.Len(t, result.Teleports, 1)
+				assert.Equal(t, maze.Point{X: 2, Y: 2}, result.Path[result.Teleports[0]-1])
+				assert.Equal(t, maze.Point{X: 4, Y: 2}, result.Path[result.Teleports[0]])
+				if optimal {
+					assert.Equal(t, 5, result.PathLength)
+					assert.Equal(t, 5.0, result.PathCost)
+				}
+			},
+		},
+		{
+			name:        "keys",
+			grid:        lockedCorridor(),
+			start:       maze.Point{X: 2, Y: 1},
+			goal:        maze.Point{X: 5, Y: 1},
+			supported:   func(info Info) bool { return info.Keys },
+			unsupported: ErrUnsupportedKeys,
+			check: func(t *testing.T, grid maze.Grid, result *Result, optimal bool) {
+				assertKeyPath(t, grid, result.Path, result.Pickups)
+				assert.Equal(t, []int{3}, result.Pickups)
+				if optimal {
+					assert.Equal(t, 9, result.PathLength)
+				}
+			},
+		},
+		{
+			name:        "wall breaks",
+			grid:        walledRooms(),
+			start:       maze.Point{X: 0, Y: 0},
+			goal:        maze.Point{X: 4, Y: 0},
+			opts:        []Option{WithWallBreaks(2)},
+			supported:   func(info Info) bool { return info.WallBreaks },
+			unsupported: ErrUnsupportedWallBreaks,
+			check: func(t *testing.T, grid maze.Grid, result *Result, optimal bool) {
+				assertBreakPath(t, grid, result.Path, result.Breaks, 2)
+				if optimal {
+					assert.Equal(t, 4, result.PathLength)
+					assert.Equal(t, []int{1, 3}, result.Breaks)
+				}
+			},
+		},
+		{
+			name:        "moving obstacles",
+			grid:        obstacleGrid,
+			start:       maze.Point{X: 0, Y: 1},
+			goal:        maze.Point{X: 4, Y: 1},
+			opts:        []Option{WithObstacles(obstacles)},
+			supported:   func(info Info) bool { return info.MovingObstacles },
+			unsupported: ErrUnsupportedObstacles,
+			check: func(t *testing.T, grid maze.Grid, result *Result, optimal bool) {
+				assertDodges(t, result.Path, obstacles)
+				require.Len(t, result.Times, len(result.Path))
+				assert.Equal(t, result.PathLength, result.Times[len(result.Times)-1])
+				assert.Len(t, result.Obstacles, len(result.Path))
+				if optimal {
+					// Into the alcove while the obstacle passes, waiting once.
+					assert.Equal(t, 7, result.PathLength)
+					assert.Contains(t, result.Path, maze.Point{X: 1, Y: 0})
+				}
+			},
+		},
+	}
+
+	registry := NewDefaultRegistry()
+	for _, tc := range cases {
+		for _, info := range registry.List() {
+			t.Run(tc.name+"/"+info.Name, func(t *testing.T) {
+				solver, _ := registry.Lookup(info.Name)
+				result, err := solver.Solve(tc.grid, tc.start, tc.goal, tc.opts...)
+				if tc.supported != nil && !tc.supported(info) {
+					assert.ErrorIs(t, err, tc.unsupported)
+					return
+				}
+				require.NoError(t, err)
+				require.True(t, result.Found)
+				assert.Equal(t, tc.start, result.Path[0])
+				assert.Equal(t, tc.goal, result.Path[len(result.Path)-1])
+				tc.check(t, tc.grid, result, info.Optimal)
+			})
+		}
+	}
+}
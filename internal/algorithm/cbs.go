@@ -0,0 +1,401 @@
+package algorithm
+
+import (
+	"container/heap"
+	"context"
+	"slices"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+)
+
+// Agent is one of several walkers sharing a maze, each on its way from Start
+// to Goal.
+type Agent struct {
+	Start maze.Point `json:"start"`
+	Goal  maze.Point `json:"goal"`
+}
+
+// ConflictKind tells how the paths of two agents collide.
+type ConflictKind string
+
+const (
+	// ConflictVertex is two agents standing on the same square at once.
+	ConflictVertex ConflictKind = "vertex"
+	// ConflictEdge is two agents swapping squares in the same time step.
+	ConflictEdge ConflictKind = "edge"
+)
+
+// Conflict is the first collision between the paths of two agents. A vertex
+// conflict has both agents on Point at time step Time; an edge conflict has
+// the first agent step from Point to To while the second steps back, leaving
+// at time step Time.
+type Conflict struct {
+	Kind   ConflictKind `json:"kind"`
+	Agents [2]int       `json:"agents"`
+	Time   int          `json:"time"`
+	Point  maze.Point   `json:"point"`
+	To     *maze.Point  `json:"to,omitempty"`
+}
+
+// MultiResult captures the output of a multi-agent search. Paths holds one
+// path per agent, in the order of the agents, each moving one step in time
+// per point with waits repeating a point; an agent stays on its goal once it
+// has arrived. SumOfCosts adds up the time steps every agent takes to arrive
+// and Makespan is the longest of them. ExpandedNodes counts the constraint
+// tree nodes expanded and LowLevelExpansions the space-time states the single
+// agent searches expanded between them. When Found is unset the paths are the
+// best partial solution, the one with the fewest conflicting pairs of agents
+// seen, and Conflicts lists the first conflict of each pair. An agent that
+// cannot reach its goal even alone has an empty path.
+type MultiResult struct {
+	Found              bool           `json:"found"`
+	Paths              [][]maze.Point `json:"paths"`
+	SumOfCosts         int            `json:"sumOfCosts"`
+	Makespan           int            `json:"makespan"`
+	Conflicts          []Conflict     `json:"conflicts"`
+	ExpandedNodes      int            `json:"expandedNodes"`
+	LowLevelExpansions int            `json:"lowLevelExpansions"`
+	LimitReached       bool           `json:"limitReached"`
+}
+
+// CBS performs Conflict-Based Search for agents on grid: each agent is planned
+// alone with a space-time A*, and whenever two paths collide the search
+// branches on which of the two agents is kept off the square or the swap at
+// that time step. Every step, teleport and wait takes one time step, so the
+// sum of costs found is the smallest possible. CBS takes the movement,
+// topology, floors and stairs and portal options; it ranks states with the
+// tightest admissible heuristic for unit steps, ignoring the heuristic, weight
+// and portal cost options. Grids with keys or doors, wall breaks, moving
+// obstacles and per-cell costs yield ErrUnsupportedKeys,
+// ErrUnsupportedWallBreaks, ErrUnsupportedObstacles and ErrInvalidCosts.
+// Agents that cannot all reach their goals without colliding keep the search
+// branching forever, so it gives up after maxExpansions constraint tree nodes
+// and returns the best partial solution with LimitReached set.
+func CBS(ctx context.Context, grid maze.Grid, agents []Agent, maxExpansions int, opts ...Option) (*MultiResult, error) {
+	cfg, err := newConfig(grid, opts)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case cfg.keyed:
+		return nil, ErrUnsupportedKeys
+	case cfg.breaks > 0:
+		return nil, ErrUnsupportedWallBreaks
+	case cfg.timed():
+		return nil, ErrUnsupportedObstacles
+	case cfg.costs != nil:
+		return nil, ErrInvalidCosts
+	}
+	if err := validateAgents(grid, cfg, agents); err != nil {
+		return nil, err
+	}
+
+	cfg.portalCost = 1
+	cfg.heuristic = defaultHeuristic(cfg.movement, cfg.topology)
+	if cfg.heuristic == HeuristicOctile {
+		cfg.heuristic = HeuristicChebyshev
+	}
+	pl := &planner{grid: grid, cfg: cfg, agents: agents}
+	for _, a := range agents {
+		pl.estimates = append(pl.estimates, cfg.estimate(grid, a.Goal))
+	}
+
+	root := &ctNode{paths: make([][]maze.Point, len(agents))}
+	solvable := true
+	for i := range agents {
+		root.paths[i] = pl.plan(i, nil)
+		solvable = solvable && len(root.paths[i]) > 0
+	}
+	root.evaluate()
+	if !solvable {
+		return root.result(pl, false), nil
+	}
+
+	open := &ctQueue{}
+	heap.Push(open, root)
+	best, generated := root, 1
+	for open.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if pl.expandedNodes >= maxExpansions {
+			result := best.result(pl, false)
+			result.LimitReached = true
+			return result, nil
+		}
+
+		current := heap.Pop(open).(*ctNode)
+		pl.expandedNodes++
+		if len(current.conflicts) == 0 {
+			return current.result(pl, true), nil
+		}
+
+		conflict := current.conflicts[0]
+		for side, agent := range conflict.Agents {
+			child := &ctNode{
+				constraints: append(slices.Clip(current.constraints), conflict.constraint(side)),
+				paths:       slices.Clone(current.paths),
+				order:       generated,
+			}
+			child.paths[agent] = pl.plan(agent, child.constraints)
+			if child.paths[agent] == nil {
+				continue
+			}
+			generated++
+			child.evaluate()
+			heap.Push(open, child)
+			if child.better(best) {
+				best = child
+			}
+		}
+	}
+	// Every branch ran out of paths, which happens only when the constraints
+	// leave some agent no way at all to its goal.
+	return best.result(pl, false), nil
+}
+
+// validateAgents checks that there is at least one agent, that every start and
+// goal lies on an open square of the maze, and that no two agents share a start
+// or a goal.
+func validateAgents(grid maze.Grid, cfg *config, agents []Agent) error {
+	if len(agents) == 0 {
+		return ErrInvalidAgents
+	}
+	starts := make(map[maze.Point]bool, len(agents))
+	goals := make(map[maze.Point]bool, len(agents))
+	for _, a := range agents {
+		if !cfg.contains(grid, a.Start) || !cfg.contains(grid, a.Goal) {
+			return ErrOutOfBounds
+		}
+		if !isWalkable(cfg.floor(grid, a.Start.Z), a.Start) || !isWalkable(cfg.floor(grid, a.Goal.Z), a.Goal) {
+			return ErrBlocked
+		}
+		if starts[a.Start] || goals[a.Goal] {
+			return ErrInvalidAgents
+		}
+		starts[a.Start] = true
+		goals[a.Goal] = true
+	}
+	return nil
+}
+
+// constraint keeps agent off square at at time step t or, for an edge
+// constraint, from stepping from at to to when leaving at time step t.
+type constraint struct {
+	agent int
+	at    maze.Point
+	to    maze.Point
+	t     int
+	edge  bool
+}
+
+// constraint is what the branch on side of c forbids its agent.
+func (c Conflict) constraint(side int) constraint {
+	agent := c.Agents[side]
+	if c.Kind == ConflictVertex {
+		return constraint{agent: agent, at: c.Point, t: c.Time}
+	}
+	if side == 0 {
+		return constraint{agent: agent, at: c.Point, to: *c.To, t: c.Time, edge: true}
+	}
+	return constraint{agent: agent, at: *c.To, to: c.Point, t: c.Time, edge: true}
+}
+
+// planner runs the single agent searches of CBS and counts their work.
+type planner struct {
+	grid      maze.Grid
+	cfg       *config
+	agents    []Agent
+	estimates []func(maze.Point) float64
+
+	expandedNodes      int
+	lowLevelExpansions int
+}
+
+// plan finds the fastest path of agent that keeps to its constraints, or nil
+// if there is none. States are squares at a time step; past the last time step
+// a constraint names nothing changes any more, so those times fold into one
+// and the search stays finite. The agent has arrived once it stands on its
+// goal and no constraint sends it away later.
+func (pl *planner) plan(agent int, constraints []constraint) []maze.Point {
+	type step struct {
+		from, to maze.Point
+		t        int
+	}
+	a := pl.agents[agent]
+	vertices := make(map[state]bool)
+	steps := make(map[step]bool)
+	horizon, goalLast := 0, -1
+	for _, c := range constraints {
+		if c.agent != agent {
+			continue
+		}
+		horizon = max(horizon, c.t+1)
+		if c.edge {
+			steps[step{from: c.at, to: c.to, t: c.t}] = true
+			continue
+		}
+		vertices[state{Point: c.at, t: c.t}] = true
+		if c.at == a.Goal {
+			goalLast = max(goalLast, c.t)
+		}
+	}
+
+	estimate := pl.estimates[agent]
+	origin := state{Point: a.Start}
+	open := newHeapFrontier()
+	open.push(&node{state: origin, priority: estimate(a.Start)})
+	gScore := map[state]int{origin: 0}
+	parents := make(map[state]state)
+	closed := make(map[state]bool)
+
+	for open.len() > 0 {
+		current := open.pop().state
+		if closed[current] {
+			continue
+		}
+		closed[current] = true
+		pl.lowLevelExpansions++
+
+		if current.Point == a.Goal && current.t > goalLast {
+			states := buildPath(parents, origin, current)
+			path := make([]maze.Point, len(states))
+			for i, st := range states {
+				path[i] = st.Point
+			}
+			return path
+		}
+
+		g := gScore[current] + 1
+		next := min(current.t+1, horizon)
+		moves := append(pl.cfg.moves(pl.grid, state{Point: current.Point}), edge{to: current.Point})
+		for _, e := range moves {
+			if current.t < horizon && (vertices[state{Point: e.to, t: next}] || steps[step{from: current.Point, to: e.to, t: current.t}]) {
+				continue
+			}
+			to := state{Point: e.to, t: next}
+			if closed[to] {
+				continue
+			}
+			if score, ok := gScore[to]; ok && g >= score {
+				continue
+			}
+			gScore[to] = g
+			parents[to] = current
+			open.push(&node{state: to, priority: float64(g) + estimate(e.to)})
+		}
+	}
+	return nil
+}
+
+// ctNode is a node of the constraint tree: the constraints on its branch, the
+// path of every agent under them, and what those paths cost and collide on.
+type ctNode struct {
+	constraints []constraint
+	paths       [][]maze.Point
+	cost        int
+	conflicts   []Conflict
+	order       int
+}
+
+// evaluate works out the cost and conflicts of the paths of n.
+func (n *ctNode) evaluate() {
+	n.cost = 0
+	for _, path := range n.paths {
+		n.cost += max(len(path)-1, 0)
+	}
+	n.conflicts = conflicts(n.paths)
+}
+
+// better reports whether n is a better partial solution than other: fewer
+// conflicting pairs of agents, then a smaller sum of costs.
+func (n *ctNode) better(other *ctNode) bool {
+	if len(n.conflicts) != len(other.conflicts) {
+		return len(n.conflicts) < len(other.conflicts)
+	}
+	return n.cost < other.cost
+}
+
+// result reports the paths of n along with the work pl did.
+func (n *ctNode) result(pl *planner, found bool) *MultiResult {
+	result := &MultiResult{
+		Found:              found,
+		Paths:              make([][]maze.Point, len(n.paths)),
+		SumOfCosts:         n.cost,
+		Conflicts:          n.conflicts,
+		ExpandedNodes:      pl.expandedNodes,
+		LowLevelExpansions: pl.lowLevelExpansions,
+	}
+	for i, path := range n.paths {
+		result.Paths[i] = append([]maze.Point{}, path...)
+		result.Makespan = max(result.Makespan, len(path)-1)
+	}
+	if result.Conflicts == nil {
+		result.Conflicts = []Conflict{}
+	}
+	return result
+}
+
+// conflicts lists the first conflict of every pair of agents whose paths
+// collide, earliest first. Agents without a path collide with no one.
+func conflicts(paths [][]maze.Point) []Conflict {
+	var found []Conflict
+	for i := range paths {
+		for j := i + 1; j < len(paths); j++ {
+			if c, ok := firstConflict(paths, i, j); ok {
+				found = append(found, c)
+			}
+		}
+	}
+	slices.SortStableFunc(found, func(a, b Conflict) int { return a.Time - b.Time })
+	return found
+}
+
+// firstConflict finds the earliest collision between the paths of agents i
+// and j, checking the squares they stand on before the steps they arrive by.
+func firstConflict(paths [][]maze.Point, i, j int) (Conflict, bool) {
+	a, b := paths[i], paths[j]
+	if len(a) == 0 || len(b) == 0 {
+		return Conflict{}, false
+	}
+	at := func(path []maze.Point, t int) maze.Point {
+		return path[min(t, len(path)-1)]
+	}
+	for t := 0; t < max(len(a), len(b)); t++ {
+		if at(a, t) == at(b, t) {
+			return Conflict{Kind: ConflictVertex, Agents: [2]int{i, j}, Time: t, Point: at(a, t)}, true
+		}
+		if t > 0 && at(a, t-1) == at(b, t) && at(b, t-1) == at(a, t) {
+			to := at(a, t)
+			return Conflict{Kind: ConflictEdge, Agents: [2]int{i, j}, Time: t - 1, Point: at(a, t-1), To: &to}, true
+		}
+	}
+	return Conflict{}, false
+}
+
+// ctQueue orders constraint tree nodes by sum of costs, then by how many pairs
+// of agents collide, then by the order they were made in.
+type ctQueue []*ctNode
+
+func (q ctQueue) Len() int { return len(q) }
+
+func (q ctQueue) Less(i, j int) bool {
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	if len(q[i].conflicts) != len(q[j].conflicts) {
+		return len(q[i].conflicts) < len(q[j].conflicts)
+	}
+	return q[i].order < q[j].order
+}
+
+func (q ctQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *ctQueue) Push(x any) { *q = append(*q, x.(*ctNode)) }
+
+func (q *ctQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,210 @@
+package algorithm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertNoCollisions checks that every path runs from its agent's start to its
+// goal a step or a wait at a time, and that no two agents ever share a square
+// or swap squares.
+func assertNoCollisions(t *testing.T, agents []Agent, paths [][]maze.Point) {
+	t.Helper()
+	require.Len(t, paths, len(agents))
+	at := func(i, tick int) maze.Point {
+		return paths[i][min(tick, len(paths[i])-1)]
+	}
+	span := 0
+	for i, path := range paths {
+		require.NotEmpty(t, path, "agent %d", i)
+		assert.Equal(t, agents[i].Start, path[0], "agent %d", i)
+		assert.Equal(t, agents[i].Goal, path[len(path)-1], "agent %d", i)
+		for step, p := range path {
+			require.LessOrEqual(t, chebyshev(path[max(step-1, 0)], p), 1.0, "agent %d jumps at step %d", i, step)
+		}
+		span = max(span, len(path))
+	}
+	for tick := 0; tick < span; tick++ {
+		for i := range paths {
+			for j := i + 1; j < len(paths); j++ {
+				require.NotEqual(t, at(i, tick), at(j, tick), "agents %d and %d meet at step %d", i, j, tick)
+				if tick > 0 {
+					swapped := at(i, tick-1) == at(j, tick) && at(j, tick-1) == at(i, tick)
+					require.False(t, swapped, "agents %d and %d swap at step %d", i, j, tick)
+				}
+			}
+		}
+	}
+}
+
+func TestCBS_SidestepsIntoAlcove(t *testing.T) {
+	grid := maze.Grid{
+		{1, 0, 1},
+		{0, 0, 0},
+	}
+	agents := []Agent{
+		{Start: maze.Point{X: 0, Y: 1}, Goal: maze.Point{X: 2, Y: 1}},
+		{Start: maze.Point{X: 2, Y: 1}, Goal: maze.Point{X: 0, Y: 1}},
+	}
+
+	result, err := CBS(context.Background(), grid, agents, 100)
+	require.NoError(t, err)
+	require.True(t, result.Found)
+	assertNoCollisions(t, agents, result.Paths)
+	// One agent ducks into the alcove and back out, the other waits once.
+	assert.Equal(t, 7, result.SumOfCosts)
+	assert.Equal(t, 4, result.Makespan)
+	assert.Empty(t, result.Conflicts)
+	assert.False(t, result.LimitReached)
+	assert.Greater(t, result.ExpandedNodes, 1)
+	assert.Greater(t, result.LowLevelExpansions, result.ExpandedNodes)
+}
+
+func TestCBS_WaitsForAgentPassingItsGoal(t *testing.T) {
+	grid := maze.Grid{
+		{0, 0, 0, 0},
+		{1, 0, 1, 1},
+	}
+	agents := []Agent{
+		{Start: maze.Point{X: 0, Y: 0}, Goal: maze.Point{X: 1, Y: 0}},
+		{Start: maze.Point{X: 1, Y: 1}, Goal: maze.Point{X: 3, Y: 0}},
+	}
+
+	result, err := CBS(context.Background(), grid, agents, 100)
+	require.NoError(t, err)
+	require.True(t, result.Found)
+	assertNoCollisions(t, agents, result.Paths)
+	assert.Equal(t, 5, result.SumOfCosts)
+	assert.Equal(t, 3, result.Makespan)
+}
+
+func TestCBS_IndependentAgentsNeedNoBranching(t *testing.T) {
+	grid := maze.Grid{
+		{0, 0, 0},
+		{1, 1, 1},
+		{0, 0, 0},
+	}
+	agents := []Agent{
+		{Start: maze.Point{X: 0, Y: 0}, Goal: maze.Point{X: 2, Y: 0}},
+		{Start: maze.Point{X: 2, Y: 2}, Goal: maze.Point{X: 0, Y: 2}},
+	}
+
+	result, err := CBS(context.Background(), grid, agents, 100)
+	require.NoError(t, err)
+	require.True(t, result.Found)
+	assert.Equal(t, 1, result.ExpandedNodes)
+	assert.Equal(t, 4, result.SumOfCosts)
+	assert.Equal(t, 2, result.Makespan)
+}
+
+func TestCBS_CrossingAgents(t *testing.T) {
+	grid := make(maze.Grid, 5)
+	for y := range grid {
+		grid[y] = make([]int, 5)
+	}
+	agents := []Agent{
+		{Start: maze.Point{X: 0, Y: 2}, Goal: maze.Point{X: 4, Y: 2}},
+		{Start: maze.Point{X: 4, Y: 2}, Goal: maze.Point{X: 0, Y: 2}},
+		{Start: maze.Point{X: 2, Y: 0}, Goal: maze.Point{X: 2, Y: 4}},
+		{Start: maze.Point{X: 2, Y: 4}, Goal: maze.Point{X: 2, Y: 0}},
+	}
+
+	for _, movement := range Movements {
+		result, err := CBS(context.Background(), grid, agents, 10000, WithMovement(movement))
+		require.NoError(t, err)
+		require.True(t, result.Found, "%s", movement)
+		assertNoCollisions(t, agents, result.Paths)
+
+		alone := 0
+		for _, a := range agents {
+			want, err := BFS(grid, a.Start, a.Goal, WithMovement(movement))
+			require.NoError(t, err)
+			alone += want.PathLength
+		}
+		assert.GreaterOrEqual(t, result.SumOfCosts, alone, "%s", movement)
+		if !movement.Diagonal() {
+			// Diagonals let agents curve around each other at no extra time.
+			assert.Greater(t, result.SumOfCosts, alone, "%s: the agents cannot all go straight", movement)
+		}
+	}
+}
+
+func TestCBS_LimitReturnsBestPartialSolution(t *testing.T) {
+	// Two agents swapping ends of a corridor never get past each other.
+	grid := maze.Grid{{0, 0, 0}}
+	agents := []Agent{
+		{Start: maze.Point{X: 0}, Goal: maze.Point{X: 2}},
+		{Start: maze.Point{X: 2}, Goal: maze.Point{X: 0}},
+	}
+
+	result, err := CBS(context.Background(), grid, agents, 50)
+	require.NoError(t, err)
+	assert.False(t, result.Found)
+	assert.True(t, result.LimitReached)
+	assert.Equal(t, 50, result.ExpandedNodes)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, [2]int{0, 1}, result.Conflicts[0].Agents)
+	for i, path := range result.Paths {
+		assert.Equal(t, agents[i].Goal, path[len(path)-1])
+	}
+}
+
+func TestCBS_UnreachableGoal(t *testing.T) {
+	grid := maze.Grid{
+		{0, 0, 1, 0},
+	}
+	agents := []Agent{
+		{Start: maze.Point{X: 0}, Goal: maze.Point{X: 1}},
+		{Start: maze.Point{X: 1}, Goal: maze.Point{X: 3}},
+	}
+
+	result, err := CBS(context.Background(), grid, agents, 100)
+	require.NoError(t, err)
+	assert.False(t, result.Found)
+	assert.False(t, result.LimitReached)
+	assert.NotEmpty(t, result.Paths[0])
+	assert.Empty(t, result.Paths[1])
+}
+
+func TestCBS_Errors(t *testing.T) {
+	ctx := context.Background()
+	grid := maze.Grid{
+		{0, 0, 0},
+		{0, 1, 0},
+	}
+	a := Agent{Start: maze.Point{X: 0}, Goal: maze.Point{X: 2}}
+
+	_, err := CBS(ctx, grid, nil, 100)
+	assert.ErrorIs(t, err, ErrInvalidAgents)
+
+	_, err = CBS(ctx, grid, []Agent{a, {Start: a.Start, Goal: maze.Point{X: 0, Y: 1}}}, 100)
+	assert.ErrorIs(t, err, ErrInvalidAgents, "shared start")
+
+	_, err = CBS(ctx, grid, []Agent{a, {Start: maze.Point{X: 2, Y: 1}, Goal: a.Goal}}, 100)
+	assert.ErrorIs(t, err, ErrInvalidAgents, "shared goal")
+
+	_, err = CBS(ctx, grid, []Agent{{Start: a.Start, Goal: maze.Point{X: 3}}}, 100)
+	assert.ErrorIs(t, err, ErrOutOfBounds)
+
+	_, err = CBS(ctx, grid, []Agent{{Start: a.Start, Goal: maze.Point{X: 1, Y: 1}}}, 100)
+	assert.ErrorIs(t, err, ErrBlocked)
+
+	_, err = CBS(ctx, grid, []Agent{a}, 100, WithWallBreaks(1))
+	assert.ErrorIs(t, err, ErrUnsupportedWallBreaks)
+
+	_, err = CBS(ctx, grid, []Agent{a}, 100, WithObstacles([]Obstacle{{Trajectory: []maze.Point{{X: 1}}}}))
+	assert.ErrorIs(t, err, ErrUnsupportedObstacles)
+
+	keyed := maze.Grid{{0, maze.Key(1), 0}}
+	_, err = CBS(ctx, keyed, []Agent{a}, 100)
+	assert.ErrorIs(t, err, ErrUnsupportedKeys)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = CBS(cancelled, grid, []Agent{a}, 100)
+	assert.ErrorIs(t, err, context.Canceled)
+}
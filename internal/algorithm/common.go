@@ -19,12 +19,30 @@ func inBounds(grid maze.Grid, p maze.Point) bool {
 	return true
 }
 
+// normalize maps p onto grid. With wrap the grid is a torus and every point
+// has a copy on it, found by wrapping its coordinates around the edges;
+// otherwise p is on the grid only if it is in bounds.
+func normalize(grid maze.Grid, p maze.Point, wrap bool) (maze.Point, bool) {
+	if wrap && len(grid) > 0 {
+		p.Y = mod(p.Y, len(grid))
+		if n := len(grid[p.Y]); n > 0 {
+			p.X = mod(p.X, n)
+		}
+	}
+	return p, inBounds(grid, p)
+}
+
+// mod is the remainder of a divided by n, taken in [0, n).
+func mod(a, n int) int {
+	return (a%n + n) % n
+}
+
 func isWalkable(grid maze.Grid, p maze.Point) bool {
-	return grid[p.Y][p.X] == 0
+	return maze.IsOpen(grid[p.Y][p.X])
 }
 
-func buildPath(parent map[maze.Point]maze.Point, start, goal maze.Point) []maze.Point {
-	path := []maze.Point{}
+func buildPath[T comparable](parent map[T]T, start, goal T) []T {
+	path := []T{}
 	current := goal
 	for {
 		path = append(path, current)
@@ -44,3 +62,42 @@ func buildPath(parent map[maze.Point]maze.Point, start, goal maze.Point) []maze.
 	}
 	return path
 }
+
+// expandSegments turns a path whose consecutive points lie on a shared row or
+// column into a path of single steps. teleports lists the indices of the
+// points reached through a portal, whose links are left as they are; the
+// indices are returned translated to the expanded path.
+func expandSegments(path []maze.Point, teleports []int) ([]maze.Point, []int) {
+	if len(path) < 2 {
+		return path, teleports
+	}
+
+	expanded := []maze.Point{path[0]}
+	var jumps []int
+	for i := 1; i < len(path); i++ {
+		from, to := path[i-1], path[i]
+		if len(teleports) > 0 && teleports[0] == i {
+			teleports = teleports[1:]
+			expanded = append(expanded, to)
+			jumps = append(jumps, len(expanded)-1)
+			continue
+		}
+		step := maze.Point{X: sign(to.X - from.X), Y: sign(to.Y - from.Y)}
+		for p := from; p != to; {
+			p = maze.Point{X: p.X + step.X, Y: p.Y + step.Y}
+			expanded = append(expanded, p)
+		}
+	}
+	return expanded, jumps
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
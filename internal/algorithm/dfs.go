@@ -4,64 +4,19 @@ import "github.com/JoshuaPangaribuan/pathfinder/internal/maze"
 
 // DFS performs depth-first search on the given grid from start to goal.
 // It explores as far as possible along each branch before backtracking.
-// Does not guarantee the shortest path. Returns a Result with path information and visited order.
-func DFS(grid maze.Grid, start, goal maze.Point) (*Result, error) {
-	if !inBounds(grid, start) || !inBounds(grid, goal) {
-		return nil, ErrOutOfBounds
-	}
-	if !isWalkable(grid, start) || !isWalkable(grid, goal) {
-		return nil, ErrBlocked
-	}
-
-	stack := []maze.Point{start}
-	visited := map[maze.Point]bool{start: true}
-	parents := make(map[maze.Point]maze.Point)
-	visitedOrder := make([]maze.Point, 0, len(grid)*len(grid[0]))
-
-	var found bool
-
-	for len(stack) > 0 {
-		current := stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
-
-		visitedOrder = append(visitedOrder, current)
-
-		if current == goal {
-			found = true
-			break
-		}
-
-		for _, dir := range directions {
-			next := maze.Point{X: current.X + dir.X, Y: current.Y + dir.Y}
-			if !inBounds(grid, next) {
-				continue
-			}
-			if !isWalkable(grid, next) {
-				continue
-			}
-			if visited[next] {
-				continue
-			}
-
-			visited[next] = true
-			parents[next] = current
-			stack = append(stack, next)
-		}
-	}
-
-	result := &Result{
-		Found:         found,
-		VisitedOrder:  visitedOrder,
-		ExpandedNodes: len(visitedOrder),
-	}
-
-	if found {
-		path := buildPath(parents, start, goal)
-		result.Path = path
-		if len(path) > 0 {
-			result.PathLength = len(path) - 1
-		}
+// Does not guarantee the shortest path, and cell costs only feed PathCost.
+// Returns a Result with path information and visited order.
+func DFS(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Result, error) {
+	search, err := NewDFSSearch(grid, start, goal, opts...)
+	if err != nil {
+		return nil, err
 	}
+	return search.Run(), nil
+}
 
-	return result, nil
+// NewDFSSearch prepares a step-by-step depth-first search. See DFS.
+func NewDFSSearch(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Search, error) {
+	return newSearch(grid, start, goal, opts, searchSpec{
+		open: &stackFrontier{},
+	})
 }
@@ -0,0 +1,24 @@
+package algorithm
+
+import "github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+
+// Dijkstra performs uniform-cost search on the given grid from start to goal.
+// It always expands the cheapest frontier node, guaranteeing the cheapest path under the
+// cell costs supplied through WithCosts. Without costs it behaves like BFS.
+// Returns a Result with path information and visited order.
+func Dijkstra(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Result, error) {
+	search, err := NewDijkstraSearch(grid, start, goal, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return search.Run(), nil
+}
+
+// NewDijkstraSearch prepares a step-by-step uniform-cost search. See Dijkstra.
+func NewDijkstraSearch(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Search, error) {
+	return newSearch(grid, start, goal, opts, searchSpec{
+		open:    newHeapFrontier(),
+		relax:   true,
+		optimal: func(*config) bool { return true },
+	})
+}
@@ -0,0 +1,113 @@
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestCosts creates a uniform cost grid with selected cells overridden
+func createTestCosts(width, height int, overrides map[maze.Point]float64) maze.CostGrid {
+	costs := make(maze.CostGrid, height)
+	for y := range costs {
+		row := make([]float64, width)
+		for x := range row {
+			row[x] = 1
+		}
+		costs[y] = row
+	}
+	for p, cost := range overrides {
+		costs[p.Y][p.X] = cost
+	}
+	return costs
+}
+
+func TestDijkstra_ValidPath(t *testing.T) {
+	grid := createTestGrid(4, 4, []maze.Point{
+		{X: 2, Y: 0},
+		{X: 2, Y: 2},
+	})
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 3, Y: 3}
+
+	result, err := Dijkstra(grid, start, goal)
+	require.NoError(t, err)
+	assert.True(t, result.Found)
+	assert.Equal(t, start, result.Path[0])
+	assert.Equal(t, goal, result.Path[len(result.Path)-1])
+	assert.Equal(t, 6, result.PathLength)
+	assert.Equal(t, 6.0, result.PathCost)
+}
+
+func TestDijkstra_NoPath(t *testing.T) {
+	grid := createTestGrid(3, 3, []maze.Point{
+		{X: 1, Y: 0},
+		{X: 1, Y: 1},
+		{X: 1, Y: 2},
+	})
+	start := maze.Point{X: 0, Y: 1}
+	goal := maze.Point{X: 2, Y: 1}
+
+	result, err := Dijkstra(grid, start, goal)
+	require.NoError(t, err)
+	assert.False(t, result.Found)
+	assert.Empty(t, result.Path)
+	assert.Greater(t, result.ExpandedNodes, 0)
+}
+
+func TestDijkstra_AvoidsExpensiveTerrain(t *testing.T) {
+	// The direct route along the top row crosses mud; the detour is longer but cheaper.
+	grid := createTestGrid(3, 2, nil)
+	costs := createTestCosts(3, 2, map[maze.Point]float64{
+		{X: 1, Y: 0}: 10,
+	})
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 0}
+
+	result, err := Dijkstra(grid, start, goal, WithCosts(costs))
+	require.NoError(t, err)
+	assert.True(t, result.Found)
+	assert.Equal(t, 4, result.PathLength)
+	assert.Equal(t, 4.0, result.PathCost)
+	assert.NotContains(t, result.Path, maze.Point{X: 1, Y: 0})
+
+	bfs, err := BFS(grid, start, goal, WithCosts(costs))
+	require.NoError(t, err)
+	assert.Equal(t, 2, bfs.PathLength)
+	assert.Equal(t, 11.0, bfs.PathCost)
+}
+
+func TestDijkstra_InvalidCosts(t *testing.T) {
+	grid := createTestGrid(3, 3, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 2}
+
+	tests := []struct {
+		name  string
+		costs maze.CostGrid
+	}{
+		{"too few rows", createTestCosts(3, 2, nil)},
+		{"short row", maze.CostGrid{{1, 1, 1}, {1, 1}, {1, 1, 1}}},
+		{"zero cost", createTestCosts(3, 3, map[maze.Point]float64{{X: 1, Y: 1}: 0})},
+		{"negative cost", createTestCosts(3, 3, map[maze.Point]float64{{X: 1, Y: 1}: -2})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Dijkstra(grid, start, goal, WithCosts(tt.costs))
+			assert.ErrorIs(t, err, ErrInvalidCosts)
+		})
+	}
+}
+
+func TestDijkstra_OutOfBoundsAndBlocked(t *testing.T) {
+	grid := createTestGrid(3, 3, []maze.Point{{X: 2, Y: 2}})
+
+	_, err := Dijkstra(grid, maze.Point{X: -1, Y: 0}, maze.Point{X: 0, Y: 0})
+	assert.ErrorIs(t, err, ErrOutOfBounds)
+
+	_, err = Dijkstra(grid, maze.Point{X: 0, Y: 0}, maze.Point{X: 2, Y: 2})
+	assert.ErrorIs(t, err, ErrBlocked)
+}
@@ -0,0 +1,339 @@
+package algorithm
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+)
+
+// WallChange sets whether the square at Point is a wall.
+type WallChange struct {
+	Point maze.Point `json:"point"`
+	Wall  bool       `json:"wall"`
+}
+
+// Replan is the outcome of planning or replanning with a DStarLite planner.
+// Expanded counts the squares taken off the open set, some more than once,
+// and Touched the distinct squares whose distance to the goal was looked at
+// again; both cover this plan alone, including the squares a wall change
+// itself invalidated, so after an update they measure the repair rather than
+// a search from scratch.
+type Replan struct {
+	Found      bool         `json:"found"`
+	Path       []maze.Point `json:"path"`
+	PathLength int          `json:"pathLength"`
+	PathCost   float64      `json:"pathCost"`
+	Expanded   int          `json:"expanded"`
+	Touched    int          `json:"touched"`
+}
+
+// DStarLite is an incremental planner between two fixed squares of a grid
+// whose walls change over time. It runs D* Lite, searching from the goal
+// towards the start and keeping every distance to the goal it worked out, so
+// that after walls come and go only the distances the change invalidates are
+// repaired. The start never moves, so the key modifier D* Lite keeps for a
+// moving robot stays zero. It takes the movement, topology and heuristic
+// options and ignores the weight. Repairs lean on the heuristic being
+// consistent, so a heuristic that can overestimate under the movement and
+// topology, such as manhattan with diagonal steps, yields
+// ErrInadmissibleHeuristic; the polar topology yields ErrUnsupportedTopology,
+// and floors, portals, keys and doors, per-cell costs, wall breaks and moving
+// obstacles yield ErrUnsupportedReplanning. A DStarLite is not safe for concurrent use.
+type DStarLite struct {
+	grid  maze.Grid
+	cfg   *config
+	start maze.Point
+	goal  maze.Point
+
+	distance func(a, b maze.Point) float64
+	// g is the distance to the goal each square was last expanded with and
+	// rhs the one its neighbours promise; a square whose two differ waits in
+	// open. Squares missing from either map are infinitely far.
+	g    map[maze.Point]float64
+	rhs  map[maze.Point]float64
+	open *dstarQueue
+
+	expanded int
+	touched  map[maze.Point]bool
+}
+
+// NewDStarLite returns a planner from start to goal on a copy of grid. Nothing
+// is searched until the first call to Plan.
+func NewDStarLite(grid maze.Grid, start, goal maze.Point, opts ...Option) (*DStarLite, error) {
+	cfg, err := newConfig(grid, opts)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.topology == maze.TopologyPolar {
+		return nil, ErrUnsupportedTopology
+	}
+	if cfg.stairs != nil || len(cfg.portals) > 0 || cfg.keyed || cfg.costs != nil || cfg.breaks > 0 || cfg.timed() {
+		return nil, ErrUnsupportedReplanning
+	}
+	if !cfg.heuristic.admissible(cfg.movement, cfg.topology) {
+		return nil, ErrInadmissibleHeuristic
+	}
+	if !inBounds(grid, start) || !inBounds(grid, goal) {
+		return nil, ErrOutOfBounds
+	}
+	if !isWalkable(grid, start) || !isWalkable(grid, goal) {
+		return nil, ErrBlocked
+	}
+
+	d := &DStarLite{
+		grid:     make(maze.Grid, len(grid)),
+		cfg:      cfg,
+		start:    start,
+		goal:     goal,
+		g:        make(map[maze.Point]float64),
+		rhs:      map[maze.Point]float64{goal: 0},
+		open:     &dstarQueue{index: make(map[maze.Point]int)},
+		touched:  make(map[maze.Point]bool),
+		distance: cfg.distance(grid),
+	}
+	for y, row := range grid {
+		d.grid[y] = append([]int(nil), row...)
+	}
+	d.push(goal)
+	return d, nil
+}
+
+// Grid returns a copy of the grid as it stands after every wall change.
+func (d *DStarLite) Grid() maze.Grid {
+	grid := make(maze.Grid, len(d.grid))
+	for y, row := range d.grid {
+		grid[y] = append([]int(nil), row...)
+	}
+	return grid
+}
+
+// Plan brings the distances to the goal up to date, expanding only the
+// squares the wall changes since the last plan affect, and returns the
+// cheapest path from start to goal.
+func (d *DStarLite) Plan() *Replan {
+	d.reset()
+	return d.plan()
+}
+
+// plan expands squares until the start's distance to the goal is settled and
+// returns the path, adding to the counters rather than clearing them.
+func (d *DStarLite) plan() *Replan {
+	for d.open.Len() > 0 && (!keyAfter(d.open.items[0].key, d.key(d.start)) || d.lookup(d.rhs, d.start) != d.lookup(d.g, d.start)) {
+		u := heap.Pop(d.open).(*dstarItem).point
+		d.expanded++
+		d.touched[u] = true
+		if d.lookup(d.g, u) > d.lookup(d.rhs, u) {
+			d.g[u] = d.rhs[u]
+			for _, s := range d.neighbours(u) {
+				d.update(s)
+			}
+		} else {
+			delete(d.g, u)
+			d.update(u)
+			for _, s := range d.neighbours(u) {
+				d.update(s)
+			}
+		}
+	}
+	return d.result()
+}
+
+// Update applies changes to the grid and replans. A change outside the grid
+// yields ErrOutOfBounds and a wall on the start or goal ErrBlocked; the grid
+// is left untouched by a rejected batch.
+func (d *DStarLite) Update(changes []WallChange) (*Replan, error) {
+	for _, ch := range changes {
+		if !inBounds(d.grid, ch.Point) {
+			return nil, ErrOutOfBounds
+		}
+		if ch.Wall && (ch.Point == d.start || ch.Point == d.goal) {
+			return nil, ErrBlocked
+		}
+	}
+
+	d.reset()
+	var changed []maze.Point
+	for _, ch := range changes {
+		tile := 0
+		if ch.Wall {
+			tile = wall
+		}
+		if d.grid[ch.Point.Y][ch.Point.X] != tile {
+			d.grid[ch.Point.Y][ch.Point.X] = tile
+			changed = append(changed, ch.Point)
+		}
+	}
+	// A square turning into a wall or back changes the cost of every move into
+	// or out of it, and with diagonal moves of those cutting its corner, all of
+	// which start on the square or one of its neighbours.
+	for _, p := range changed {
+		d.update(p)
+		for _, s := range d.neighbours(p) {
+			d.update(s)
+		}
+	}
+	return d.plan(), nil
+}
+
+// reset clears the counters so that they cover one plan or update alone.
+func (d *DStarLite) reset() {
+	d.expanded = 0
+	clear(d.touched)
+}
+
+// update works out again how far u is from the goal through its neighbours
+// and queues it if that no longer matches its expanded distance.
+func (d *DStarLite) update(u maze.Point) {
+	d.touched[u] = true
+	if u != d.goal {
+		best := math.Inf(1)
+		for _, s := range d.neighbours(u) {
+			best = math.Min(best, d.cost(u, s)+d.lookup(d.g, s))
+		}
+		if math.IsInf(best, 1) {
+			delete(d.rhs, u)
+		} else {
+			d.rhs[u] = best
+		}
+	}
+	d.open.remove(u)
+	if d.lookup(d.g, u) != d.lookup(d.rhs, u) {
+		d.push(u)
+	}
+}
+
+// result follows the cheapest moves from start down to the goal.
+func (d *DStarLite) result() *Replan {
+	result := &Replan{Path: []maze.Point{}, Expanded: d.expanded, Touched: len(d.touched)}
+	if math.IsInf(d.lookup(d.g, d.start), 1) {
+		return result
+	}
+	result.Path = append(result.Path, d.start)
+	for current := d.start; current != d.goal; {
+		next, best := current, math.Inf(1)
+		for _, s := range d.neighbours(current) {
+			if total := d.cost(current, s) + d.lookup(d.g, s); total < best {
+				next, best = s, total
+			}
+		}
+		if next == current || len(result.Path) > len(d.grid)*len(d.grid[0]) {
+			return &Replan{Path: []maze.Point{}, Expanded: d.expanded, Touched: len(d.touched)}
+		}
+		result.PathCost += d.cost(current, next)
+		result.Path = append(result.Path, next)
+		current = next
+	}
+	result.Found = true
+	result.PathLength = len(result.Path) - 1
+	return result
+}
+
+// neighbours lists the squares one step from p in any allowed direction,
+// walls included. Every move on the grids DStarLite plans over can be made
+// both ways, so these are at once the squares p can move to and those that
+// can move to p.
+func (d *DStarLite) neighbours(p maze.Point) []maze.Point {
+	var out []maze.Point
+	for _, dir := range d.cfg.directions() {
+		if q, ok := d.cfg.normalize(d.grid, maze.Point{X: p.X + dir.X, Y: p.Y + dir.Y}); ok && q != p {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+// cost is the length of the move from u to its neighbour v, or infinity if
+// either square is a wall or the move cuts a corner it may not.
+func (d *DStarLite) cost(u, v maze.Point) float64 {
+	if !isWalkable(d.grid, u) {
+		return math.Inf(1)
+	}
+	for _, dir := range d.cfg.directions() {
+		if q, ok := d.cfg.normalize(d.grid, maze.Point{X: u.X + dir.X, Y: u.Y + dir.Y}); ok && q == v && d.cfg.canStep(d.grid, u, dir) {
+			return d.cfg.stepLength(dir)
+		}
+	}
+	return math.Inf(1)
+}
+
+// lookup reads a distance, missing squares being infinitely far.
+func (d *DStarLite) lookup(m map[maze.Point]float64, p maze.Point) float64 {
+	if v, ok := m[p]; ok {
+		return v
+	}
+	return math.Inf(1)
+}
+
+// key ranks u in the open set: by the smaller of its two distances plus the
+// heuristic from the start, then by that distance alone.
+func (d *DStarLite) key(u maze.Point) [2]float64 {
+	k := math.Min(d.lookup(d.g, u), d.lookup(d.rhs, u))
+	return [2]float64{k + d.distance(d.start, u), k}
+}
+
+// keyEpsilon is how far apart two keys must be to count as different. Sums
+// of diagonal steps round differently depending on the order they are added
+// in, so keys equal on paper can differ by a few ulps.
+const keyEpsilon = 1e-9
+
+// keyAfter reports whether key a ranks clearly after key b, ignoring
+// differences within keyEpsilon.
+func keyAfter(a, b [2]float64) bool {
+	if math.Abs(a[0]-b[0]) > keyEpsilon {
+		return a[0] > b[0]
+	}
+	return a[1]-b[1] > keyEpsilon
+}
+
+func (d *DStarLite) push(u maze.Point) {
+	heap.Push(d.open, &dstarItem{point: u, key: d.key(u)})
+}
+
+// dstarItem is a square waiting in the open set of a DStarLite planner.
+type dstarItem struct {
+	point maze.Point
+	key   [2]float64
+}
+
+// dstarQueue is the open set of a DStarLite planner, ordered by key, which
+// also finds and removes a square wherever it sits in the heap.
+type dstarQueue struct {
+	items []*dstarItem
+	index map[maze.Point]int
+}
+
+func (q *dstarQueue) less(a, b [2]float64) bool {
+	return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+}
+
+func (q *dstarQueue) Len() int { return len(q.items) }
+
+func (q *dstarQueue) Less(i, j int) bool { return q.less(q.items[i].key, q.items[j].key) }
+
+func (q *dstarQueue) Swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.index[q.items[i].point] = i
+	q.index[q.items[j].point] = j
+}
+
+func (q *dstarQueue) Push(x any) {
+	item := x.(*dstarItem)
+	q.index[item.point] = len(q.items)
+	q.items = append(q.items, item)
+}
+
+func (q *dstarQueue) Pop() any {
+	n := len(q.items)
+	item := q.items[n-1]
+	q.items = q.items[:n-1]
+	delete(q.index, item.point)
+	return item
+}
+
+// remove drops p from the queue if it is waiting there.
+func (q *dstarQueue) remove(p maze.Point) {
+	if i, ok := q.index[p]; ok {
+		heap.Remove(q, i)
+	}
+}
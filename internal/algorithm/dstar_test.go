@@ -0,0 +1,287 @@
+package algorithm
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertWalk checks that path runs from start to goal over open squares, one
+// allowed step at a time.
+func assertWalk(t *testing.T, grid maze.Grid, path []maze.Point, start, goal maze.Point, opts ...Option) {
+	t.Helper()
+	cfg, err := newConfig(grid, opts)
+	require.NoError(t, err)
+	require.NotEmpty(t, path)
+	assert.Equal(t, start, path[0])
+	assert.Equal(t, goal, path[len(path)-1])
+	for i := 1; i < len(path); i++ {
+		ok := false
+		for _, dir := range cfg.directions() {
+			q, inside := cfg.normalize(grid, maze.Point{X: path[i-1].X + dir.X, Y: path[i-1].Y + dir.Y})
+			if inside && q == path[i] && cfg.canStep(grid, path[i-1], dir) {
+				ok = true
+			}
+		}
+		require.True(t, ok, "illegal step from %v to %v", path[i-1], path[i])
+	}
+}
+
+func TestDStarLite_FirstPlanMatchesDijkstra(t *testing.T) {
+	grid := maze.Grid{
+		{0, 0, 0, 0, 0},
+		{1, 1, 1, 1, 0},
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 1, 1},
+		{0, 0, 0, 0, 0},
+	}
+	start, goal := maze.Point{X: 0, Y: 0}, maze.Point{X: 4, Y: 4}
+
+	planner, err := NewDStarLite(grid, start, goal)
+	require.NoError(t, err)
+	plan := planner.Plan()
+	require.True(t, plan.Found)
+	assertWalk(t, grid, plan.Path, start, goal)
+	assert.Equal(t, 16, plan.PathLength)
+	assert.Equal(t, 16.0, plan.PathCost)
+	assert.Positive(t, plan.Expanded)
+
+	again := planner.Plan()
+	assert.Equal(t, plan.Path, again.Path)
+	assert.Zero(t, again.Expanded, "nothing changed, so nothing is expanded")
+}
+
+func TestDStarLite_DiagonalRoundingStillRepairs(t *testing.T) {
+	// Sums of diagonal steps round differently depending on the order they
+	// are added in, so keys that are equal on paper differ by an ulp or two.
+	grid := maze.Grid{
+		{0, 0, 0, 0, 0, 0, 1, 1, 0},
+		{0, 0, 1, 0, 0, 0, 0, 0, 1},
+		{0, 0, 0, 0, 0, 1, 0, 0, 0},
+		{0, 0, 0, 0, 1, 0, 0, 0, 0},
+	}
+	start, goal := maze.Point{X: 0, Y: 0}, maze.Point{X: 8, Y: 3}
+	opts := []Option{WithMovement(MovementEightWay)}
+
+	planner, err := NewDStarLite(grid, start, goal, opts...)
+	require.NoError(t, err)
+	planner.Plan()
+	plan, err := planner.Update([]WallChange{
+		{Point: maze.Point{X: 3, Y: 2}, Wall: true},
+		{Point: maze.Point{X: 7, Y: 2}, Wall: true},
+		{Point: maze.Point{X: 5, Y: 2}, Wall: false},
+	})
+	require.NoError(t, err)
+
+	want, err := Dijkstra(planner.Grid(), start, goal, opts...)
+	require.NoError(t, err)
+	require.True(t, want.Found)
+	require.True(t, plan.Found)
+	assert.InDelta(t, want.PathCost, plan.PathCost, 1e-9)
+	assertWalk(t, planner.Grid(), plan.Path, start, goal, opts...)
+}
+
+// assertRepairsMatchDijkstra plans an open size×size grid from corner to
+// corner, then for every seed applies rounds of random wall changes and checks
+// each repaired path against Dijkstra on the edited grid.
+func assertRepairsMatchDijkstra(t *testing.T, size int, seeds int64, opts ...Option) {
+	t.Helper()
+	grid := make(maze.Grid, size)
+	for y := range grid {
+		grid[y] = make([]int, size)
+	}
+	start, goal := maze.Point{X: 0, Y: 0}, maze.Point{X: size - 1, Y: size - 1}
+
+	for seed := int64(1); seed <= seeds; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		planner, err := NewDStarLite(grid, start, goal, opts...)
+		require.NoError(t, err)
+		planner.Plan()
+
+		for round := 0; round < 40; round++ {
+			var changes []WallChange
+			for range 1 + rng.Intn(4) {
+				p := maze.Point{X: rng.Intn(size), Y: rng.Intn(size)}
+				if p != start && p != goal {
+					changes = append(changes, WallChange{Point: p, Wall: rng.Intn(3) > 0})
+				}
+			}
+			plan, err := planner.Update(changes)
+			require.NoError(t, err)
+
+			current := planner.Grid()
+			want, err := Dijkstra(current, start, goal, opts...)
+			require.NoError(t, err)
+			require.Equal(t, want.Found, plan.Found, "seed %d round %d", seed, round)
+			if want.Found {
+				assert.InDelta(t, want.PathCost, plan.PathCost, 1e-9, "seed %d round %d", seed, round)
+				assertWalk(t, current, plan.Path, start, goal, opts...)
+			}
+		}
+	}
+}
+
+func TestDStarLite_RandomWallChangesMatchDijkstra(t *testing.T) {
+	cases := []struct {
+		name string
+		opts []Option
+	}{
+		{"four-way", nil},
+		{"eight-way", []Option{WithMovement(MovementEightWay)}},
+		{"eight-way corner cutting", []Option{WithMovement(MovementEightWayCornerCutting)}},
+		{"torus", []Option{WithTopology(maze.TopologyTorus)}},
+		{"hex", []Option{WithTopology(maze.TopologyHex)}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertRepairsMatchDijkstra(t, 12, 30, tc.opts...)
+		})
+	}
+}
+
+func TestDStarLite_EightWayHeuristics(t *testing.T) {
+	// Manhattan overestimates a diagonal step, and with it repairs settled
+	// on paths longer than the cheapest.
+	grid := maze.Grid{{0, 0}, {0, 0}}
+	_, err := NewDStarLite(grid, maze.Point{}, maze.Point{X: 1, Y: 1},
+		WithMovement(MovementEightWay), WithHeuristic(HeuristicManhattan))
+	require.ErrorIs(t, err, ErrInadmissibleHeuristic)
+
+	for _, h := range Heuristics {
+		t.Run(string(h), func(t *testing.T) {
+			opts := []Option{WithMovement(MovementEightWay), WithHeuristic(h)}
+			if !h.admissible(MovementEightWay, maze.TopologySquare) {
+				_, err := NewDStarLite(grid, maze.Point{}, maze.Point{X: 1, Y: 1}, opts...)
+				assert.ErrorIs(t, err, ErrInadmissibleHeuristic)
+				return
+			}
+			assertRepairsMatchDijkstra(t, 12, 10, opts...)
+		})
+	}
+}
+
+func TestDStarLite_RepairTouchesLessThanRerun(t *testing.T) {
+	// Corridors two squares high winding down a 20x14 grid.
+	const width, height = 20, 14
+	grid := make(maze.Grid, height)
+	for y := range grid {
+		grid[y] = make([]int, width)
+		if y%3 == 2 {
+			for x := range grid[y] {
+				grid[y][x] = wall
+			}
+			gap := width - 1
+			if y%6 == 5 {
+				gap = 0
+			}
+			grid[y][gap] = 0
+		}
+	}
+	start, goal := maze.Point{X: 0, Y: 0}, maze.Point{X: 0, Y: height - 1}
+
+	planner, err := NewDStarLite(grid, start, goal)
+	require.NoError(t, err)
+	first := planner.Plan()
+	require.True(t, first.Found)
+
+	// A wall in the first corridor, far from the goal the search started at,
+	// only pushes the path onto the corridor's other row.
+	changes := []WallChange{{Point: maze.Point{X: 5, Y: 0}, Wall: true}, {Point: maze.Point{X: 9, Y: 1}, Wall: true}}
+	plan, err := planner.Update(changes)
+	require.NoError(t, err)
+	require.True(t, plan.Found)
+
+	fresh, err := NewDStarLite(planner.Grid(), start, goal)
+	require.NoError(t, err)
+	rerun := fresh.Plan()
+	assert.Equal(t, rerun.PathCost, plan.PathCost)
+	assert.Greater(t, plan.PathCost, first.PathCost)
+	assert.Less(t, plan.Expanded*4, rerun.Expanded)
+	assert.Less(t, plan.Touched*4, rerun.Touched)
+}
+
+func TestDStarLite_UpdateCountsItsOwnRepair(t *testing.T) {
+	grid := make(maze.Grid, 5)
+	for y := range grid {
+		grid[y] = make([]int, 5)
+	}
+	start, goal := maze.Point{X: 0, Y: 0}, maze.Point{X: 4, Y: 4}
+	opts := []Option{WithMovement(MovementEightWay)}
+
+	planner, err := NewDStarLite(grid, start, goal, opts...)
+	require.NoError(t, err)
+	first := planner.Plan()
+	require.True(t, first.Found)
+
+	// Off the diagonal the path takes, so the path stays, but the wall and its
+	// eight neighbours all have their distance worked out again.
+	plan, err := planner.Update([]WallChange{{Point: maze.Point{X: 3, Y: 1}, Wall: true}})
+	require.NoError(t, err)
+	require.True(t, plan.Found)
+	assert.Equal(t, first.PathCost, plan.PathCost)
+	assert.GreaterOrEqual(t, plan.Touched, 9)
+}
+
+func TestDStarLite_BlockAndReopen(t *testing.T) {
+	grid := maze.Grid{
+		{0, 0, 0},
+		{1, 0, 1},
+		{0, 0, 0},
+	}
+	start, goal := maze.Point{X: 0, Y: 0}, maze.Point{X: 0, Y: 2}
+	gap := maze.Point{X: 1, Y: 1}
+
+	planner, err := NewDStarLite(grid, start, goal)
+	require.NoError(t, err)
+	require.True(t, planner.Plan().Found)
+
+	plan, err := planner.Update([]WallChange{{Point: gap, Wall: true}})
+	require.NoError(t, err)
+	assert.False(t, plan.Found)
+	assert.Empty(t, plan.Path)
+	assert.Equal(t, wall, planner.Grid()[gap.Y][gap.X])
+
+	plan, err = planner.Update([]WallChange{{Point: gap, Wall: false}})
+	require.NoError(t, err)
+	require.True(t, plan.Found)
+	assert.Equal(t, 4, plan.PathLength)
+}
+
+func TestDStarLite_Errors(t *testing.T) {
+	grid := maze.Grid{
+		{0, 0, 0},
+		{0, 1, 0},
+	}
+	start, goal := maze.Point{X: 0, Y: 0}, maze.Point{X: 2, Y: 1}
+
+	_, err := NewDStarLite(grid, start, maze.Point{X: 3})
+	assert.ErrorIs(t, err, ErrOutOfBounds)
+
+	_, err = NewDStarLite(grid, start, maze.Point{X: 1, Y: 1})
+	assert.ErrorIs(t, err, ErrBlocked)
+
+	_, err = NewDStarLite(grid, start, goal, WithWallBreaks(1))
+	assert.ErrorIs(t, err, ErrUnsupportedReplanning)
+
+	_, err = NewDStarLite(grid, start, goal, WithCosts(maze.CostGrid{{1, 1, 1}, {1, 1, 1}}))
+	assert.ErrorIs(t, err, ErrUnsupportedReplanning)
+
+	_, err = NewDStarLite(grid, start, goal, WithObstacles([]Obstacle{{Trajectory: []maze.Point{{X: 1}}}}))
+	assert.ErrorIs(t, err, ErrUnsupportedReplanning)
+
+	keyed := maze.Grid{{0, maze.Key(1), 0}}
+	_, err = NewDStarLite(keyed, start, maze.Point{X: 2})
+	assert.ErrorIs(t, err, ErrUnsupportedReplanning)
+
+	planner, err := NewDStarLite(grid, start, goal)
+	require.NoError(t, err)
+	_, err = planner.Update([]WallChange{{Point: maze.Point{X: 1}, Wall: true}, {Point: maze.Point{Y: 2}, Wall: true}})
+	assert.ErrorIs(t, err, ErrOutOfBounds)
+	assert.Equal(t, 0, planner.Grid()[0][1], "a rejected batch changes nothing")
+
+	_, err = planner.Update([]WallChange{{Point: goal, Wall: true}})
+	assert.ErrorIs(t, err, ErrBlocked)
+}
@@ -7,4 +7,50 @@ var (
 	ErrOutOfBounds = errors.New("point outside grid bounds")
 	// ErrBlocked indicates the coordinate is not walkable.
 	ErrBlocked = errors.New("point is blocked")
+	// ErrInvalidCosts indicates a cost grid does not match its grid or holds a
+	// non-positive cost.
+	ErrInvalidCosts = errors.New("costs must match the grid and be positive")
+	// ErrUnknownMovement indicates an unsupported movement model.
+	ErrUnknownMovement = errors.New("movement must be one of: 4-way, 8-way, 8-way-corner-cutting")
+	// ErrUnknownHeuristic indicates an unsupported heuristic.
+	ErrUnknownHeuristic = errors.New("heuristic must be one of: manhattan, euclidean, chebyshev, octile, hex, radial, zero")
+	// ErrUnsupportedTopology indicates a solver or movement model that cannot
+	// run on the requested grid topology.
+	ErrUnsupportedTopology = errors.New("topology is not supported by this solver or movement")
+	// ErrUnsupportedFloors indicates multiple floors given to a solver, or with
+	// options, that cannot handle them.
+	ErrUnsupportedFloors = errors.New("multi-level mazes are not supported by this solver or with per-cell costs")
+	// ErrUnsupportedKeys indicates keys or doors in a grid given to a solver
+	// that cannot track the keys held.
+	ErrUnsupportedKeys = errors.New("keys and doors are not supported by this solver")
+	// ErrUnsupportedWallBreaks indicates wall breaks asked of a solver that
+	// cannot track the walls broken.
+	ErrUnsupportedWallBreaks = errors.New("wall breaks are not supported by this solver")
+	// ErrInvalidWallBreaks indicates a negative number of wall breaks.
+	ErrInvalidWallBreaks = errors.New("wall breaks must be a non-negative number")
+	// ErrUnsupportedObstacles indicates moving obstacles given to a solver that
+	// cannot track time.
+	ErrUnsupportedObstacles = errors.New("moving obstacles are not supported by this solver")
+	// ErrInvalidObstacles indicates an obstacle with an empty trajectory or one
+	// that leaves the grid.
+	ErrInvalidObstacles = errors.New("obstacles must move along non-empty trajectories inside the grid")
+	// ErrObstacleCycle indicates moving obstacles that take too long to repeat.
+	ErrObstacleCycle = errors.New("moving obstacles must repeat within 4096 time steps")
+	// ErrInvalidAgents indicates no agents, or agents sharing a start or a goal.
+	ErrInvalidAgents = errors.New("agents must number at least one, with distinct starts and distinct goals")
+	// ErrUnsupportedReplanning indicates a maze or options incremental
+	// replanning cannot keep up to date.
+	ErrUnsupportedReplanning = errors.New("incremental replanning only supports walls and open squares on a single floor, without portals, keys, costs, wall breaks or obstacles")
+	// ErrInadmissibleHeuristic indicates a heuristic that can overestimate the
+	// remaining cost under the movement and topology asked of a solver that
+	// needs it never to.
+	ErrInadmissibleHeuristic = errors.New("heuristic overestimates the remaining cost for this movement and topology")
+	// ErrInvalidPortalCost indicates a negative or non-finite portal cost.
+	ErrInvalidPortalCost = errors.New("portal cost must be a non-negative number")
+	// ErrInvalidWeight indicates a negative or non-finite heuristic weight.
+	ErrInvalidWeight = errors.New("weight must be a non-negative number")
+	// ErrInvalidSolver indicates a solver was registered without a usable name.
+	ErrInvalidSolver = errors.New("solver name must not be empty")
+	// ErrDuplicateSolver indicates a solver name or alias is already registered.
+	ErrDuplicateSolver = errors.New("solver already registered")
 )
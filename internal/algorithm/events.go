@@ -0,0 +1,32 @@
+package algorithm
+
+import "github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+
+// EventKind identifies what happened during a single search step.
+type EventKind string
+
+const (
+	// EventPush reports a node entering the open set for the first time.
+	EventPush EventKind = "push"
+	// EventRelax reports an open node receiving a cheaper cost and a new parent.
+	EventRelax EventKind = "relax"
+	// EventPop reports a node leaving the open set and joining the closed set.
+	EventPop EventKind = "pop"
+	// EventGoalReached reports that the goal was popped and the search is over.
+	EventGoalReached EventKind = "goalReached"
+)
+
+// Event is emitted by a Search for every change to its open or closed set.
+// G is the cost from the start, H the heuristic estimate to the goal and F the
+// priority the node is ordered by, g + w·h for weighted informed searches.
+// Bidirectional searches set Side and measure G and H from that side's end; their
+// goalReached event marks the point where the two halves met, with G the path cost. Parent is set for push and relax events.
+type Event struct {
+	Kind   EventKind   `json:"kind"`
+	Point  maze.Point  `json:"point"`
+	Parent *maze.Point `json:"parent,omitempty"`
+	G      float64     `json:"g"`
+	H      float64     `json:"h"`
+	F      float64     `json:"f"`
+	Side   Side        `json:"side,omitempty"`
+}
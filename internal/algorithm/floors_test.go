@@ -0,0 +1,93 @@
+package algorithm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolvers_MultiLevel(t *testing.T) {
+	seed := int64(12)
+	generated, err := maze.NewMultiLevelGenerator(3).Generate(context.Background(), 8, 6, &seed)
+	require.NoError(t, err)
+	floors := generated.Floors
+
+	start := maze.Point{X: 1, Y: 1}
+	goal := maze.Point{X: 15, Y: 11, Z: 2}
+	opts := []Option{WithFloors(floors[1:], generated.Stairs)}
+	want, err := Dijkstra(floors[0], start, goal, opts...)
+	require.NoError(t, err)
+	require.True(t, want.Found)
+
+	stairs := make(map[maze.Point]bool)
+	for _, s := range generated.Stairs {
+		stairs[s] = true
+	}
+
+	registry := NewDefaultRegistry()
+	for _, info := range registry.List() {
+		if !info.MultiLevel {
+			continue
+		}
+		t.Run(info.Name, func(t *testing.T) {
+			solver, _ := registry.Lookup(info.Name)
+			result, err := solver.Solve(floors[0], start, goal, opts...)
+			require.NoError(t, err)
+			require.True(t, result.Found)
+			assert.Equal(t, start, result.Path[0])
+			assert.Equal(t, goal, result.Path[len(result.Path)-1])
+			for i := 1; i < len(result.Path); i++ {
+				a, b := result.Path[i-1], result.Path[i]
+				require.Equal(t, 0, floors[b.Z][b.Y][b.X], "step %d enters a wall", i)
+				if a.Z == b.Z {
+					require.Equal(t, 1.0, manhattan(a, b), "step %d is not a single move", i)
+					continue
+				}
+				require.Equal(t, a.X, b.X)
+				require.Equal(t, a.Y, b.Y)
+				require.True(t, stairs[maze.Point{X: a.X, Y: a.Y, Z: min(a.Z, b.Z)}], "step %d changes floor without stairs", i)
+			}
+			if info.Optimal {
+				assert.Equal(t, want.PathLength, result.PathLength)
+			}
+		})
+	}
+}
+
+func TestAStar_MultiLevelHeuristic(t *testing.T) {
+	open := createTestGrid(5, 5, nil)
+	stairs := []maze.Point{{X: 4, Y: 4}, {X: 0, Y: 0, Z: 1}}
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 4, Y: 4, Z: 2}
+
+	result, err := AStar(open, start, goal, WithFloors([]maze.Grid{open, open}, stairs))
+	require.NoError(t, err)
+	assert.True(t, result.Optimal)
+	assert.Equal(t, 8+1+8+1+8, result.PathLength, "cross each floor to reach the next stairs")
+	assert.Equal(t, 5.0, HeuristicManhattan.distance()(maze.Point{X: 1, Y: 1}, maze.Point{X: 2, Y: 2, Z: 3}))
+	assert.Equal(t, 0.0, HeuristicZero.distance()(maze.Point{}, maze.Point{Z: 3}))
+}
+
+func TestSolvers_MultiLevelErrors(t *testing.T) {
+	grid := createTestGrid(3, 3, nil)
+	floors := WithFloors([]maze.Grid{grid}, []maze.Point{{X: 1, Y: 1}})
+	start := maze.Point{X: 0, Y: 0}
+
+	_, err := JPS(grid, start, maze.Point{X: 2, Y: 2, Z: 1}, floors)
+	assert.ErrorIs(t, err, ErrUnsupportedFloors)
+
+	_, err = BFS(grid, start, maze.Point{X: 2, Y: 2, Z: 2}, floors)
+	assert.ErrorIs(t, err, ErrOutOfBounds)
+
+	_, err = Dijkstra(grid, start, maze.Point{X: 2, Y: 2, Z: 1}, floors, WithCosts(createTestCosts(3, 3, nil)))
+	assert.ErrorIs(t, err, ErrUnsupportedFloors)
+
+	_, err = BFS(grid, start, maze.Point{X: 2, Y: 2}, WithFloors([]maze.Grid{createTestGrid(2, 2, nil)}, nil))
+	assert.ErrorIs(t, err, maze.ErrInvalidFloors)
+
+	_, err = TerrainAStar(context.Background(), &gridTerrain{grid: grid}, start, maze.Point{X: 2, Y: 2}, 10, floors)
+	assert.ErrorIs(t, err, ErrUnsupportedFloors)
+}
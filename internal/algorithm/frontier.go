@@ -0,0 +1,61 @@
+package algorithm
+
+import "container/heap"
+
+// frontier is the open set of a Search. Each solver picks the discipline that
+// defines it: FIFO for BFS, LIFO for DFS and a min-heap for best-first search.
+type frontier interface {
+	push(n *node)
+	pop() *node
+	// peek returns the node pop would return next without removing it.
+	peek() *node
+	len() int
+}
+
+type queueFrontier struct {
+	items []*node
+}
+
+func (q *queueFrontier) push(n *node) { q.items = append(q.items, n) }
+
+func (q *queueFrontier) pop() *node {
+	n := q.items[0]
+	q.items = q.items[1:]
+	return n
+}
+
+func (q *queueFrontier) peek() *node { return q.items[0] }
+
+func (q *queueFrontier) len() int { return len(q.items) }
+
+type stackFrontier struct {
+	items []*node
+}
+
+func (s *stackFrontier) push(n *node) { s.items = append(s.items, n) }
+
+func (s *stackFrontier) pop() *node {
+	n := s.items[len(s.items)-1]
+	s.items = s.items[:len(s.items)-1]
+	return n
+}
+
+func (s *stackFrontier) peek() *node { return s.items[len(s.items)-1] }
+
+func (s *stackFrontier) len() int { return len(s.items) }
+
+type heapFrontier struct {
+	pq *priorityQueue
+}
+
+func newHeapFrontier() *heapFrontier {
+	return &heapFrontier{pq: newPriorityQueue()}
+}
+
+func (h *heapFrontier) push(n *node) { heap.Push(h.pq, n) }
+
+func (h *heapFrontier) pop() *node { return heap.Pop(h.pq).(*node) }
+
+func (h *heapFrontier) peek() *node { return (*h.pq)[0] }
+
+func (h *heapFrontier) len() int { return h.pq.Len() }
@@ -0,0 +1,264 @@
+package algorithm
+
+import (
+	"math"
+	"strings"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+)
+
+// Heuristic names the distance estimate informed solvers such as A* and JPS use
+// to rank open nodes.
+type Heuristic string
+
+const (
+	// HeuristicManhattan sums the horizontal and vertical distance.
+	HeuristicManhattan Heuristic = "manhattan"
+	// HeuristicEuclidean is the straight-line distance.
+	HeuristicEuclidean Heuristic = "euclidean"
+	// HeuristicChebyshev is the larger of the horizontal and vertical distance.
+	HeuristicChebyshev Heuristic = "chebyshev"
+	// HeuristicOctile is the 8-connected distance with diagonal steps costing √2.
+	HeuristicOctile Heuristic = "octile"
+	// HeuristicHex is the number of steps between two tiles of a hex grid in
+	// axial coordinates.
+	HeuristicHex Heuristic = "hex"
+	// HeuristicRadial is the vertical distance alone. On polar grids that is
+	// the number of rows between two tiles, the only distance that does not
+	// depend on how the rings are split.
+	HeuristicRadial Heuristic = "radial"
+	// HeuristicZero estimates nothing, which turns A* into Dijkstra.
+	HeuristicZero Heuristic = "zero"
+)
+
+// Heuristics lists every supported heuristic.
+var Heuristics = []Heuristic{
+	HeuristicManhattan,
+	HeuristicEuclidean,
+	HeuristicChebyshev,
+	HeuristicOctile,
+	HeuristicHex,
+	HeuristicRadial,
+	HeuristicZero,
+}
+
+// ParseHeuristic converts a heuristic name into a Heuristic. An empty name is
+// returned as is and lets the solver pick the default for its movement model.
+func ParseHeuristic(name string) (Heuristic, error) {
+	if name == "" {
+		return "", nil
+	}
+	for _, h := range Heuristics {
+		if strings.EqualFold(name, string(h)) {
+			return h, nil
+		}
+	}
+	return "", ErrUnknownHeuristic
+}
+
+// defaultHeuristic is the tightest admissible heuristic for a movement model on
+// a topology.
+func defaultHeuristic(m Movement, t maze.Topology) Heuristic {
+	switch t {
+	case maze.TopologyHex:
+		return HeuristicHex
+	case maze.TopologyPolar:
+		return HeuristicRadial
+	}
+	if m.Diagonal() {
+		return HeuristicOctile
+	}
+	return HeuristicManhattan
+}
+
+func (h Heuristic) valid() bool {
+	for _, known := range Heuristics {
+		if h == known {
+			return true
+		}
+	}
+	return false
+}
+
+// distance returns the function that measures h between two points. Floors
+// are only ever changed by stairs, each a step of length 1 that goes nowhere
+// else, so adding the number of floors between the points keeps an admissible
+// heuristic admissible and a consistent one consistent.
+func (h Heuristic) distance() func(a, b maze.Point) float64 {
+	planar := h.planar()
+	if h == HeuristicZero {
+		return planar
+	}
+	return func(a, b maze.Point) float64 {
+		return planar(a, b) + math.Abs(float64(a.Z-b.Z))
+	}
+}
+
+// distance returns the function that measures the configured heuristic
+// between two points of the maze whose ground floor is grid. On a torus the
+// second point is first moved to its copy across the seams nearest the first
+// along each axis, which turns every heuristic but hex into the matching
+// distance on the torus: one that wraps can only ever be shorter.
+func (c *config) distance(grid maze.Grid) func(a, b maze.Point) float64 {
+	distance := c.heuristic.distance()
+	if c.topology != maze.TopologyTorus {
+		return distance
+	}
+	width, height := len(grid[0]), len(grid)
+	return func(a, b maze.Point) float64 {
+		b.X = a.X + wrapOffset(b.X-a.X, width)
+		b.Y = a.Y + wrapOffset(b.Y-a.Y, height)
+		return distance(a, b)
+	}
+}
+
+// estimate returns the heuristic informed solvers rank p with on their way to
+// goal: the configured distance scaled by the cheapest cell cost. Portals can
+// take a walker further than any distance allows, so with portals the estimate
+// is the cheapest way to goal in a relaxed maze where every portal end and goal
+// are joined by moves costing their scaled distance, and each portal still
+// joins its ends at the portal cost. Every real route reaches its first portal
+// in no less than the scaled distance and the rest is covered the same way, so
+// an admissible heuristic stays admissible; each estimate is a minimum of
+// consistent ones, so it stays consistent too. Costs from each portal end to
+// goal are worked out once, and every estimate then takes time linear in the
+// number of portal ends.
+func (c *config) estimate(grid maze.Grid, goal maze.Point) func(maze.Point) float64 {
+	distance := c.distance(grid)
+	direct := func(p maze.Point) float64 {
+		return c.minCost * distance(p, goal)
+	}
+	if len(c.portals) == 0 || c.heuristic == HeuristicZero {
+		return direct
+	}
+
+	ends := make([]maze.Point, 0, len(c.portals))
+	index := make(map[maze.Point]int, len(c.portals))
+	for end := range c.portals {
+		index[end] = len(ends)
+		ends = append(ends, end)
+	}
+
+	// Dijkstra from goal over the complete relaxed graph of portal ends.
+	toGoal := make([]float64, len(ends))
+	for i, end := range ends {
+		toGoal[i] = direct(end)
+	}
+	settled := make([]bool, len(ends))
+	for range ends {
+		u := -1
+		for i := range ends {
+			if !settled[i] && (u < 0 || toGoal[i] < toGoal[u]) {
+				u = i
+			}
+		}
+		settled[u] = true
+		partner := index[c.portals[ends[u]]]
+		toGoal[partner] = math.Min(toGoal[partner], c.portalCost+toGoal[u])
+		for i, end := range ends {
+			if !settled[i] {
+				toGoal[i] = math.Min(toGoal[i], c.minCost*distance(end, ends[u])+toGoal[u])
+			}
+		}
+	}
+
+	return func(p maze.Point) float64 {
+		best := direct(p)
+		for i, end := range ends {
+			best = math.Min(best, c.minCost*distance(p, end)+toGoal[i])
+		}
+		return best
+	}
+}
+
+// wrapOffset is the offset of smallest magnitude that ends where d does on a
+// ring of n positions.
+func wrapOffset(d, n int) int {
+	d = mod(d, n)
+	if d > n/2 {
+		d -= n
+	}
+	return d
+}
+
+// planar returns the function that measures h between two points on the same
+// floor.
+func (h Heuristic) planar() func(a, b maze.Point) float64 {
+	switch h {
+	case HeuristicEuclidean:
+		return euclidean
+	case HeuristicChebyshev:
+		return chebyshev
+	case HeuristicOctile:
+		return octile
+	case HeuristicHex:
+		return hexDistance
+	case HeuristicRadial:
+		return radial
+	case HeuristicZero:
+		return func(maze.Point, maze.Point) float64 { return 0 }
+	default:
+		return manhattan
+	}
+}
+
+// admissible reports whether h never overestimates the remaining cost under
+// movement model m on topology t. Each admissible heuristic here is also
+// consistent, so the closed set never has to be reopened.
+func (h Heuristic) admissible(m Movement, t maze.Topology) bool {
+	if h == HeuristicRadial || h == HeuristicZero {
+		// No step on any topology moves more than one row.
+		return true
+	}
+	if t == maze.TopologyPolar {
+		// Columns of different rows are unrelated, so only rows can be compared.
+		return false
+	}
+	if t == maze.TopologyHex {
+		// A hex step moves one unit along up to two axial axes at once.
+		return h == HeuristicHex || h == HeuristicChebyshev
+	}
+	if t == maze.TopologyTorus && h == HeuristicHex {
+		// The copy of the goal nearest along each axis need not be the one
+		// nearest in hex distance.
+		return false
+	}
+	if h == HeuristicManhattan || h == HeuristicHex {
+		return !m.Diagonal()
+	}
+	return true
+}
+
+func manhattan(a, b maze.Point) float64 {
+	dx := math.Abs(float64(a.X - b.X))
+	dy := math.Abs(float64(a.Y - b.Y))
+	return dx + dy
+}
+
+func euclidean(a, b maze.Point) float64 {
+	dx := float64(a.X - b.X)
+	dy := float64(a.Y - b.Y)
+	return math.Hypot(dx, dy)
+}
+
+func chebyshev(a, b maze.Point) float64 {
+	dx := math.Abs(float64(a.X - b.X))
+	dy := math.Abs(float64(a.Y - b.Y))
+	return math.Max(dx, dy)
+}
+
+// octile is the length of the shortest 8-connected path on an open grid, with
+// diagonal steps costing √2.
+func octile(a, b maze.Point) float64 {
+	dx := math.Abs(float64(a.X - b.X))
+	dy := math.Abs(float64(a.Y - b.Y))
+	return math.Max(dx, dy) + (math.Sqrt2-1)*math.Min(dx, dy)
+}
+
+func hexDistance(a, b maze.Point) float64 {
+	return float64(maze.HexDistance(a, b))
+}
+
+func radial(a, b maze.Point) float64 {
+	return math.Abs(float64(a.Y - b.Y))
+}
@@ -0,0 +1,164 @@
+package algorithm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeuristic(t *testing.T) {
+	h, err := ParseHeuristic("Euclidean")
+	require.NoError(t, err)
+	assert.Equal(t, HeuristicEuclidean, h)
+
+	h, err = ParseHeuristic("")
+	require.NoError(t, err)
+	assert.Empty(t, h)
+
+	_, err = ParseHeuristic("hamming")
+	assert.ErrorIs(t, err, ErrUnknownHeuristic)
+}
+
+func TestHeuristicDistances(t *testing.T) {
+	a := maze.Point{X: 1, Y: 1}
+	b := maze.Point{X: 4, Y: 5}
+
+	assert.Equal(t, 7.0, HeuristicManhattan.distance()(a, b))
+	assert.Equal(t, 5.0, HeuristicEuclidean.distance()(a, b))
+	assert.Equal(t, 4.0, HeuristicChebyshev.distance()(a, b))
+	assert.InDelta(t, 4+3*(math.Sqrt2-1), HeuristicOctile.distance()(a, b), 1e-9)
+	assert.Equal(t, 7.0, HeuristicHex.distance()(a, b))
+	assert.Equal(t, 4.0, HeuristicRadial.distance()(a, b))
+	assert.Equal(t, 0.0, HeuristicZero.distance()(a, b))
+}
+
+func TestAStar_HeuristicsFindOptimalPath(t *testing.T) {
+	grid := createTestGrid(8, 8, []maze.Point{
+		{X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 2, Y: 3},
+		{X: 5, Y: 4}, {X: 5, Y: 5}, {X: 5, Y: 6}, {X: 5, Y: 7},
+	})
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 7, Y: 7}
+
+	for _, movement := range Movements {
+		want, err := Dijkstra(grid, start, goal, WithMovement(movement))
+		require.NoError(t, err)
+
+		for _, h := range Heuristics {
+			if !h.admissible(movement, maze.TopologySquare) {
+				continue
+			}
+			t.Run(string(movement)+"/"+string(h), func(t *testing.T) {
+				result, err := AStar(grid, start, goal, WithMovement(movement), WithHeuristic(h))
+				require.NoError(t, err)
+				assert.True(t, result.Optimal)
+				assert.Equal(t, h, result.Heuristic)
+				assert.InDelta(t, want.PathCost, result.PathCost, 1e-9)
+			})
+		}
+	}
+}
+
+func TestAStar_DefaultHeuristic(t *testing.T) {
+	grid := createTestGrid(3, 3, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 2}
+
+	result, err := AStar(grid, start, goal)
+	require.NoError(t, err)
+	assert.Equal(t, HeuristicManhattan, result.Heuristic)
+	assert.Equal(t, 1.0, result.Weight)
+	assert.True(t, result.Optimal)
+
+	result, err = AStar(grid, start, goal, WithMovement(MovementEightWay))
+	require.NoError(t, err)
+	assert.Equal(t, HeuristicOctile, result.Heuristic)
+	assert.True(t, result.Optimal)
+
+	result, err = AStar(grid, start, goal, WithMovement(MovementEightWay), WithHeuristic(HeuristicManhattan))
+	require.NoError(t, err)
+	assert.False(t, result.Optimal)
+}
+
+func TestAStar_WeightedExpandsFewerNodes(t *testing.T) {
+	grid := createTestGrid(30, 30, nil)
+	for y := 5; y < 25; y++ {
+		grid[y][15] = 1
+	}
+	start := maze.Point{X: 0, Y: 15}
+	goal := maze.Point{X: 29, Y: 15}
+
+	plain, err := AStar(grid, start, goal, WithHeuristic(HeuristicEuclidean))
+	require.NoError(t, err)
+	weighted, err := AStar(grid, start, goal, WithHeuristic(HeuristicEuclidean), WithWeight(3))
+	require.NoError(t, err)
+
+	assert.True(t, plain.Optimal)
+	assert.False(t, weighted.Optimal)
+	assert.Equal(t, 3.0, weighted.Weight)
+	assert.True(t, weighted.Found)
+	assert.Less(t, weighted.ExpandedNodes, plain.ExpandedNodes)
+	assert.GreaterOrEqual(t, weighted.PathCost, plain.PathCost)
+}
+
+func TestAStar_ZeroWeightMatchesDijkstra(t *testing.T) {
+	grid := createTestGrid(5, 5, []maze.Point{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}})
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 4, Y: 4}
+
+	want, err := Dijkstra(grid, start, goal)
+	require.NoError(t, err)
+	result, err := AStar(grid, start, goal, WithWeight(0))
+	require.NoError(t, err)
+
+	assert.True(t, result.Optimal)
+	assert.Equal(t, want.PathCost, result.PathCost)
+}
+
+func TestAStar_InvalidHeuristicOptions(t *testing.T) {
+	grid := createTestGrid(3, 3, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 2}
+
+	_, err := AStar(grid, start, goal, WithHeuristic("hamming"))
+	assert.ErrorIs(t, err, ErrUnknownHeuristic)
+
+	for _, w := range []float64{-1, math.NaN(), math.Inf(1)} {
+		_, err = AStar(grid, start, goal, WithWeight(w))
+		assert.ErrorIs(t, err, ErrInvalidWeight)
+	}
+}
+
+func TestOptimalFlag(t *testing.T) {
+	grid := createTestGrid(3, 3, nil)
+	costs := createTestCosts(3, 3, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 2}
+
+	tests := []struct {
+		name  string
+		solve func(maze.Grid, maze.Point, maze.Point, ...Option) (*Result, error)
+		opts  []Option
+		want  bool
+	}{
+		{"bfs", BFS, nil, true},
+		{"bfs with costs", BFS, []Option{WithCosts(costs)}, false},
+		{"bfs with diagonals", BFS, []Option{WithMovement(MovementEightWay)}, false},
+		{"dfs", DFS, nil, false},
+		{"dijkstra with costs", Dijkstra, []Option{WithCosts(costs)}, true},
+		{"jps", JPS, nil, true},
+		{"jps with costs", JPS, []Option{WithCosts(costs)}, false},
+		{"jps weighted", JPS, []Option{WithWeight(2)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.solve(grid, start, goal, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result.Optimal)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package algorithm
+
+import "github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+
+// JPS performs Jump Point Search on the given grid from start to goal.
+// It runs A* over jump points only: instead of pushing every neighbour it slides in a
+// straight line until it meets the goal or a cell with a forced neighbour, skipping the
+// symmetric paths that make A* expand so many nodes on open grids. The path length is
+// the same optimal length A* finds on uniform-cost grids; cell costs only feed PathCost.
+// Result.Path is expanded back into single steps while VisitedOrder lists the jump points.
+// Every movement model from WithMovement is supported; with diagonals the classic 8-connected
+// jump rules apply and the default heuristic switches to octile distance. WithHeuristic and
+// WithWeight are honoured as in AStar. Jump rules exist for square grids only, so WithTopology
+// selecting any other topology fails with ErrUnsupportedTopology, and WithFloors fails with
+// ErrUnsupportedFloors. Jumps stop on portal squares, which are then expanded like the start:
+// in every direction, plus the teleport to their partner.
+func JPS(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Result, error) {
+	search, err := NewJPSSearch(grid, start, goal, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return search.Run(), nil
+}
+
+// NewJPSSearch prepares a step-by-step Jump Point Search. See JPS.
+func NewJPSSearch(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Search, error) {
+	return newSearch(grid, start, goal, opts, searchSpec{
+		open:       newHeapFrontier(),
+		informed:   true,
+		successors: jumpSuccessors,
+		relax:      true,
+		segments:   true,
+		optimal: func(cfg *config) bool {
+			return cfg.costs == nil && informedOptimal(cfg)
+		},
+		squareOnly:  true,
+		singleFloor: true,
+		positional:  true,
+	})
+}
+
+// jumpSuccessors jumps from p in every direction except back towards its parent,
+// and through the portal on p. A node reached through a portal came from no
+// direction, so it jumps every way.
+func jumpSuccessors(s *Search, at state) []edge {
+	p := at.Point
+	parent, hasParent := s.parents[at]
+	hasParent = hasParent && !s.teleported[at]
+
+	dirs := s.cfg.movement.directions()
+	edges := make([]edge, 0, len(dirs)+1)
+	for _, dir := range dirs {
+		if hasParent && sign(parent.X-p.X) == dir.X && sign(parent.Y-p.Y) == dir.Y {
+			continue
+		}
+		to, ok := s.jump(p, dir)
+		if !ok {
+			continue
+		}
+		edges = append(edges, edge{to: to, cost: s.segmentCost(p, to)})
+	}
+	if partner, ok := s.cfg.portals[p]; ok {
+		edges = append(edges, edge{to: partner, cost: s.cfg.portalCost, teleport: true})
+	}
+	return edges
+}
+
+// jump slides from p along dir and returns the first jump point it meets.
+func (s *Search) jump(p, dir maze.Point) (maze.Point, bool) {
+	if s.cfg.movement.Diagonal() {
+		return s.jumpDiagonal(p, dir)
+	}
+	return s.jumpOrthogonal(p, dir)
+}
+
+// jumpOrthogonal implements jumps for 4-connected movement. Horizontal moves stop at
+// cells with a forced neighbour above or below. Vertical moves additionally stop
+// wherever a horizontal jump would find something, since a canonical 4-connected
+// path only turns from vertical to horizontal there.
+func (s *Search) jumpOrthogonal(p, dir maze.Point) (maze.Point, bool) {
+	for {
+		next := maze.Point{X: p.X + dir.X, Y: p.Y + dir.Y}
+		if !s.passable(next) {
+			return maze.Point{}, false
+		}
+		if next == s.goal || s.isPortal(next) {
+			return next, true
+		}
+
+		if dir.X != 0 {
+			if s.hasForcedNeighbor(p, next, maze.Point{X: 0, Y: 1}) {
+				return next, true
+			}
+		} else {
+			if s.hasForcedNeighbor(p, next, maze.Point{X: 1, Y: 0}) {
+				return next, true
+			}
+			if _, ok := s.jumpOrthogonal(next, maze.Point{X: -1, Y: 0}); ok {
+				return next, true
+			}
+			if _, ok := s.jumpOrthogonal(next, maze.Point{X: 1, Y: 0}); ok {
+				return next, true
+			}
+		}
+
+		p = next
+	}
+}
+
+// jumpDiagonal implements the classic 8-connected jump rules. Straight moves stop at
+// forced neighbours; diagonal moves stop at forced neighbours or wherever a straight
+// jump along either of their components finds a jump point.
+func (s *Search) jumpDiagonal(p, dir maze.Point) (maze.Point, bool) {
+	cornerCutting := s.cfg.movement == MovementEightWayCornerCutting
+	for {
+		if !s.cfg.movement.canStep(s.grid, p, dir) {
+			return maze.Point{}, false
+		}
+		next := maze.Point{X: p.X + dir.X, Y: p.Y + dir.Y}
+		if next == s.goal || s.isPortal(next) {
+			return next, true
+		}
+
+		switch {
+		case dir.X != 0 && dir.Y != 0:
+			if cornerCutting && s.hasForcedDiagonalNeighbor(next, dir) {
+				return next, true
+			}
+			if _, ok := s.jumpDiagonal(next, maze.Point{X: dir.X, Y: 0}); ok {
+				return next, true
+			}
+			if _, ok := s.jumpDiagonal(next, maze.Point{X: 0, Y: dir.Y}); ok {
+				return next, true
+			}
+		case dir.X != 0:
+			if s.hasForcedNeighbor(p, next, maze.Point{X: 0, Y: 1}) {
+				return next, true
+			}
+			if cornerCutting && s.hasForcedNeighbor(next, maze.Point{X: next.X + dir.X, Y: next.Y}, maze.Point{X: 0, Y: 1}) {
+				return next, true
+			}
+		default:
+			if s.hasForcedNeighbor(p, next, maze.Point{X: 1, Y: 0}) {
+				return next, true
+			}
+			if cornerCutting && s.hasForcedNeighbor(next, maze.Point{X: next.X, Y: next.Y + dir.Y}, maze.Point{X: 1, Y: 0}) {
+				return next, true
+			}
+		}
+
+		p = next
+	}
+}
+
+// hasForcedDiagonalNeighbor reports whether a diagonal move arriving at p along dir
+// can only reach a cell behind it by cutting past a blocked corner.
+func (s *Search) hasForcedDiagonalNeighbor(p, dir maze.Point) bool {
+	backX := maze.Point{X: p.X - dir.X, Y: p.Y}
+	backY := maze.Point{X: p.X, Y: p.Y - dir.Y}
+	if s.passable(maze.Point{X: p.X - dir.X, Y: p.Y + dir.Y}) && !s.passable(backX) {
+		return true
+	}
+	return s.passable(maze.Point{X: p.X + dir.X, Y: p.Y - dir.Y}) && !s.passable(backY)
+}
+
+// hasForcedNeighbor reports whether next has an open side cell, along either sense
+// of side, whose counterpart beside prev is blocked.
+func (s *Search) hasForcedNeighbor(prev, next, side maze.Point) bool {
+	for _, k := range []int{-1, 1} {
+		beside := maze.Point{X: next.X + k*side.X, Y: next.Y + k*side.Y}
+		behind := maze.Point{X: prev.X + k*side.X, Y: prev.Y + k*side.Y}
+		if s.passable(beside) && !s.passable(behind) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPortal reports whether p is a portal square, where jumps stop since the
+// teleport from it is a successor no direction leads to.
+func (s *Search) isPortal(p maze.Point) bool {
+	_, ok := s.cfg.portals[p]
+	return ok
+}
+
+func (s *Search) passable(p maze.Point) bool {
+	return inBounds(s.grid, p) && isWalkable(s.grid, p)
+}
+
+// segmentCost charges every cell entered on the straight or diagonal line from
+// `from` to `to`, scaled by the step length.
+func (s *Search) segmentCost(from, to maze.Point) float64 {
+	step := maze.Point{X: sign(to.X - from.X), Y: sign(to.Y - from.Y)}
+	var total float64
+	for p := from; p != to; {
+		p = maze.Point{X: p.X + step.X, Y: p.Y + step.Y}
+		total += stepLength(step) * s.cfg.costs.Cost(p)
+	}
+	return total
+}
@@ -0,0 +1,165 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertStepwisePath checks that path only takes single steps the movement model allows
+func assertStepwisePath(t *testing.T, grid maze.Grid, path []maze.Point, movement Movement) {
+	t.Helper()
+	for i, p := range path {
+		require.True(t, inBounds(grid, p) && isWalkable(grid, p), "path point %v is not walkable", p)
+		if i == 0 {
+			continue
+		}
+		prev := path[i-1]
+		dir := maze.Point{X: p.X - prev.X, Y: p.Y - prev.Y}
+		require.LessOrEqual(t, dir.X*dir.X+dir.Y*dir.Y, 2, "path jumps from %v to %v", prev, p)
+		require.True(t, movement.canStep(grid, prev, dir), "illegal step from %v to %v", prev, p)
+	}
+}
+
+func TestJPS_ValidPath(t *testing.T) {
+	grid := createTestGrid(4, 4, []maze.Point{
+		{X: 2, Y: 0},
+		{X: 2, Y: 2},
+	})
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 3, Y: 3}
+
+	result, err := JPS(grid, start, goal)
+	require.NoError(t, err)
+	assert.True(t, result.Found)
+	assert.Equal(t, start, result.Path[0])
+	assert.Equal(t, goal, result.Path[len(result.Path)-1])
+	assert.Equal(t, 6, result.PathLength)
+	assertStepwisePath(t, grid, result.Path, MovementFourWay)
+}
+
+func TestJPS_NoPath(t *testing.T) {
+	grid := createTestGrid(3, 3, []maze.Point{
+		{X: 1, Y: 0},
+		{X: 1, Y: 1},
+		{X: 1, Y: 2},
+	})
+
+	result, err := JPS(grid, maze.Point{X: 0, Y: 1}, maze.Point{X: 2, Y: 1})
+	require.NoError(t, err)
+	assert.False(t, result.Found)
+	assert.Empty(t, result.Path)
+}
+
+func TestJPS_SameStartAndGoal(t *testing.T) {
+	grid := createTestGrid(3, 3, nil)
+	start := maze.Point{X: 1, Y: 1}
+
+	result, err := JPS(grid, start, start)
+	require.NoError(t, err)
+	assert.True(t, result.Found)
+	assert.Equal(t, []maze.Point{start}, result.Path)
+	assert.Equal(t, 0, result.PathLength)
+}
+
+func TestJPS_ExpandsFewerNodesOnOpenGrid(t *testing.T) {
+	grid := createTestGrid(40, 40, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 39, Y: 39}
+
+	jps, err := JPS(grid, start, goal)
+	require.NoError(t, err)
+	astar, err := AStar(grid, start, goal)
+	require.NoError(t, err)
+
+	assert.Equal(t, astar.PathLength, jps.PathLength)
+	assert.Less(t, jps.ExpandedNodes, astar.ExpandedNodes)
+	assertStepwisePath(t, grid, jps.Path, MovementFourWay)
+}
+
+func TestJPS_MatchesBFSOnRandomGrids(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 300; i++ {
+		width, height := 2+rng.Intn(14), 2+rng.Intn(14)
+		grid := createTestGrid(width, height, nil)
+		for y := range grid {
+			for x := range grid[y] {
+				if rng.Float64() < 0.3 {
+					grid[y][x] = 1
+				}
+			}
+		}
+		start := maze.Point{X: rng.Intn(width), Y: rng.Intn(height)}
+		goal := maze.Point{X: rng.Intn(width), Y: rng.Intn(height)}
+		grid[start.Y][start.X] = 0
+		grid[goal.Y][goal.X] = 0
+
+		bfs, err := BFS(grid, start, goal)
+		require.NoError(t, err)
+		jps, err := JPS(grid, start, goal)
+		require.NoError(t, err)
+
+		require.Equal(t, bfs.Found, jps.Found, "grid %d: %v", i, grid)
+		require.Equal(t, bfs.PathLength, jps.PathLength, "grid %d: %v", i, grid)
+		if jps.Found {
+			assertStepwisePath(t, grid, jps.Path, MovementFourWay)
+		}
+	}
+}
+
+func TestJPS_MatchesBFSOnMazes(t *testing.T) {
+	gen := maze.NewGenerator()
+	for seed := int64(0); seed < 20; seed++ {
+		s := seed
+		m, err := gen.Generate(context.Background(), 12, 9, &s)
+		require.NoError(t, err)
+
+		start := maze.Point{X: 1, Y: 1}
+		goal := maze.Point{X: m.Width - 2, Y: m.Height - 2}
+		bfs, err := BFS(m.Grid, start, goal)
+		require.NoError(t, err)
+		jps, err := JPS(m.Grid, start, goal)
+		require.NoError(t, err)
+
+		assert.Equal(t, bfs.PathLength, jps.PathLength, "seed %d", seed)
+		assertStepwisePath(t, m.Grid, jps.Path, MovementFourWay)
+	}
+}
+
+func TestJPS_MatchesDijkstraWithDiagonals(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	for _, movement := range []Movement{MovementEightWay, MovementEightWayCornerCutting} {
+		t.Run(string(movement), func(t *testing.T) {
+			for i := 0; i < 300; i++ {
+				width, height := 2+rng.Intn(14), 2+rng.Intn(14)
+				grid := createTestGrid(width, height, nil)
+				for y := range grid {
+					for x := range grid[y] {
+						if rng.Float64() < 0.3 {
+							grid[y][x] = 1
+						}
+					}
+				}
+				start := maze.Point{X: rng.Intn(width), Y: rng.Intn(height)}
+				goal := maze.Point{X: rng.Intn(width), Y: rng.Intn(height)}
+				grid[start.Y][start.X] = 0
+				grid[goal.Y][goal.X] = 0
+
+				dijkstra, err := Dijkstra(grid, start, goal, WithMovement(movement))
+				require.NoError(t, err)
+				jps, err := JPS(grid, start, goal, WithMovement(movement))
+				require.NoError(t, err)
+
+				require.Equal(t, dijkstra.Found, jps.Found, "grid %d: %v", i, grid)
+				require.InDelta(t, dijkstra.PathCost, jps.PathCost, 1e-9, "grid %d: %v", i, grid)
+				if jps.Found {
+					assertStepwisePath(t, grid, jps.Path, movement)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package algorithm
+
+import "github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+
+// keySet is a set of key colours, colour c being bit c-1.
+type keySet uint32
+
+func (k keySet) with(c int) keySet {
+	return k | 1<<(c-1)
+}
+
+func (k keySet) has(c int) bool {
+	return k&(1<<(c-1)) != 0
+}
+
+// passable reports whether the square at p can be entered holding keys: open
+// squares always can, doors only once their key is held.
+func passable(grid maze.Grid, p maze.Point, keys keySet) bool {
+	v := grid[p.Y][p.X]
+	if c, ok := maze.DoorColor(v); ok {
+		return keys.has(c)
+	}
+	return maze.IsOpen(v)
+}
+
+// squares projects the states on a path onto the squares they stand on.
+func squares(path []state) []maze.Point {
+	points := make([]maze.Point, len(path))
+	for i, st := range path {
+		points[i] = st.Point
+	}
+	return points
+}
+
+// pickups lists the indices of the states on path where a key is picked up,
+// including the first state if the path starts on a key.
+func pickups(path []state) []int {
+	var indices []int
+	var held keySet
+	for i, st := range path {
+		if st.keys != held {
+			indices = append(indices, i)
+			held = st.keys
+		}
+	}
+	return indices
+}
@@ -0,0 +1,100 @@
+package algorithm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockedCorridor is a corridor cut by door 1, whose key lies down a dead end
+// on the near side.
+func lockedCorridor() maze.Grid {
+	return maze.Grid{
+		{1, 1, 1, 1, 1, 1, 1},
+		{1, 0, 0, maze.Door(1), 0, 0, 1},
+		{1, 0, 1, 1, 1, 1, 1},
+		{1, maze.Key(1), 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1, 1, 1},
+	}
+}
+
+// assertKeyPath checks that path only steps through doors whose key it has
+// picked up, and that pickups lists exactly where it picks up new keys.
+func assertKeyPath(t *testing.T, grid maze.Grid, path []maze.Point, pickups []int) {
+	t.Helper()
+	var held keySet
+	var collected []int
+	for i, p := range path {
+		require.LessOrEqual(t, manhattan(path[max(i-1, 0)], p), 1.0, "step %d jumps", i)
+		require.True(t, passable(grid, p, held), "step %d enters %v without its key", i, p)
+		if c, ok := maze.KeyColor(grid[p.Y][p.X]); ok && !held.has(c) {
+			held = held.with(c)
+			collected = append(collected, i)
+		}
+	}
+	assert.Equal(t, collected, pickups)
+}
+
+func TestSolvers_ProjectVisitedOrder(t *testing.T) {
+	grid := lockedCorridor()
+	result, err := BFS(grid, maze.Point{X: 2, Y: 1}, maze.Point{X: 5, Y: 1})
+	require.NoError(t, err)
+
+	seen := make(map[maze.Point]bool)
+	for _, p := range result.VisitedOrder {
+		require.False(t, seen[p], "%v is listed twice", p)
+		seen[p] = true
+	}
+	// The squares between the start and the key are expanded again once the
+	// key is held.
+	assert.Len(t, result.VisitedOrder, 7)
+	assert.Equal(t, 10, result.ExpandedNodes)
+}
+
+func TestAStar_KeysMatchDijkstra(t *testing.T) {
+	seed := int64(5)
+	generated, err := maze.NewKeyGenerator(maze.NewBraidedGenerator(maze.NewGenerator(), 0.5), 3).Generate(context.Background(), 9, 7, &seed)
+	require.NoError(t, err)
+	grid := generated.Grid
+	require.True(t, maze.HasKeys(grid))
+
+	start := maze.Point{X: 1, Y: 1}
+	goal := maze.Point{X: 17, Y: 13}
+	for _, movement := range Movements {
+		want, err := Dijkstra(grid, start, goal, WithMovement(movement))
+		require.NoError(t, err)
+		require.True(t, want.Found, "the generator must keep the maze solvable from its first square")
+
+		result, err := AStar(grid, start, goal, WithMovement(movement))
+		require.NoError(t, err)
+		assert.True(t, result.Optimal)
+		assert.InDelta(t, want.PathCost, result.PathCost, 1e-9, "%s", movement)
+		assert.LessOrEqual(t, result.ExpandedNodes, want.ExpandedNodes)
+	}
+}
+
+func TestSolvers_KeyBehindItsDoor(t *testing.T) {
+	grid := lockedCorridor()
+	grid[3][1] = 0
+	grid[1][5] = maze.Key(1)
+
+	result, err := AStar(grid, maze.Point{X: 2, Y: 1}, maze.Point{X: 4, Y: 1})
+	require.NoError(t, err)
+	assert.False(t, result.Found)
+	assert.Empty(t, result.Pickups)
+}
+
+func TestSolvers_KeyErrors(t *testing.T) {
+	grid := lockedCorridor()
+
+	_, err := BFS(grid, maze.Point{X: 3, Y: 1}, maze.Point{X: 5, Y: 1})
+	assert.ErrorIs(t, err, ErrBlocked, "a door is no place to start")
+
+	costs := createTestCosts(7, 5, nil)
+	costs[1][3] = 0
+	_, err = Dijkstra(grid, maze.Point{X: 2, Y: 1}, maze.Point{X: 5, Y: 1}, WithCosts(costs))
+	assert.ErrorIs(t, err, ErrInvalidCosts, "doors need a cost like any other square")
+}
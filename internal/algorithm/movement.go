@@ -0,0 +1,218 @@
+package algorithm
+
+import (
+	"math"
+	"strings"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+)
+
+// Movement selects which neighbours a solver may step to.
+type Movement string
+
+const (
+	// MovementFourWay allows steps up, down, left and right only.
+	MovementFourWay Movement = "4-way"
+	// MovementEightWay adds diagonal steps, but only when both cells beside the
+	// diagonal are open, so a path never clips the corner of a wall.
+	MovementEightWay Movement = "8-way"
+	// MovementEightWayCornerCutting adds diagonal steps whenever at least one of
+	// the cells beside the diagonal is open, letting paths clip wall corners.
+	MovementEightWayCornerCutting Movement = "8-way-corner-cutting"
+)
+
+// Movements lists every supported movement model.
+var Movements = []Movement{MovementFourWay, MovementEightWay, MovementEightWayCornerCutting}
+
+var diagonalDirections = []maze.Point{
+	{X: 1, Y: -1},
+	{X: 1, Y: 1},
+	{X: -1, Y: 1},
+	{X: -1, Y: -1},
+}
+
+var allDirections = append(append([]maze.Point{}, directions...), diagonalDirections...)
+
+// ParseMovement converts a movement name into a Movement. An empty name selects
+// MovementFourWay.
+func ParseMovement(name string) (Movement, error) {
+	if name == "" {
+		return MovementFourWay, nil
+	}
+	for _, m := range Movements {
+		if strings.EqualFold(name, string(m)) {
+			return m, nil
+		}
+	}
+	return "", ErrUnknownMovement
+}
+
+// Diagonal reports whether the movement model allows diagonal steps.
+func (m Movement) Diagonal() bool {
+	return m == MovementEightWay || m == MovementEightWayCornerCutting
+}
+
+func (m Movement) valid() bool {
+	for _, known := range Movements {
+		if m == known {
+			return true
+		}
+	}
+	return false
+}
+
+// directions returns the step offsets the movement model allows.
+func (m Movement) directions() []maze.Point {
+	if m.Diagonal() {
+		return allDirections
+	}
+	return directions
+}
+
+// canStep reports whether a single step from p along dir is allowed on grid.
+func (m Movement) canStep(grid maze.Grid, p, dir maze.Point) bool {
+	return m.canStepOn(func(q maze.Point) bool {
+		return inBounds(grid, q) && isWalkable(grid, q)
+	}, p, dir)
+}
+
+// canStepOn is canStep for a map described only by which squares are open.
+func (m Movement) canStepOn(open func(maze.Point) bool, p, dir maze.Point) bool {
+	next := maze.Point{X: p.X + dir.X, Y: p.Y + dir.Y}
+	if !open(next) {
+		return false
+	}
+	if dir.X == 0 || dir.Y == 0 {
+		return true
+	}
+	if !m.Diagonal() {
+		return false
+	}
+
+	openX := open(maze.Point{X: p.X + dir.X, Y: p.Y})
+	openY := open(maze.Point{X: p.X, Y: p.Y + dir.Y})
+	if m == MovementEightWayCornerCutting {
+		return openX || openY
+	}
+	return openX && openY
+}
+
+// directions returns the step offsets allowed under cfg.
+func (c *config) directions() []maze.Point {
+	if c.topology == maze.TopologyHex {
+		return maze.HexDirections
+	}
+	return c.movement.directions()
+}
+
+// canStep reports whether a single step from p along dir is allowed on grid
+// under cfg. On a torus steps off one edge come back in on the opposite edge.
+func (c *config) canStep(grid maze.Grid, p, dir maze.Point) bool {
+	return c.canStepOn(func(q maze.Point) bool {
+		q, ok := c.normalize(grid, q)
+		return ok && isWalkable(grid, q)
+	}, p, dir)
+}
+
+// normalize maps p onto grid, wrapping it around the edges of a torus.
+func (c *config) normalize(grid maze.Grid, p maze.Point) (maze.Point, bool) {
+	return normalize(grid, p, c.topology == maze.TopologyTorus)
+}
+
+// canStepOn is canStep for a map described only by which tiles are open.
+// Neighbouring hex tiles share an edge, so a hex step only needs its target open.
+func (c *config) canStepOn(open func(maze.Point) bool, p, dir maze.Point) bool {
+	if c.topology == maze.TopologyHex {
+		return open(maze.Point{X: p.X + dir.X, Y: p.Y + dir.Y})
+	}
+	return c.movement.canStepOn(open, p, dir)
+}
+
+// stepLength is the length of a single step along dir under cfg. Every hex
+// step has length 1.
+func (c *config) stepLength(dir maze.Point) float64 {
+	if c.topology == maze.TopologyHex {
+		return 1
+	}
+	return stepLength(dir)
+}
+
+// floor returns floor z of the maze whose ground floor is grid, or nil if
+// there is no such floor.
+func (c *config) floor(grid maze.Grid, z int) maze.Grid {
+	switch {
+	case z == 0:
+		return grid
+	case z > 0 && z <= len(c.floors):
+		return c.floors[z-1]
+	default:
+		return nil
+	}
+}
+
+// contains reports whether p lies on a floor of the maze whose ground floor is
+// grid.
+func (c *config) contains(grid maze.Grid, p maze.Point) bool {
+	floor := c.floor(grid, p.Z)
+	return floor != nil && inBounds(floor, p)
+}
+
+// moves lists the steps allowed from the state at on the maze whose ground
+// floor is grid, each costing its length. Polar grids have no fixed offsets, so
+// their neighbours come from maze.PolarNeighbors; every step between touching
+// tiles has length 1 there, as has every flight of stairs. A portal square adds
+// the teleport to its partner at the portal cost. While at has walls left to
+// break, straight steps may enter walls too. With moving obstacles a path may
+// also wait in place, and moves that run into an obstacle are dropped.
+func (c *config) moves(grid maze.Grid, at state) []edge {
+	p, keys := at.Point, at.keys
+	breakable := func(floor maze.Grid, q maze.Point) bool {
+		return at.breaks < c.breaks && floor[q.Y][q.X] == wall
+	}
+	floor := c.floor(grid, p.Z)
+	var moves []edge
+	if c.topology == maze.TopologyPolar {
+		for _, next := range maze.PolarNeighbors(floor, p) {
+			if passable(floor, next, keys) || breakable(floor, next) {
+				next.Z = p.Z
+				moves = append(moves, edge{to: next, cost: 1})
+			}
+		}
+	} else {
+		open := func(q maze.Point) bool {
+			q, ok := c.normalize(floor, q)
+			return ok && passable(floor, q, keys)
+		}
+		for _, dir := range c.directions() {
+			next, ok := c.normalize(floor, maze.Point{X: p.X + dir.X, Y: p.Y + dir.Y, Z: p.Z})
+			straight := c.topology == maze.TopologyHex || dir.X == 0 || dir.Y == 0
+			if !c.canStepOn(open, p, dir) && !(ok && straight && breakable(floor, next)) {
+				continue
+			}
+			moves = append(moves, edge{to: next, cost: c.stepLength(dir)})
+		}
+	}
+
+	if c.stairs[p] {
+		moves = append(moves, edge{to: maze.Point{X: p.X, Y: p.Y, Z: p.Z + 1}, cost: 1})
+	}
+	if below := (maze.Point{X: p.X, Y: p.Y, Z: p.Z - 1}); c.stairs[below] {
+		moves = append(moves, edge{to: below, cost: 1})
+	}
+	if partner, ok := c.portals[p]; ok {
+		moves = append(moves, edge{to: partner, cost: c.portalCost, teleport: true})
+	}
+	if c.timed() {
+		moves = c.dodge(p, at.t, append(moves, edge{to: p, cost: 1}))
+	}
+	return moves
+}
+
+// stepLength is the geometric length of a single step along dir: 1 for
+// orthogonal steps and √2 for diagonal ones.
+func stepLength(dir maze.Point) float64 {
+	if dir.X != 0 && dir.Y != 0 {
+		return math.Sqrt2
+	}
+	return 1
+}
@@ -0,0 +1,119 @@
+package algorithm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMovement(t *testing.T) {
+	tests := []struct {
+		name string
+		want Movement
+	}{
+		{"", MovementFourWay},
+		{"4-way", MovementFourWay},
+		{"8-WAY", MovementEightWay},
+		{"8-way-corner-cutting", MovementEightWayCornerCutting},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMovement(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := ParseMovement("hex")
+	assert.ErrorIs(t, err, ErrUnknownMovement)
+}
+
+func TestMovement_CanStepCorners(t *testing.T) {
+	// The diagonal from (0,0) to (1,1) passes the wall at (1,0).
+	grid := createTestGrid(2, 2, []maze.Point{{X: 1, Y: 0}})
+	from := maze.Point{X: 0, Y: 0}
+	diagonal := maze.Point{X: 1, Y: 1}
+
+	assert.False(t, MovementFourWay.canStep(grid, from, diagonal))
+	assert.False(t, MovementEightWay.canStep(grid, from, diagonal))
+	assert.True(t, MovementEightWayCornerCutting.canStep(grid, from, diagonal))
+
+	// With both sides blocked even corner cutting may not squeeze through.
+	grid[1][0] = 1
+	assert.False(t, MovementEightWayCornerCutting.canStep(grid, from, diagonal))
+}
+
+func TestSolvers_DiagonalMovement(t *testing.T) {
+	grid := createTestGrid(5, 5, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 4, Y: 4}
+
+	for name, solve := range map[string]func(maze.Grid, maze.Point, maze.Point, ...Option) (*Result, error){
+		"bfs":      BFS,
+		"dijkstra": Dijkstra,
+		"astar":    AStar,
+		"jps":      JPS,
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := solve(grid, start, goal, WithMovement(MovementEightWay))
+			require.NoError(t, err)
+			assert.True(t, result.Found)
+			assert.Equal(t, 4, result.PathLength)
+			assert.InDelta(t, 4*math.Sqrt2, result.PathCost, 1e-9)
+			assertStepwisePath(t, grid, result.Path, MovementEightWay)
+		})
+	}
+}
+
+func TestSolvers_DFSRespectsMovement(t *testing.T) {
+	grid := createTestGrid(5, 5, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 4, Y: 4}
+
+	result, err := DFS(grid, start, goal, WithMovement(MovementEightWay))
+	require.NoError(t, err)
+	assert.True(t, result.Found)
+	assertStepwisePath(t, grid, result.Path, MovementEightWay)
+}
+
+func TestSolvers_CornerCuttingShortensPath(t *testing.T) {
+	grid := createTestGrid(3, 3, []maze.Point{{X: 1, Y: 0}, {X: 1, Y: 1}})
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 0}
+
+	strict, err := AStar(grid, start, goal, WithMovement(MovementEightWay))
+	require.NoError(t, err)
+	cutting, err := AStar(grid, start, goal, WithMovement(MovementEightWayCornerCutting))
+	require.NoError(t, err)
+
+	// Without corner cutting every diagonal around the wall column is forbidden.
+	assert.InDelta(t, 6, strict.PathCost, 1e-9)
+	assert.InDelta(t, 2+2*math.Sqrt2, cutting.PathCost, 1e-9)
+}
+
+func TestSolvers_UnknownMovement(t *testing.T) {
+	grid := createTestGrid(3, 3, nil)
+	_, err := AStar(grid, maze.Point{X: 0, Y: 0}, maze.Point{X: 2, Y: 2}, WithMovement("hex"))
+	assert.ErrorIs(t, err, ErrUnknownMovement)
+}
+
+func TestOctileHeuristicIsAdmissible(t *testing.T) {
+	grid := createTestGrid(7, 7, []maze.Point{{X: 3, Y: 2}, {X: 3, Y: 3}, {X: 3, Y: 4}})
+	goal := maze.Point{X: 6, Y: 3}
+
+	for y := range grid {
+		for x := range grid[y] {
+			p := maze.Point{X: x, Y: y}
+			if !isWalkable(grid, p) {
+				continue
+			}
+			result, err := Dijkstra(grid, p, goal, WithMovement(MovementEightWay))
+			require.NoError(t, err)
+			assert.LessOrEqual(t, octile(p, goal), result.PathCost+1e-9, "at %v", p)
+		}
+	}
+}
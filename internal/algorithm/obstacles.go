@@ -0,0 +1,129 @@
+package algorithm
+
+import "github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+
+// MaxObstacleCycle bounds how many time steps moving obstacles may take to
+// fall into a repeating pattern: the longest scripted trajectory plus the
+// least common multiple of the periodic ones.
+const MaxObstacleCycle = 4096
+
+// Obstacle is a square-sized obstacle that moves to the next point of
+// Trajectory at every time step, standing on Trajectory[t] at time t. A
+// periodic obstacle starts over from the first point once it has reached the
+// last one; a scripted one stops on the last point for good. An obstacle with
+// a single point never moves.
+type Obstacle struct {
+	Trajectory []maze.Point `json:"trajectory"`
+	Periodic   bool         `json:"periodic"`
+}
+
+// At returns the square the obstacle stands on at time step t.
+func (o Obstacle) At(t int) maze.Point {
+	n := len(o.Trajectory)
+	if o.Periodic {
+		return o.Trajectory[t%n]
+	}
+	return o.Trajectory[min(t, n-1)]
+}
+
+// ObstacleFrames lists where every obstacle stands at each of the time steps
+// 0 to steps-1, one frame per step in the order of obstacles.
+func ObstacleFrames(obstacles []Obstacle, steps int) [][]maze.Point {
+	frames := make([][]maze.Point, steps)
+	for t := range frames {
+		frames[t] = make([]maze.Point, len(obstacles))
+		for i, o := range obstacles {
+			frames[t][i] = o.At(t)
+		}
+	}
+	return frames
+}
+
+// timeline is how moving obstacles play out over time: until settle scripted
+// obstacles are still on their way, after which every obstacle repeats what it
+// did cycle steps before.
+type timeline struct {
+	settle int
+	cycle  int
+}
+
+// newTimeline works out the timeline of obstacles, or reports that they do not
+// repeat within MaxObstacleCycle steps.
+func newTimeline(obstacles []Obstacle) (timeline, error) {
+	tl := timeline{cycle: 1}
+	for _, o := range obstacles {
+		n := len(o.Trajectory)
+		if !o.Periodic {
+			tl.settle = max(tl.settle, n-1)
+			continue
+		}
+		tl.cycle = tl.cycle / gcd(tl.cycle, n) * n
+		if tl.cycle > MaxObstacleCycle {
+			return timeline{}, ErrObstacleCycle
+		}
+	}
+	if tl.settle+tl.cycle > MaxObstacleCycle {
+		return timeline{}, ErrObstacleCycle
+	}
+	return tl, nil
+}
+
+// tick returns the time step after t, folded back by whole cycles once the
+// obstacles have settled so that searches over time stay finite. Obstacles
+// stand where they did at the unfolded time.
+func (tl timeline) tick(t int) int {
+	t++
+	if t >= tl.settle+tl.cycle {
+		t -= tl.cycle
+	}
+	return t
+}
+
+// gcd is the greatest common divisor of two positive numbers.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// occupied reports whether an obstacle stands on p at time step t.
+func (c *config) occupied(p maze.Point, t int) bool {
+	for _, o := range c.obstacles {
+		if o.At(t) == p {
+			return true
+		}
+	}
+	return false
+}
+
+// dodge drops the moves from p at time step t that run into an obstacle: those
+// ending on a square an obstacle stands on at the next step, and those
+// swapping squares with an obstacle coming the other way.
+func (c *config) dodge(p maze.Point, t int, moves []edge) []edge {
+	next := c.timeline.tick(t)
+	kept := moves[:0]
+	for _, e := range moves {
+		collides := false
+		for _, o := range c.obstacles {
+			if o.At(next) == e.to || (o.At(t) == e.to && o.At(next) == p) {
+				collides = true
+				break
+			}
+		}
+		if !collides {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// times lists the time step each state on path is reached at. A path moves
+// one step in time per state, waits included.
+func times(path []state) []int {
+	indices := make([]int, len(path))
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
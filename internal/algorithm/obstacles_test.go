@@ -0,0 +1,154 @@
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// alcoveCorridor is a corridor with an alcove next to its second square, and
+// an obstacle coming down the corridor from the far end that then parks on
+// its first square.
+func alcoveCorridor() (maze.Grid, []Obstacle) {
+	grid := maze.Grid{
+		{1, 0, 1, 1, 1},
+		{0, 0, 0, 0, 0},
+	}
+	return grid, []Obstacle{{Trajectory: []maze.Point{
+		{X: 4, Y: 1}, {X: 3, Y: 1}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 1},
+	}}}
+}
+
+// assertDodges checks that path only steps between touching squares or waits,
+// and never shares a square with an obstacle or swaps squares with one.
+func assertDodges(t *testing.T, path []maze.Point, obstacles []Obstacle) {
+	t.Helper()
+	for i, p := range path {
+		require.LessOrEqual(t, chebyshev(path[max(i-1, 0)], p), 1.0, "step %d jumps", i)
+		for _, o := range obstacles {
+			require.NotEqual(t, o.At(i), p, "step %d runs into an obstacle", i)
+			if i > 0 {
+				swapped := o.At(i-1) == p && o.At(i) == path[i-1]
+				require.False(t, swapped, "step %d swaps squares with an obstacle", i)
+			}
+		}
+	}
+}
+
+func TestObstacle_At(t *testing.T) {
+	trajectory := []maze.Point{{X: 0}, {X: 1}, {X: 2}}
+	scripted := Obstacle{Trajectory: trajectory}
+	periodic := Obstacle{Trajectory: trajectory, Periodic: true}
+
+	for tick, want := range []int{0, 1, 2, 2, 2} {
+		assert.Equal(t, want, scripted.At(tick).X, "scripted at %d", tick)
+	}
+	for tick, want := range []int{0, 1, 2, 0, 1} {
+		assert.Equal(t, want, periodic.At(tick).X, "periodic at %d", tick)
+	}
+
+	frames := ObstacleFrames([]Obstacle{scripted, periodic}, 4)
+	require.Len(t, frames, 4)
+	assert.Equal(t, []maze.Point{{X: 2}, {X: 0}}, frames[3])
+}
+
+func TestAStar_ObstaclesMatchBFS(t *testing.T) {
+	grid := make(maze.Grid, 7)
+	for y := range grid {
+		grid[y] = make([]int, 7)
+	}
+	var obstacles []Obstacle
+	for x := 1; x < 7; x += 2 {
+		// Patrols walk up and down every other column, each starting at a
+		// different height.
+		var trajectory []maze.Point
+		for y := 0; y < 7; y++ {
+			trajectory = append(trajectory, maze.Point{X: x, Y: y})
+		}
+		for y := 5; y > 0; y-- {
+			trajectory = append(trajectory, maze.Point{X: x, Y: y})
+		}
+		shift := x * 2
+		trajectory = append(trajectory[shift:], trajectory[:shift]...)
+		obstacles = append(obstacles, Obstacle{Trajectory: trajectory, Periodic: true})
+	}
+
+	start := maze.Point{X: 0, Y: 3}
+	goal := maze.Point{X: 6, Y: 3}
+	for _, movement := range Movements {
+		want, err := BFS(grid, start, goal, WithObstacles(obstacles), WithMovement(movement))
+		require.NoError(t, err)
+		require.True(t, want.Found)
+		assertDodges(t, want.Path, obstacles)
+
+		result, err := AStar(grid, start, goal, WithObstacles(obstacles), WithMovement(movement))
+		require.NoError(t, err)
+		require.True(t, result.Found)
+		assertDodges(t, result.Path, obstacles)
+		if !movement.Diagonal() {
+			assert.Equal(t, want.PathLength, result.PathLength, "%s", movement)
+		}
+		assert.GreaterOrEqual(t, result.PathLength, 6)
+	}
+}
+
+func TestSolvers_GoalNeverFree(t *testing.T) {
+	grid := maze.Grid{{0, 0, 0}}
+	obstacles := []Obstacle{
+		{Trajectory: []maze.Point{{X: 2}}},
+		{Trajectory: []maze.Point{{X: 1}, {X: 1}, {X: 0}}, Periodic: true},
+	}
+
+	result, err := AStar(grid, maze.Point{X: 0}, maze.Point{X: 2}, WithObstacles(obstacles))
+	require.NoError(t, err)
+	assert.False(t, result.Found, "the search must end once every time step repeats")
+	assert.Len(t, result.Obstacles, 3, "one whole pattern of moves")
+}
+
+func TestSolvers_ObstacleErrors(t *testing.T) {
+	grid, obstacles := alcoveCorridor()
+	start := maze.Point{X: 0, Y: 1}
+	goal := maze.Point{X: 4, Y: 1}
+
+	_, err := AStar(grid, start, goal, WithObstacles([]Obstacle{{}}))
+	assert.ErrorIs(t, err, ErrInvalidObstacles)
+
+	_, err = AStar(grid, start, goal, WithObstacles([]Obstacle{{Trajectory: []maze.Point{{X: 5, Y: 1}}}}))
+	assert.ErrorIs(t, err, ErrInvalidObstacles)
+
+	_, err = AStar(grid, goal, start, WithObstacles(obstacles))
+	assert.ErrorIs(t, err, ErrBlocked, "an obstacle stands on the start")
+
+	long := func(n int) Obstacle {
+		return Obstacle{Trajectory: make([]maze.Point, n), Periodic: true}
+	}
+	_, err = AStar(grid, start, goal, WithObstacles([]Obstacle{long(64), long(67)}))
+	assert.ErrorIs(t, err, ErrObstacleCycle)
+}
+
+func TestSolvers_WaitInsideBrokenWall(t *testing.T) {
+	grid := maze.Grid{
+		{0, 1, 0},
+		{1, 1, 1},
+	}
+	// One obstacle holds the goal for four steps, the other takes the start
+	// after the first, so the walker has to wait inside the wall it broke.
+	obstacles := []Obstacle{
+		{Trajectory: []maze.Point{{X: 2}, {X: 2}, {X: 2}, {X: 2}, {X: 2, Y: 1}}},
+		{Trajectory: []maze.Point{{Y: 1}, {X: 0}}},
+	}
+	start, goal := maze.Point{X: 0}, maze.Point{X: 2}
+
+	for name, solve := range map[string]func(maze.Grid, maze.Point, maze.Point, ...Option) (*Result, error){"bfs": BFS, "astar": AStar} {
+		t.Run(name, func(t *testing.T) {
+			result, err := solve(grid, start, goal, WithWallBreaks(1), WithObstacles(obstacles))
+			require.NoError(t, err)
+			require.True(t, result.Found)
+			assertDodges(t, result.Path, obstacles)
+			assert.Equal(t, []maze.Point{start, {X: 1}, {X: 1}, {X: 1}, goal}, result.Path)
+			assert.Equal(t, []int{1}, result.Breaks, "waiting in the broken wall breaks nothing new")
+		})
+	}
+}
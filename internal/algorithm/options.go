@@ -0,0 +1,281 @@
+package algorithm
+
+import (
+	"math"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+)
+
+// Option customises a single solver run.
+type Option func(*config)
+
+type config struct {
+	costs     maze.CostGrid
+	minCost   float64
+	movement  Movement
+	topology  maze.Topology
+	heuristic Heuristic
+	weight    float64
+	// floors holds the floors stacked above the grid, which is floor 0, and
+	// stairs the squares with stairs up to the floor above.
+	floors []maze.Grid
+	stairs map[maze.Point]bool
+	// portals maps either end of every portal in the maze to the other, and
+	// portalCost is what teleporting between them costs.
+	portals    map[maze.Point]maze.Point
+	portalCost float64
+	// keyed is set when the maze holds keys or doors, which makes solvers
+	// search over squares and the keys held on them.
+	keyed bool
+	// breaks is how many walls a path may break through, which makes solvers
+	// search over squares and the walls broken on the way to them.
+	breaks int
+	// obstacles move over the maze as time passes, which makes solvers search
+	// over squares and time steps folded by timeline.
+	obstacles []Obstacle
+	timeline  timeline
+}
+
+// WithCosts charges per-cell movement costs taken from costs instead of a
+// uniform cost of 1 per step. costs must have the same shape as the grid and
+// hold positive, finite values.
+func WithCosts(costs maze.CostGrid) Option {
+	return func(c *config) {
+		c.costs = costs
+	}
+}
+
+// WithFloors turns the grid into the ground floor of a multi-level maze with
+// the floors in above stacked on top of it, floor z being above[z-1]. Every
+// floor must have the grid's shape. Each point in stairs joins its open square
+// on floor Point.Z to the same open square on the floor above; climbing or
+// descending them is a step of length 1. Points then carry their floor in Z,
+// and heuristics add the number of floors between two points. Per-cell costs
+// are not supported on multi-level mazes.
+func WithFloors(above []maze.Grid, stairs []maze.Point) Option {
+	return func(c *config) {
+		c.floors = above
+		c.stairs = make(map[maze.Point]bool, len(stairs))
+		for _, s := range stairs {
+			c.stairs[s] = true
+		}
+	}
+}
+
+// WithPortalCost sets what teleporting from a portal square to its partner
+// costs; the default is 1. Portals come from the grid itself, see
+// maze.FirstPortal. Teleporting is a move of its own that per-cell costs do not
+// scale. cost must be non-negative and finite.
+func WithPortalCost(cost float64) Option {
+	return func(c *config) {
+		c.portalCost = cost
+	}
+}
+
+// WithWallBreaks lets a path break through up to k walls, entering each by a
+// straight step as if it were open; diagonal steps never break walls and still
+// need the squares beside them open unless corners may be cut. The default, 0,
+// keeps every wall solid. k must be non-negative. Breaking a wall costs what
+// entering any square does, so with per-cell costs walls need valid costs too.
+func WithWallBreaks(k int) Option {
+	return func(c *config) {
+		c.breaks = k
+	}
+}
+
+// WithObstacles fills the maze with obstacles moving one step per time step,
+// each standing on Trajectory[t] at time t. Every step of a path then takes one
+// time step, and a path may also wait in place for one, costing what entering
+// its square does. Paths never share a square with an obstacle nor swap squares
+// with one. Every trajectory must be non-empty and inside the maze, and the
+// obstacles must fall into a repeating pattern within MaxObstacleCycle steps.
+func WithObstacles(obstacles []Obstacle) Option {
+	return func(c *config) {
+		c.obstacles = obstacles
+	}
+}
+
+// WithMovement selects the movement model. The default is MovementFourWay.
+func WithMovement(m Movement) Option {
+	return func(c *config) {
+		c.movement = m
+	}
+}
+
+// WithTopology selects the grid topology, which decides the neighbours of a
+// tile. The default is maze.TopologySquare. Hex and polar grids support
+// MovementFourWay only, where it means stepping to any tile that shares an edge.
+// Polar grids must have the layout checked by maze.ValidatePolarGrid. Torus
+// grids are rectangular square grids whose edges wrap around, with every
+// movement model; heuristics then measure the shortest way across the seams.
+func WithTopology(t maze.Topology) Option {
+	return func(c *config) {
+		c.topology = t
+	}
+}
+
+// WithHeuristic selects the heuristic informed solvers rank nodes with. The
+// default is HeuristicManhattan, HeuristicOctile for diagonal movement,
+// HeuristicHex on hex grids, or HeuristicRadial on polar grids.
+// Uninformed solvers ignore it.
+func WithHeuristic(h Heuristic) Option {
+	return func(c *config) {
+		c.heuristic = h
+	}
+}
+
+// WithWeight scales the heuristic of informed solvers, ranking nodes by
+// f = g + w·h. A weight above 1 trades optimality for fewer expansions; the
+// default is 1. w must be non-negative and finite.
+func WithWeight(w float64) Option {
+	return func(c *config) {
+		c.weight = w
+	}
+}
+
+// newConfig applies opts and validates the result against grid.
+func newConfig(grid maze.Grid, opts []Option) (*config, error) {
+	cfg := &config{minCost: 1, movement: MovementFourWay, topology: maze.TopologySquare, weight: 1, portalCost: 1}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if !cfg.movement.valid() {
+		return nil, ErrUnknownMovement
+	}
+
+	switch cfg.topology {
+	case maze.TopologySquare, maze.TopologyTorus:
+	case maze.TopologyHex:
+		if cfg.movement.Diagonal() {
+			return nil, ErrUnsupportedTopology
+		}
+	case maze.TopologyPolar:
+		if cfg.movement.Diagonal() {
+			return nil, ErrUnsupportedTopology
+		}
+		if grid != nil {
+			if err := maze.ValidatePolarGrid(grid); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, maze.ErrUnknownTopology
+	}
+
+	if cfg.stairs != nil {
+		if grid == nil || cfg.costs != nil {
+			return nil, ErrUnsupportedFloors
+		}
+		stairs := make([]maze.Point, 0, len(cfg.stairs))
+		for s := range cfg.stairs {
+			stairs = append(stairs, s)
+		}
+		if err := maze.ValidateFloors(append([]maze.Grid{grid}, cfg.floors...), stairs); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.heuristic == "" {
+		cfg.heuristic = defaultHeuristic(cfg.movement, cfg.topology)
+	}
+	if !cfg.heuristic.valid() {
+		return nil, ErrUnknownHeuristic
+	}
+
+	if cfg.weight < 0 || math.IsNaN(cfg.weight) || math.IsInf(cfg.weight, 0) {
+		return nil, ErrInvalidWeight
+	}
+
+	if cfg.breaks < 0 {
+		return nil, ErrInvalidWallBreaks
+	}
+
+	if len(cfg.obstacles) > 0 {
+		for _, o := range cfg.obstacles {
+			if len(o.Trajectory) == 0 {
+				return nil, ErrInvalidObstacles
+			}
+			for _, p := range o.Trajectory {
+				if grid != nil && !cfg.contains(grid, p) {
+					return nil, ErrInvalidObstacles
+				}
+			}
+		}
+		timeline, err := newTimeline(cfg.obstacles)
+		if err != nil {
+			return nil, err
+		}
+		cfg.timeline = timeline
+	}
+
+	if cfg.portalCost < 0 || math.IsNaN(cfg.portalCost) || math.IsInf(cfg.portalCost, 0) {
+		return nil, ErrInvalidPortalCost
+	}
+	if grid != nil {
+		portals, err := maze.Portals(append([]maze.Grid{grid}, cfg.floors...)...)
+		if err != nil {
+			return nil, err
+		}
+		cfg.portals = portals
+		cfg.keyed = maze.HasKeys(append([]maze.Grid{grid}, cfg.floors...)...)
+	}
+
+	if cfg.costs != nil {
+		minCost, err := validateCosts(grid, cfg.costs, cfg.breaks > 0)
+		if err != nil {
+			return nil, err
+		}
+		cfg.minCost = minCost
+	}
+
+	return cfg, nil
+}
+
+// stateful reports whether searches track more than the square they stand on:
+// the keys held, the walls broken, or the time.
+func (c *config) stateful() bool {
+	return c.keyed || c.breaks > 0 || c.timed()
+}
+
+// timed reports whether obstacles move over the maze, so searches track time.
+func (c *config) timed() bool {
+	return len(c.obstacles) > 0
+}
+
+// unitPortals reports whether every teleport costs what a step does, which
+// keeps searches that count steps optimal.
+func (c *config) unitPortals() bool {
+	return len(c.portals) == 0 || c.portalCost == 1
+}
+
+// validateCosts checks that costs matches the grid shape and returns the
+// smallest cost charged for entering a walkable cell or a door, or a wall when
+// walls may be broken.
+func validateCosts(grid maze.Grid, costs maze.CostGrid, walls bool) (float64, error) {
+	if len(costs) != len(grid) {
+		return 0, ErrInvalidCosts
+	}
+
+	minCost := math.Inf(1)
+	for y, row := range grid {
+		if len(costs[y]) != len(row) {
+			return 0, ErrInvalidCosts
+		}
+		for x, cell := range row {
+			if _, door := maze.DoorColor(cell); !door && !maze.IsOpen(cell) && !(walls && cell == wall) {
+				continue
+			}
+			cost := costs[y][x]
+			if cost <= 0 || math.IsNaN(cost) || math.IsInf(cost, 0) {
+				return 0, ErrInvalidCosts
+			}
+			minCost = math.Min(minCost, cost)
+		}
+	}
+
+	if math.IsInf(minCost, 1) {
+		minCost = 1
+	}
+	return minCost, nil
+}
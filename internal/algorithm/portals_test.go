@@ -0,0 +1,139 @@
+package algorithm
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// portalRooms is two rooms joined only by portal 2.
+func portalRooms() maze.Grid {
+	return maze.Grid{
+		{1, 1, 1, 1, 1, 1, 1},
+		{1, 0, 0, 1, 0, 0, 1},
+		{1, 0, 2, 1, 2, 0, 1},
+		{1, 1, 1, 1, 1, 1, 1},
+	}
+}
+
+// assertPortalPath checks that every step of path either moves to a touching
+// square or, exactly at the indices in teleports, jumps through a portal.
+func assertPortalPath(t *testing.T, grid maze.Grid, path []maze.Point, teleports []int) {
+	t.Helper()
+	portals, err := maze.Portals(grid)
+	require.NoError(t, err)
+	for i := 1; i < len(path); i++ {
+		a, b := path[i-1], path[i]
+		require.True(t, isWalkable(grid, b), "step %d enters a wall", i)
+		if slices.Contains(teleports, i) {
+			require.Equal(t, portals[a], b, "step %d is marked as a teleport but joins no portal", i)
+			continue
+		}
+		require.LessOrEqual(t, chebyshev(a, b), 1.0, "step %d jumps from %v to %v", i, a, b)
+	}
+}
+
+func TestSolvers_PortalCost(t *testing.T) {
+	grid := portalRooms()
+	start := maze.Point{X: 1, Y: 1}
+	goal := maze.Point{X: 5, Y: 1}
+	costs := maze.CostGrid{
+		{1, 1, 1, 1, 1, 1, 1},
+		{1, 2, 2, 1, 2, 2, 1},
+		{1, 2, 2, 1, 2, 2, 1},
+		{1, 1, 1, 1, 1, 1, 1},
+	}
+
+	result, err := Dijkstra(grid, start, goal, WithPortalCost(0.5))
+	require.NoError(t, err)
+	assert.Equal(t, 4.5, result.PathCost)
+
+	// Cell costs scale steps but not the teleport itself.
+	result, err = Dijkstra(grid, start, goal, WithPortalCost(3), WithCosts(costs))
+	require.NoError(t, err)
+	assert.Equal(t, 11.0, result.PathCost)
+
+	result, err = BFS(grid, start, goal, WithPortalCost(3))
+	require.NoError(t, err)
+	assert.Equal(t, 7.0, result.PathCost)
+	assert.False(t, result.Optimal, "BFS counts teleports as single steps")
+}
+
+func TestAStar_PortalHeuristicIsAdmissible(t *testing.T) {
+	grid := createTestGrid(14, 9, []maze.Point{
+		{X: 6, Y: 0}, {X: 6, Y: 1}, {X: 6, Y: 2}, {X: 6, Y: 3}, {X: 6, Y: 4}, {X: 6, Y: 5}, {X: 6, Y: 6}, {X: 6, Y: 7},
+	})
+	grid[0][0], grid[0][13] = 2, 2
+	grid[8][1], grid[4][12] = 3, 3
+	goal := maze.Point{X: 13, Y: 8}
+
+	for _, portalCost := range []float64{0, 0.5, 1, 6} {
+		for _, movement := range Movements {
+			cfg, err := newConfig(grid, []Option{WithPortalCost(portalCost), WithMovement(movement)})
+			require.NoError(t, err)
+			estimate := cfg.estimate(grid, goal)
+
+			for y, row := range grid {
+				for x := range row {
+					p := maze.Point{X: x, Y: y}
+					if !isWalkable(grid, p) {
+						continue
+					}
+					exact, err := Dijkstra(grid, p, goal, WithPortalCost(portalCost), WithMovement(movement))
+					require.NoError(t, err)
+					require.True(t, exact.Found)
+					require.LessOrEqual(t, estimate(p), exact.PathCost+1e-9,
+						"cost %v, %s: estimate at %v exceeds the true cost", portalCost, movement, p)
+				}
+			}
+
+			start := maze.Point{X: 0, Y: 8}
+			exact, err := Dijkstra(grid, start, goal, WithPortalCost(portalCost), WithMovement(movement))
+			require.NoError(t, err)
+			for _, solve := range []func(maze.Grid, maze.Point, maze.Point, ...Option) (*Result, error){AStar, JPS, BidirectionalAStar} {
+				result, err := solve(grid, start, goal, WithPortalCost(portalCost), WithMovement(movement))
+				require.NoError(t, err)
+				assert.True(t, result.Optimal)
+				assert.InDelta(t, exact.PathCost, result.PathCost, 1e-9, "cost %v, %s", portalCost, movement)
+				assertPortalPath(t, grid, result.Path, result.Teleports)
+			}
+		}
+	}
+}
+
+func TestAStar_PortalsAcrossFloors(t *testing.T) {
+	ground := maze.Grid{
+		{0, 0, 2},
+		{1, 1, 1},
+	}
+	upper := maze.Grid{
+		{2, 0, 0},
+		{1, 1, 1},
+	}
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 0, Z: 1}
+
+	result, err := AStar(ground, start, goal, WithFloors([]maze.Grid{upper}, nil))
+	require.NoError(t, err)
+	require.True(t, result.Found)
+	assert.Equal(t, []maze.Point{
+		{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 0, Z: 1}, {X: 1, Y: 0, Z: 1}, {X: 2, Y: 0, Z: 1},
+	}, result.Path)
+	assert.Equal(t, []int{3}, result.Teleports)
+}
+
+func TestSolvers_PortalErrors(t *testing.T) {
+	grid := portalRooms()
+	start := maze.Point{X: 1, Y: 1}
+	goal := maze.Point{X: 1, Y: 2}
+
+	_, err := AStar(grid, start, goal, WithPortalCost(-1))
+	assert.ErrorIs(t, err, ErrInvalidPortalCost)
+
+	grid[1][2] = 2
+	_, err = AStar(grid, start, goal)
+	assert.ErrorIs(t, err, maze.ErrInvalidPortals)
+}
@@ -1,13 +1,9 @@
 package algorithm
 
-import (
-	"container/heap"
-
-	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
-)
+import "container/heap"
 
 type node struct {
-	point    maze.Point
+	state
 	priority float64
 	index    int
 }
@@ -42,8 +38,8 @@ func (pq *priorityQueue) Pop() any {
 	return item
 }
 
-func (pq *priorityQueue) update(item *node, point maze.Point, priority float64) {
-	item.point = point
+func (pq *priorityQueue) update(item *node, st state, priority float64) {
+	item.state = st
 	item.priority = priority
 	heap.Fix(pq, item.index)
 }
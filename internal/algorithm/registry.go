@@ -0,0 +1,162 @@
+package algorithm
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+)
+
+// Registry indexes solvers by their name and aliases. Lookups are case-insensitive
+// and the registry is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	solvers []Solver
+	byKey   map[string]Solver
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		byKey: make(map[string]Solver),
+	}
+}
+
+// NewDefaultRegistry creates a registry holding every solver shipped with this package.
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, s := range builtinSolvers() {
+		r.MustRegister(s)
+	}
+	return r
+}
+
+func builtinSolvers() []Solver {
+	return []Solver{
+		NewSolver(Info{
+			Name:            "bfs",
+			Label:           "Breadth-First Search",
+			Aliases:         []string{},
+			Optimal:         true,
+			Topologies:      maze.Topologies,
+			MultiLevel:      true,
+			Keys:            true,
+			WallBreaks:      true,
+			MovingObstacles: true,
+		}, NewBFSSearch),
+		NewSolver(Info{
+			Name:            "dfs",
+			Label:           "Depth-First Search",
+			Aliases:         []string{},
+			Topologies:      maze.Topologies,
+			MultiLevel:      true,
+			Keys:            true,
+			WallBreaks:      true,
+			MovingObstacles: true,
+		}, NewDFSSearch),
+		NewSolver(Info{
+			Name:            "astar",
+			Label:           "A* Search",
+			Aliases:         []string{"a*"},
+			Optimal:         true,
+			SupportsCosts:   true,
+			Topologies:      maze.Topologies,
+			MultiLevel:      true,
+			Keys:            true,
+			WallBreaks:      true,
+			MovingObstacles: true,
+		}, NewAStarSearch),
+		NewSolver(Info{
+			Name:            "dijkstra",
+			Label:           "Dijkstra",
+			Aliases:         []string{},
+			Optimal:         true,
+			SupportsCosts:   true,
+			Topologies:      maze.Topologies,
+			MultiLevel:      true,
+			Keys:            true,
+			WallBreaks:      true,
+			MovingObstacles: true,
+		}, NewDijkstraSearch),
+		NewSolver(Info{
+			Name:       "jps",
+			Label:      "Jump Point Search",
+			Aliases:    []string{"jump-point"},
+			Optimal:    true,
+			Topologies: []maze.Topology{maze.TopologySquare},
+		}, NewJPSSearch),
+		NewSolver(Info{
+			Name:       "bidirectional-bfs",
+			Label:      "Bidirectional BFS",
+			Aliases:    []string{"bibfs"},
+			Optimal:    true,
+			Topologies: maze.Topologies,
+			MultiLevel: true,
+		}, NewBidirectionalBFSSearch),
+		NewSolver(Info{
+			Name:          "bidirectional-astar",
+			Label:         "Bidirectional A*",
+			Aliases:       []string{"bidirectional-a*", "biastar"},
+			Optimal:       true,
+			SupportsCosts: true,
+			Topologies:    maze.Topologies,
+			MultiLevel:    true,
+		}, NewBidirectionalAStarSearch),
+	}
+}
+
+// Register adds s under its name and aliases. It fails if any of those keys is
+// empty or already taken.
+func (r *Registry) Register(s Solver) error {
+	info := s.Info()
+	keys := append([]string{info.Name}, info.Aliases...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range keys {
+		key = strings.ToLower(key)
+		if key == "" {
+			return fmt.Errorf("solver %q: %w", info.Name, ErrInvalidSolver)
+		}
+		if _, ok := r.byKey[key]; ok {
+			return fmt.Errorf("solver key %q: %w", key, ErrDuplicateSolver)
+		}
+	}
+
+	for _, key := range keys {
+		r.byKey[strings.ToLower(key)] = s
+	}
+	r.solvers = append(r.solvers, s)
+	return nil
+}
+
+// MustRegister is like Register but panics on error. It is meant for wiring
+// solvers at start-up.
+func (r *Registry) MustRegister(s Solver) {
+	if err := r.Register(s); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the solver registered under name or one of its aliases.
+func (r *Registry) Lookup(name string) (Solver, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.byKey[strings.ToLower(name)]
+	return s, ok
+}
+
+// List returns the metadata of every registered solver in registration order.
+func (r *Registry) List() []Info {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	infos := make([]Info, 0, len(r.solvers))
+	for _, s := range r.solvers {
+		infos = append(infos, s.Info())
+	}
+	return infos
+}
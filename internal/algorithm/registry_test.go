@@ -0,0 +1,60 @@
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_DefaultSolvers(t *testing.T) {
+	registry := NewDefaultRegistry()
+
+	names := []string{}
+	for _, info := range registry.List() {
+		names = append(names, info.Name)
+	}
+	assert.Equal(t, []string{"bfs", "dfs", "astar", "dijkstra", "jps", "bidirectional-bfs", "bidirectional-astar"}, names)
+}
+
+func TestRegistry_LookupByAliasIsCaseInsensitive(t *testing.T) {
+	registry := NewDefaultRegistry()
+
+	for _, key := range []string{"astar", "ASTAR", "a*", "A*"} {
+		solver, ok := registry.Lookup(key)
+		require.True(t, ok, key)
+		assert.Equal(t, "astar", solver.Info().Name)
+	}
+
+	_, ok := registry.Lookup("unknown")
+	assert.False(t, ok)
+}
+
+func TestRegistry_RegisterRejectsDuplicates(t *testing.T) {
+	registry := NewDefaultRegistry()
+
+	err := registry.Register(NewSolver(Info{Name: "greedy", Aliases: []string{"A*"}}, NewAStarSearch))
+	assert.ErrorIs(t, err, ErrDuplicateSolver)
+
+	// A failed registration must not leave a partial entry behind
+	_, ok := registry.Lookup("greedy")
+	assert.False(t, ok)
+
+	err = registry.Register(NewSolver(Info{Name: ""}, NewBFSSearch))
+	assert.ErrorIs(t, err, ErrInvalidSolver)
+}
+
+func TestRegistry_CustomSolver(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewSolver(Info{Name: "custom"}, NewBFSSearch)))
+
+	solver, ok := registry.Lookup("custom")
+	require.True(t, ok)
+
+	grid := createTestGrid(3, 3, nil)
+	result, err := solver.Solve(grid, maze.Point{X: 0, Y: 0}, maze.Point{X: 2, Y: 2})
+	require.NoError(t, err)
+	assert.True(t, result.Found)
+	assert.Equal(t, 4, result.PathLength)
+}
@@ -0,0 +1,379 @@
+package algorithm
+
+import (
+	"iter"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+)
+
+// Search is the step-by-step form of a solver. Each call to Step advances the
+// search by at most one event, which lets callers animate the open set, the
+// scores and parent updates instead of only the final visiting order.
+// A Search is not safe for concurrent use.
+type Search struct {
+	grid  maze.Grid
+	start maze.Point
+	goal  maze.Point
+	cfg   *config
+
+	open       frontier
+	heuristic  func(maze.Point) float64
+	weight     float64
+	guide      Heuristic
+	successors func(s *Search, at state) []edge
+	// relax allows an open node to be re-pushed with a cheaper cost. Without it a
+	// node is claimed by the first parent that discovers it, as in BFS and DFS.
+	relax bool
+	// segments marks parent links that span several cells in a straight line,
+	// which Result expands back into single steps.
+	segments bool
+	optimal  bool
+
+	gScore  map[state]float64
+	parents map[state]state
+	// teleported marks the nodes whose parent link is a jump through a portal.
+	teleported   map[state]bool
+	closed       map[state]bool
+	visitedOrder []maze.Point
+	visitedSides []Side
+	// origin is the state the search sets out from and reached the one it
+	// stopped at. With keys or wall breaks a square may be expanded once per set
+	// of keys and number of walls broken, and seen keeps VisitedOrder to the
+	// first of them; expanded counts them all.
+	origin   state
+	reached  state
+	seen     map[maze.Point]bool
+	expanded int
+
+	// pair is set for bidirectional searches, which delegate to two halves and
+	// leave the fields above that describe a single open set unused.
+	pair *bidirectional
+
+	pending []Event
+	done    bool
+	found   bool
+}
+
+// searchSpec captures what distinguishes one solver from another. Informed
+// solvers rank nodes with the configured heuristic and weight; the others use a
+// zero heuristic. Nil successors means the grid neighbours of the movement model.
+// optimal reports whether the configuration guarantees the cheapest path,
+// squareOnly rejects every topology but maze.TopologySquare, singleFloor
+// rejects multi-level mazes, and positional every search whose states are more
+// than squares: grids with keys or doors, and wall breaks.
+type searchSpec struct {
+	open        frontier
+	informed    bool
+	successors  func(s *Search, at state) []edge
+	relax       bool
+	segments    bool
+	optimal     func(cfg *config) bool
+	squareOnly  bool
+	singleFloor bool
+	positional  bool
+}
+
+// state is a node of the search graph: a square, the keys held on it, the
+// walls broken on the way there and, with moving obstacles, the time step it is
+// reached at. Without keys, doors, wall breaks and obstacles the other fields
+// stay empty and states are just squares.
+type state struct {
+	maze.Point
+	keys   keySet
+	breaks int
+	t      int
+}
+
+// edge is a move from the node being expanded to one of its successors.
+// teleport marks a jump through a portal.
+type edge struct {
+	to       maze.Point
+	cost     float64
+	teleport bool
+}
+
+func newSearch(grid maze.Grid, start, goal maze.Point, opts []Option, spec searchSpec) (*Search, error) {
+	cfg, err := newConfig(grid, opts)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.contains(grid, start) || !cfg.contains(grid, goal) {
+		return nil, ErrOutOfBounds
+	}
+	if !isWalkable(cfg.floor(grid, start.Z), start) || !isWalkable(cfg.floor(grid, goal.Z), goal) {
+		return nil, ErrBlocked
+	}
+	if spec.squareOnly && cfg.topology != maze.TopologySquare {
+		return nil, ErrUnsupportedTopology
+	}
+	if spec.singleFloor && cfg.stairs != nil {
+		return nil, ErrUnsupportedFloors
+	}
+	if spec.positional && cfg.keyed {
+		return nil, ErrUnsupportedKeys
+	}
+	if spec.positional && cfg.breaks > 0 {
+		return nil, ErrUnsupportedWallBreaks
+	}
+	if spec.positional && cfg.timed() {
+		return nil, ErrUnsupportedObstacles
+	}
+	if cfg.occupied(start, 0) {
+		return nil, ErrBlocked
+	}
+
+	s := &Search{
+		grid:         grid,
+		start:        start,
+		goal:         goal,
+		cfg:          cfg,
+		open:         spec.open,
+		heuristic:    func(maze.Point) float64 { return 0 },
+		weight:       1,
+		successors:   gridSuccessors,
+		relax:        spec.relax,
+		segments:     spec.segments,
+		parents:      make(map[state]state),
+		teleported:   make(map[state]bool),
+		closed:       make(map[state]bool),
+		visitedOrder: make([]maze.Point, 0, len(grid)*len(grid[0])),
+	}
+	// The walker sets out at time 0 rather than stepping onto start.
+	s.origin = s.enter(start, state{})
+	s.origin.t = 0
+	s.gScore = map[state]float64{s.origin: 0}
+	if cfg.stateful() {
+		s.seen = make(map[maze.Point]bool)
+	}
+	if spec.informed {
+		s.heuristic = cfg.estimate(grid, goal)
+		s.weight = cfg.weight
+		s.guide = cfg.heuristic
+	}
+	if spec.optimal != nil {
+		s.optimal = spec.optimal(cfg)
+	}
+	if spec.successors != nil {
+		s.successors = spec.successors
+	}
+
+	h := s.heuristic(start)
+	s.open.push(&node{state: s.origin, priority: s.weight * h})
+	s.emit(Event{Kind: EventPush, Point: start, H: h, F: s.weight * h})
+
+	return s, nil
+}
+
+// Step returns the next event of the search. It returns false once the goal
+// has been reached or the open set is exhausted.
+func (s *Search) Step() (Event, bool) {
+	for len(s.pending) == 0 {
+		if s.done {
+			return Event{}, false
+		}
+		if s.pair != nil {
+			s.expandPair()
+		} else {
+			s.expand()
+		}
+	}
+
+	ev := s.pending[0]
+	s.pending = s.pending[1:]
+	return ev, true
+}
+
+// Events yields the remaining events of the search in order.
+func (s *Search) Events() iter.Seq[Event] {
+	return func(yield func(Event) bool) {
+		for {
+			ev, ok := s.Step()
+			if !ok || !yield(ev) {
+				return
+			}
+		}
+	}
+}
+
+// Run drains the search and returns its result.
+func (s *Search) Run() *Result {
+	for {
+		if _, ok := s.Step(); !ok {
+			return s.Result()
+		}
+	}
+}
+
+// Done reports whether the search has finished.
+func (s *Search) Done() bool {
+	return s.done && len(s.pending) == 0
+}
+
+// Result summarises the search so far. Path fields are only filled once the
+// goal has been reached.
+func (s *Search) Result() *Result {
+	result := &Result{
+		Found:         s.found,
+		VisitedOrder:  s.visitedOrder,
+		VisitedSides:  s.visitedSides,
+		ExpandedNodes: s.expanded,
+		Optimal:       s.optimal,
+	}
+	if s.guide != "" {
+		result.Heuristic = s.guide
+		result.Weight = s.weight
+	}
+
+	if s.found {
+		var path []maze.Point
+		var teleports []int
+		if s.pair != nil {
+			path, teleports = s.pair.path()
+			result.PathCost = s.pair.best
+		} else {
+			states := buildPath(s.parents, s.origin, s.reached)
+			path = squares(states)
+			teleports = s.teleports(states)
+			result.Pickups = pickups(states)
+			result.Breaks = breaks(states)
+			if s.cfg.timed() {
+				result.Times = times(states)
+			}
+			if s.segments {
+				path, teleports = expandSegments(path, teleports)
+			}
+			result.PathCost = s.gScore[s.reached]
+		}
+		result.Path = path
+		result.Teleports = teleports
+		if len(path) > 0 {
+			result.PathLength = len(path) - 1
+		}
+	}
+	if s.cfg.timed() {
+		steps := s.cfg.timeline.settle + s.cfg.timeline.cycle
+		if s.found {
+			steps = result.PathLength + 1
+		}
+		result.Obstacles = ObstacleFrames(s.cfg.obstacles, steps)
+	}
+
+	return result
+}
+
+// expand pops one node from the open set and queues the events it causes.
+func (s *Search) expand() {
+	if s.open.len() == 0 {
+		s.done = true
+		return
+	}
+
+	current := s.open.pop().state
+	if s.closed[current] {
+		return
+	}
+
+	s.closed[current] = true
+	s.expanded++
+	if s.seen == nil || !s.seen[current.Point] {
+		if s.seen != nil {
+			s.seen[current.Point] = true
+		}
+		s.visitedOrder = append(s.visitedOrder, current.Point)
+	}
+
+	g := s.gScore[current]
+	h := s.heuristic(current.Point)
+	f := g + s.weight*h
+	s.emit(Event{Kind: EventPop, Point: current.Point, G: g, H: h, F: f})
+
+	if current.Point == s.goal {
+		s.found = true
+		s.done = true
+		s.reached = current
+		s.emit(Event{Kind: EventGoalReached, Point: current.Point, G: g, H: h, F: f})
+		return
+	}
+
+	for _, e := range s.successors(s, current) {
+		next := s.enter(e.to, current)
+		if s.closed[next] {
+			continue
+		}
+
+		kind := EventPush
+		tentative := g + e.cost
+		if score, ok := s.gScore[next]; ok {
+			if !s.relax || tentative >= score {
+				continue
+			}
+			kind = EventRelax
+		}
+
+		s.parents[next] = current
+		s.teleported[next] = e.teleport
+		s.gScore[next] = tentative
+		nextH := s.heuristic(next.Point)
+		nextF := tentative + s.weight*nextH
+		s.open.push(&node{state: next, priority: nextF})
+
+		parent := current.Point
+		s.emit(Event{Kind: kind, Point: next.Point, Parent: &parent, G: tentative, H: nextH, F: nextF})
+	}
+}
+
+// enter returns the state of stepping onto p from the state from: the keys
+// held gain the key lying on p, if any, entering a wall breaks it, and with
+// moving obstacles the step takes one time step. Waiting on p enters nothing
+// new, so a walker waiting inside a wall it broke does not break it again.
+func (s *Search) enter(p maze.Point, from state) state {
+	next := state{Point: p}
+	if !s.cfg.stateful() {
+		return next
+	}
+	next.keys, next.breaks = from.keys, from.breaks
+	if s.cfg.timed() {
+		next.t = s.cfg.timeline.tick(from.t)
+	}
+	if p == from.Point {
+		return next
+	}
+	switch v := s.cfg.floor(s.grid, p.Z)[p.Y][p.X]; {
+	case v == wall:
+		next.breaks++
+	default:
+		if c, ok := maze.KeyColor(v); ok {
+			next.keys = next.keys.with(c)
+		}
+	}
+	return next
+}
+
+// teleports lists the indices of the states on path that s reached by jumping
+// through a portal from the state before.
+func (s *Search) teleports(path []state) []int {
+	var teleports []int
+	for i := 1; i < len(path); i++ {
+		if s.teleported[path[i]] {
+			teleports = append(teleports, i)
+		}
+	}
+	return teleports
+}
+
+// gridSuccessors steps to every neighbour the movement model allows with the
+// keys held, charging the cost of the entered cell scaled by the length of the
+// step.
+func gridSuccessors(s *Search, at state) []edge {
+	edges := s.cfg.moves(s.grid, at)
+	for i, e := range edges {
+		if !e.teleport {
+			edges[i].cost = e.cost * s.cfg.costs.Cost(e.to)
+		}
+	}
+	return edges
+}
+
+func (s *Search) emit(ev Event) {
+	s.pending = append(s.pending, ev)
+}
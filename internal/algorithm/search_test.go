@@ -0,0 +1,150 @@
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectEvents(t *testing.T, search *Search) []Event {
+	t.Helper()
+	events := []Event{}
+	for ev := range search.Events() {
+		events = append(events, ev)
+	}
+	return events
+}
+
+func TestSearch_BFSEventSequence(t *testing.T) {
+	grid := createTestGrid(3, 1, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 0}
+
+	search, err := NewBFSSearch(grid, start, goal)
+	require.NoError(t, err)
+
+	events := collectEvents(t, search)
+	kinds := make([]EventKind, 0, len(events))
+	for _, ev := range events {
+		kinds = append(kinds, ev.Kind)
+	}
+	assert.Equal(t, []EventKind{
+		EventPush, // start
+		EventPop, EventPush,
+		EventPop, EventPush,
+		EventPop, EventGoalReached,
+	}, kinds)
+
+	push := events[2]
+	require.NotNil(t, push.Parent)
+	assert.Equal(t, start, *push.Parent)
+	assert.Equal(t, maze.Point{X: 1, Y: 0}, push.Point)
+	assert.Equal(t, 1.0, push.G)
+
+	assert.True(t, search.Done())
+	_, ok := search.Step()
+	assert.False(t, ok)
+}
+
+func TestSearch_AStarScores(t *testing.T) {
+	grid := createTestGrid(4, 4, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 3, Y: 3}
+
+	search, err := NewAStarSearch(grid, start, goal)
+	require.NoError(t, err)
+
+	for ev := range search.Events() {
+		assert.InDelta(t, ev.G+ev.H, ev.F, 1e-9)
+		assert.Equal(t, manhattan(ev.Point, goal), ev.H)
+	}
+	assert.True(t, search.Result().Found)
+}
+
+func TestSearch_AStarEmitsRelax(t *testing.T) {
+	// (0,2) is first discovered from (1,2), which A* pops early because it sits
+	// closer to the goal, and is later improved through (0,1).
+	grid := createTestGrid(3, 3, nil)
+	costs := maze.CostGrid{
+		{2, 1, 1},
+		{2, 1, 3},
+		{3, 1, 3},
+	}
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 2}
+
+	search, err := NewAStarSearch(grid, start, goal, WithCosts(costs))
+	require.NoError(t, err)
+
+	var relaxed []Event
+	for ev := range search.Events() {
+		if ev.Kind == EventRelax {
+			relaxed = append(relaxed, ev)
+		}
+	}
+	require.NotEmpty(t, relaxed)
+	assert.Equal(t, maze.Point{X: 0, Y: 2}, relaxed[0].Point)
+	assert.Equal(t, maze.Point{X: 0, Y: 1}, *relaxed[0].Parent)
+	assert.Equal(t, 5.0, relaxed[0].G)
+
+	dijkstra, err := Dijkstra(grid, start, goal, WithCosts(costs))
+	require.NoError(t, err)
+	assert.Equal(t, dijkstra.PathCost, search.Result().PathCost)
+}
+
+func TestSearch_WrappersMatchStepper(t *testing.T) {
+	grid := createTestGrid(6, 6, []maze.Point{
+		{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1},
+		{X: 3, Y: 2}, {X: 3, Y: 3}, {X: 1, Y: 4},
+	})
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 5, Y: 5}
+
+	tests := []struct {
+		name   string
+		solve  func(maze.Grid, maze.Point, maze.Point, ...Option) (*Result, error)
+		search SearchFunc
+	}{
+		{"bfs", BFS, NewBFSSearch},
+		{"dfs", DFS, NewDFSSearch},
+		{"dijkstra", Dijkstra, NewDijkstraSearch},
+		{"astar", AStar, NewAStarSearch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := tt.solve(grid, start, goal)
+			require.NoError(t, err)
+
+			search, err := tt.search(grid, start, goal)
+			require.NoError(t, err)
+			pops := []maze.Point{}
+			for ev := range search.Events() {
+				if ev.Kind == EventPop {
+					pops = append(pops, ev.Point)
+				}
+			}
+
+			assert.Equal(t, want.VisitedOrder, pops)
+			assert.Equal(t, want, search.Result())
+		})
+	}
+}
+
+func TestSearch_PartialResult(t *testing.T) {
+	grid := createTestGrid(5, 5, nil)
+	search, err := NewBFSSearch(grid, maze.Point{X: 0, Y: 0}, maze.Point{X: 4, Y: 4})
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, ok := search.Step()
+		require.True(t, ok)
+	}
+
+	partial := search.Result()
+	assert.False(t, search.Done())
+	assert.False(t, partial.Found)
+	assert.NotEmpty(t, partial.VisitedOrder)
+}
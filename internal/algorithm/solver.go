@@ -0,0 +1,60 @@
+package algorithm
+
+import "github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+
+// Info describes a solver so callers can list and pick algorithms without
+// hard-coding their names. SupportsCosts reports whether the solver weighs each
+// step by the per-cell costs given with WithCosts rather than counting steps,
+// Topologies lists the grid topologies the solver runs on, MultiLevel reports
+// whether it accepts WithFloors, Keys whether it solves grids with keys and
+// doors, WallBreaks whether it accepts WithWallBreaks, and MovingObstacles
+// whether it accepts WithObstacles.
+type Info struct {
+	Name            string          `json:"name"`
+	Label           string          `json:"label"`
+	Aliases         []string        `json:"aliases"`
+	Optimal         bool            `json:"optimal"`
+	SupportsCosts   bool            `json:"supportsCosts"`
+	Topologies      []maze.Topology `json:"topologies"`
+	MultiLevel      bool            `json:"multiLevel"`
+	Keys            bool            `json:"keys"`
+	WallBreaks      bool            `json:"wallBreaks"`
+	MovingObstacles bool            `json:"movingObstacles"`
+}
+
+// Solver is a pathfinding algorithm that can be registered and looked up by name.
+// Search returns the step-by-step form of the algorithm; Solve runs it to completion.
+type Solver interface {
+	Info() Info
+	Search(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Search, error)
+	Solve(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Result, error)
+}
+
+// SearchFunc is the signature shared by the package-level New*Search constructors.
+type SearchFunc func(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Search, error)
+
+type funcSolver struct {
+	info   Info
+	search SearchFunc
+}
+
+// NewSolver wraps a SearchFunc and its metadata into a Solver.
+func NewSolver(info Info, search SearchFunc) Solver {
+	return &funcSolver{info: info, search: search}
+}
+
+func (s *funcSolver) Info() Info {
+	return s.info
+}
+
+func (s *funcSolver) Search(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Search, error) {
+	return s.search(grid, start, goal, opts...)
+}
+
+func (s *funcSolver) Solve(grid maze.Grid, start, goal maze.Point, opts ...Option) (*Result, error) {
+	search, err := s.search(grid, start, goal, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return search.Run(), nil
+}
@@ -0,0 +1,110 @@
+package algorithm
+
+import (
+	"context"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+)
+
+// Terrain is a map too large to hold as a Grid, such as an infinite chunked
+// world. Solvers look its squares up only as their search reaches them, which
+// lets an implementation load the map lazily.
+type Terrain interface {
+	Walkable(ctx context.Context, p maze.Point) (bool, error)
+}
+
+// TerrainAStar performs A* search on terrain from start to goal. It takes the
+// movement, heuristic and weight options of AStar; per-cell costs are not
+// supported and yield ErrInvalidCosts, and the polar and torus topologies, whose
+// grids are bounded, yield ErrUnsupportedTopology. Because terrain may be unbounded, the
+// search gives up after maxExpansions nodes and returns a Result with Found
+// unset. Errors from terrain lookups abort the search and are returned as is.
+func TerrainAStar(ctx context.Context, terrain Terrain, start, goal maze.Point, maxExpansions int, opts ...Option) (*Result, error) {
+	cfg, err := newConfig(nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.topology == maze.TopologyPolar || cfg.topology == maze.TopologyTorus {
+		return nil, ErrUnsupportedTopology
+	}
+
+	var lookupErr error
+	open := func(p maze.Point) bool {
+		if lookupErr != nil {
+			return false
+		}
+		walkable, err := terrain.Walkable(ctx, p)
+		if err != nil {
+			lookupErr = err
+			return false
+		}
+		return walkable
+	}
+	if !open(start) || !open(goal) {
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		return nil, ErrBlocked
+	}
+
+	distance := cfg.heuristic.distance()
+	heuristic := func(p maze.Point) float64 { return distance(p, goal) }
+
+	queue := newHeapFrontier()
+	queue.push(&node{state: state{Point: start}, priority: cfg.weight * heuristic(start)})
+	gScore := map[maze.Point]float64{start: 0}
+	parents := make(map[maze.Point]maze.Point)
+	closed := make(map[maze.Point]bool)
+	result := &Result{
+		VisitedOrder: []maze.Point{},
+		Heuristic:    cfg.heuristic,
+		Weight:       cfg.weight,
+		Optimal:      informedOptimal(cfg),
+	}
+
+	for queue.len() > 0 && len(result.VisitedOrder) < maxExpansions {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		current := queue.pop().Point
+		if closed[current] {
+			continue
+		}
+		closed[current] = true
+		result.VisitedOrder = append(result.VisitedOrder, current)
+
+		if current == goal {
+			result.Found = true
+			result.Path = buildPath(parents, start, goal)
+			result.PathLength = len(result.Path) - 1
+			result.PathCost = gScore[goal]
+			break
+		}
+
+		g := gScore[current]
+		for _, dir := range cfg.directions() {
+			allowed := cfg.canStepOn(open, current, dir)
+			if lookupErr != nil {
+				return nil, lookupErr
+			}
+			if !allowed {
+				continue
+			}
+			next := maze.Point{X: current.X + dir.X, Y: current.Y + dir.Y}
+			if closed[next] {
+				continue
+			}
+			tentative := g + cfg.stepLength(dir)
+			if score, ok := gScore[next]; ok && tentative >= score {
+				continue
+			}
+			parents[next] = current
+			gScore[next] = tentative
+			queue.push(&node{state: state{Point: next}, priority: tentative + cfg.weight*heuristic(next)})
+		}
+	}
+
+	result.ExpandedNodes = len(result.VisitedOrder)
+	return result, nil
+}
@@ -0,0 +1,102 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gridTerrain serves a finite grid as Terrain, with everything outside it walled.
+type gridTerrain struct {
+	grid    maze.Grid
+	lookups int
+	err     error
+}
+
+func (g *gridTerrain) Walkable(_ context.Context, p maze.Point) (bool, error) {
+	g.lookups++
+	if g.err != nil {
+		return false, g.err
+	}
+	return inBounds(g.grid, p) && isWalkable(g.grid, p), nil
+}
+
+func TestTerrainAStar_MatchesAStarOnGrids(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(23))
+	for i := 0; i < 100; i++ {
+		var start, goal maze.Point
+		grid := randomTestGrid(rng, &start, &goal)
+
+		for _, movement := range Movements {
+			want, err := AStar(grid, start, goal, WithMovement(movement))
+			require.NoError(t, err)
+			got, err := TerrainAStar(ctx, &gridTerrain{grid: grid}, start, goal, 1000, WithMovement(movement))
+			require.NoError(t, err)
+
+			require.Equal(t, want.Found, got.Found, "grid %d (%s)", i, movement)
+			require.InDelta(t, want.PathCost, got.PathCost, 1e-9, "grid %d (%s)", i, movement)
+			if got.Found {
+				assertStepwisePath(t, grid, got.Path, movement)
+			}
+		}
+	}
+}
+
+func TestTerrainAStar_LoadsWorldChunksLazily(t *testing.T) {
+	ctx := context.Background()
+	world, err := maze.NewWorld(31, 8)
+	require.NoError(t, err)
+	cache := maze.NewChunkCache(world)
+
+	start := maze.Point{X: 1, Y: 1}
+	goal := maze.Point{X: 3*16 + 5, Y: 7}
+	result, err := TerrainAStar(ctx, cache, start, goal, 100000)
+	require.NoError(t, err)
+
+	require.True(t, result.Found)
+	assert.Equal(t, start, result.Path[0])
+	assert.Equal(t, goal, result.Path[len(result.Path)-1])
+	for i := 1; i < len(result.Path); i++ {
+		a, b := result.Path[i-1], result.Path[i]
+		assert.Contains(t, directions, maze.Point{X: b.X - a.X, Y: b.Y - a.Y})
+		open, err := cache.Walkable(ctx, b)
+		require.NoError(t, err)
+		assert.True(t, open)
+	}
+
+	loaded := cache.Loaded()
+	assert.Contains(t, loaded, maze.ChunkCoord{X: 0, Y: 0})
+	assert.Contains(t, loaded, maze.ChunkCoord{X: 3, Y: 0})
+	assert.Less(t, len(loaded), 100, "only chunks near the route should be generated")
+}
+
+func TestTerrainAStar_Limits(t *testing.T) {
+	ctx := context.Background()
+	grid := createTestGrid(10, 10, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 9, Y: 9}
+
+	result, err := TerrainAStar(ctx, &gridTerrain{grid: grid}, start, goal, 5)
+	require.NoError(t, err)
+	assert.False(t, result.Found)
+	assert.Equal(t, 5, result.ExpandedNodes)
+
+	broken := errors.New("chunk store unavailable")
+	_, err = TerrainAStar(ctx, &gridTerrain{grid: grid, err: broken}, start, goal, 5)
+	assert.ErrorIs(t, err, broken)
+
+	_, err = TerrainAStar(ctx, &gridTerrain{grid: grid}, start, maze.Point{X: 20, Y: 0}, 5)
+	assert.ErrorIs(t, err, ErrBlocked)
+
+	_, err = TerrainAStar(ctx, &gridTerrain{grid: grid}, start, goal, 5, WithCosts(createTestCosts(10, 10, nil)))
+	assert.ErrorIs(t, err, ErrInvalidCosts)
+
+	_, err = TerrainAStar(ctx, &gridTerrain{grid: grid}, start, goal, 5, WithTopology(maze.TopologyPolar))
+	assert.ErrorIs(t, err, ErrUnsupportedTopology)
+}
@@ -0,0 +1,293 @@
+package algorithm
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bfsDistances runs a plain breadth-first search over the squares neighbours
+// lists, which must all lie on grid, and returns the step count from start to
+// every reachable open square.
+func bfsDistances(grid maze.Grid, start maze.Point, neighbours func(maze.Point) []maze.Point) map[maze.Point]int {
+	dist := map[maze.Point]int{start: 0}
+	queue := []maze.Point{start}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, n := range neighbours(p) {
+			if _, seen := dist[n]; seen || !isWalkable(grid, n) {
+				continue
+			}
+			dist[n] = dist[p] + 1
+			queue = append(queue, n)
+		}
+	}
+	return dist
+}
+
+// openWalls opens the walls at points of a generated maze, giving it loops so
+// that the solvers can disagree on the path they take.
+func openWalls(grid maze.Grid, points ...maze.Point) {
+	for _, p := range points {
+		grid[p.Y][p.X] = 0
+	}
+}
+
+// hexNeighbours lists the tiles of grid touching p.
+func hexNeighbours(grid maze.Grid) func(maze.Point) []maze.Point {
+	return func(p maze.Point) []maze.Point {
+		var out []maze.Point
+		for _, d := range maze.HexDirections {
+			if n := (maze.Point{X: p.X + d.X, Y: p.Y + d.Y}); inBounds(grid, n) {
+				out = append(out, n)
+			}
+		}
+		return out
+	}
+}
+
+func assertHexPath(t *testing.T, grid maze.Grid, path []maze.Point) {
+	t.Helper()
+	for i := 1; i < len(path); i++ {
+		a, b := path[i-1], path[i]
+		require.Contains(t, maze.HexDirections, maze.Point{X: b.X - a.X, Y: b.Y - a.Y}, "step %d is not a hex step", i)
+		require.True(t, isWalkable(grid, b))
+	}
+}
+
+func TestSolvers_HexTopology(t *testing.T) {
+	seed := int64(8)
+	generated, err := maze.NewHexGenerator().Generate(context.Background(), 10, 8, &seed)
+	require.NoError(t, err)
+	grid := generated.Grid
+	openWalls(grid, maze.Point{X: 4, Y: 4}, maze.Point{X: 10, Y: 8}, maze.Point{X: 6, Y: 10})
+
+	start := maze.Point{X: 1, Y: 1}
+	goal := maze.Point{X: 19, Y: 15}
+	want := bfsDistances(grid, start, hexNeighbours(grid))[goal]
+
+	registry := NewDefaultRegistry()
+	for _, info := range registry.List() {
+		if !slices.Contains(info.Topologies, maze.TopologyHex) {
+			continue
+		}
+		t.Run(info.Name, func(t *testing.T) {
+			solver, _ := registry.Lookup(info.Name)
+			result, err := solver.Solve(grid, start, goal, WithTopology(maze.TopologyHex))
+			require.NoError(t, err)
+			require.True(t, result.Found)
+			assertHexPath(t, grid, result.Path)
+			assert.Equal(t, start, result.Path[0])
+			assert.Equal(t, goal, result.Path[len(result.Path)-1])
+			if info.Optimal {
+				assert.Equal(t, want, result.PathLength)
+				assert.Equal(t, float64(want), result.PathCost)
+			}
+		})
+	}
+}
+
+func TestAStar_HexHeuristics(t *testing.T) {
+	grid := createTestGrid(12, 12, nil)
+	start := maze.Point{X: 0, Y: 11}
+	goal := maze.Point{X: 11, Y: 0}
+
+	result, err := AStar(grid, start, goal, WithTopology(maze.TopologyHex))
+	require.NoError(t, err)
+	assert.Equal(t, HeuristicHex, result.Heuristic)
+	assert.True(t, result.Optimal)
+	assert.Equal(t, 11, result.PathLength, "the (1,-1) axis is a straight hex line")
+	assert.Equal(t, result.PathLength, len(result.VisitedOrder)-1, "hex distance is exact on an open grid")
+
+	result, err = AStar(grid, start, goal, WithTopology(maze.TopologyHex), WithHeuristic(HeuristicChebyshev))
+	require.NoError(t, err)
+	assert.True(t, result.Optimal)
+
+	result, err = AStar(grid, start, goal, WithTopology(maze.TopologyHex), WithHeuristic(HeuristicManhattan))
+	require.NoError(t, err)
+	assert.False(t, result.Optimal)
+}
+
+func TestSolvers_HexTopologyErrors(t *testing.T) {
+	grid := createTestGrid(3, 3, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 2}
+
+	_, err := JPS(grid, start, goal, WithTopology(maze.TopologyHex))
+	assert.ErrorIs(t, err, ErrUnsupportedTopology)
+
+	_, err = BFS(grid, start, goal, WithTopology(maze.TopologyHex), WithMovement(MovementEightWay))
+	assert.ErrorIs(t, err, ErrUnsupportedTopology)
+
+	_, err = BFS(grid, start, goal, WithTopology("triangle"))
+	assert.ErrorIs(t, err, maze.ErrUnknownTopology)
+}
+
+func TestSolvers_PolarTopology(t *testing.T) {
+	seed := int64(4)
+	generated, err := maze.NewPolarGenerator().Generate(context.Background(), 6, 6, &seed)
+	require.NoError(t, err)
+	grid := generated.Grid
+	// Open every wall of one ring so the maze has loops.
+	for x := range grid[5] {
+		grid[5][x] = 0
+	}
+
+	start := maze.Point{X: 0, Y: len(grid) - 1}
+	goal := maze.Point{X: 0, Y: 0}
+	want := bfsDistances(grid, start, func(p maze.Point) []maze.Point { return maze.PolarNeighbors(grid, p) })[goal]
+
+	registry := NewDefaultRegistry()
+	for _, info := range registry.List() {
+		if !slices.Contains(info.Topologies, maze.TopologyPolar) {
+			continue
+		}
+		t.Run(info.Name, func(t *testing.T) {
+			solver, _ := registry.Lookup(info.Name)
+			result, err := solver.Solve(grid, start, goal, WithTopology(maze.TopologyPolar))
+			require.NoError(t, err)
+			require.True(t, result.Found)
+			for i := 1; i < len(result.Path); i++ {
+				require.Contains(t, maze.PolarNeighbors(grid, result.Path[i-1]), result.Path[i], "step %d does not touch", i)
+				require.True(t, isWalkable(grid, result.Path[i]))
+			}
+			assert.Equal(t, start, result.Path[0])
+			assert.Equal(t, goal, result.Path[len(result.Path)-1])
+			if info.Optimal {
+				assert.Equal(t, want, result.PathLength)
+			}
+		})
+	}
+}
+
+func TestAStar_PolarHeuristics(t *testing.T) {
+	seed := int64(9)
+	generated, err := maze.NewPolarGenerator().Generate(context.Background(), 5, 5, &seed)
+	require.NoError(t, err)
+	start := maze.Point{X: 0, Y: len(generated.Grid) - 1}
+	goal := maze.Point{X: 0, Y: 0}
+
+	result, err := AStar(generated.Grid, start, goal, WithTopology(maze.TopologyPolar))
+	require.NoError(t, err)
+	assert.Equal(t, HeuristicRadial, result.Heuristic)
+	assert.True(t, result.Optimal)
+
+	result, err = AStar(generated.Grid, start, goal, WithTopology(maze.TopologyPolar), WithHeuristic(HeuristicManhattan))
+	require.NoError(t, err)
+	assert.False(t, result.Optimal)
+}
+
+func TestSolvers_PolarTopologyErrors(t *testing.T) {
+	square := createTestGrid(5, 5, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 0, Y: 2}
+
+	_, err := BFS(square, start, goal, WithTopology(maze.TopologyPolar))
+	assert.ErrorIs(t, err, maze.ErrInvalidPolarGrid)
+
+	seed := int64(1)
+	generated, err := maze.NewPolarGenerator().Generate(context.Background(), 3, 3, &seed)
+	require.NoError(t, err)
+
+	_, err = JPS(generated.Grid, start, goal, WithTopology(maze.TopologyPolar))
+	assert.ErrorIs(t, err, ErrUnsupportedTopology)
+
+	_, err = BFS(generated.Grid, start, goal, WithTopology(maze.TopologyPolar), WithMovement(MovementEightWay))
+	assert.ErrorIs(t, err, ErrUnsupportedTopology)
+}
+
+// torusNeighbours lists the squares of grid touching p, wrapping around its
+// edges.
+func torusNeighbours(grid maze.Grid) func(maze.Point) []maze.Point {
+	return func(p maze.Point) []maze.Point {
+		var out []maze.Point
+		for _, d := range directions {
+			n, _ := normalize(grid, maze.Point{X: p.X + d.X, Y: p.Y + d.Y}, true)
+			out = append(out, n)
+		}
+		return out
+	}
+}
+
+func TestSolvers_TorusTopology(t *testing.T) {
+	seed := int64(6)
+	generated, err := maze.NewKruskalGenerator().Generate(context.Background(), 9, 7, &seed, maze.WithWrap())
+	require.NoError(t, err)
+	grid := generated.Grid
+	openWalls(grid, maze.Point{X: 3, Y: 0}, maze.Point{X: 0, Y: 5}, maze.Point{X: 9, Y: 8}, maze.Point{X: 14, Y: 3})
+
+	start := maze.Point{X: 1, Y: 1}
+	goal := maze.Point{X: 17, Y: 13}
+	want := bfsDistances(grid, start, torusNeighbours(grid))[goal]
+
+	registry := NewDefaultRegistry()
+	for _, info := range registry.List() {
+		if !slices.Contains(info.Topologies, maze.TopologyTorus) {
+			continue
+		}
+		t.Run(info.Name, func(t *testing.T) {
+			solver, _ := registry.Lookup(info.Name)
+			result, err := solver.Solve(grid, start, goal, WithTopology(maze.TopologyTorus))
+			require.NoError(t, err)
+			require.True(t, result.Found)
+			for i := 1; i < len(result.Path); i++ {
+				a, b := result.Path[i-1], result.Path[i]
+				dx, dy := wrapOffset(b.X-a.X, len(grid[0])), wrapOffset(b.Y-a.Y, len(grid))
+				require.Equal(t, 1.0, manhattan(maze.Point{}, maze.Point{X: dx, Y: dy}), "step %d is not a single move", i)
+				require.True(t, isWalkable(grid, b))
+			}
+			assert.Equal(t, start, result.Path[0])
+			assert.Equal(t, goal, result.Path[len(result.Path)-1])
+			if info.Optimal {
+				assert.Equal(t, want, result.PathLength)
+			}
+		})
+	}
+}
+
+func TestAStar_TorusHeuristics(t *testing.T) {
+	grid := createTestGrid(10, 6, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 9, Y: 5}
+
+	result, err := AStar(grid, start, goal, WithTopology(maze.TopologyTorus))
+	require.NoError(t, err)
+	assert.Equal(t, HeuristicManhattan, result.Heuristic)
+	assert.True(t, result.Optimal)
+	assert.Equal(t, 2, result.PathLength, "one step west and one north across the seams")
+	assert.Less(t, len(result.VisitedOrder), 5, "wrapped manhattan is exact on an open torus")
+
+	result, err = AStar(grid, start, goal, WithTopology(maze.TopologyTorus), WithMovement(MovementEightWay))
+	require.NoError(t, err)
+	assert.Equal(t, HeuristicOctile, result.Heuristic)
+	assert.True(t, result.Optimal)
+	assert.Equal(t, 1, result.PathLength, "the corners touch diagonally")
+
+	result, err = AStar(grid, start, goal, WithTopology(maze.TopologyTorus), WithHeuristic(HeuristicHex))
+	require.NoError(t, err)
+	assert.False(t, result.Optimal)
+
+	cfg, err := newConfig(grid, []Option{WithTopology(maze.TopologyTorus), WithHeuristic(HeuristicEuclidean)})
+	require.NoError(t, err)
+	assert.InDelta(t, math.Hypot(3, 2), cfg.distance(grid)(maze.Point{X: 1, Y: 1}, maze.Point{X: 8, Y: 5}), 1e-9)
+}
+
+func TestSolvers_TorusTopologyErrors(t *testing.T) {
+	grid := createTestGrid(4, 4, nil)
+	start := maze.Point{X: 0, Y: 0}
+
+	_, err := JPS(grid, start, maze.Point{X: 3, Y: 3}, WithTopology(maze.TopologyTorus))
+	assert.ErrorIs(t, err, ErrUnsupportedTopology)
+
+	_, err = BFS(grid, start, maze.Point{X: 4, Y: 0}, WithTopology(maze.TopologyTorus))
+	assert.ErrorIs(t, err, ErrOutOfBounds, "start and goal must lie on the grid itself")
+
+	_, err = TerrainAStar(context.Background(), &gridTerrain{grid: grid}, start, maze.Point{X: 3, Y: 3}, 10, WithTopology(maze.TopologyTorus))
+	assert.ErrorIs(t, err, ErrUnsupportedTopology)
+}
@@ -4,11 +4,34 @@ import "github.com/JoshuaPangaribuan/pathfinder/internal/maze"
 
 // Result captures the output of a pathfinding algorithm run.
 // It includes whether a path was found, the path itself, the order nodes were visited,
-// the number of expanded nodes, and the path length.
+// the number of expanded nodes, the path length in steps and the total movement cost.
+// Bidirectional solvers fill VisitedSides with the side that expanded each entry of
+// VisitedOrder. Informed solvers also report the heuristic and weight they ranked nodes with, and
+// Optimal tells whether the solver and its options guarantee the cheapest path.
+// Teleports lists the indices of the points on Path reached by jumping through a
+// portal from the point before, rather than by stepping. On grids with keys and
+// doors Pickups lists the indices of the points on Path where a key is picked
+// up, and with WithWallBreaks Breaks lists the indices of the walls it breaks
+// through. VisitedOrder then lists each square once, the first time it is
+// expanded, while ExpandedNodes counts every (square, keys held, walls broken)
+// state expanded. With WithObstacles Times holds the time step each point of
+// Path is reached at, and Obstacles where every obstacle stands at each time
+// step up to the goal, or over one whole pattern of their moves if no path was
+// found.
 type Result struct {
-	Found         bool         `json:"found"`
-	Path          []maze.Point `json:"path"`
-	VisitedOrder  []maze.Point `json:"visitedOrder"`
-	ExpandedNodes int          `json:"expandedNodes"`
-	PathLength    int          `json:"pathLength"`
+	Found         bool           `json:"found"`
+	Path          []maze.Point   `json:"path"`
+	VisitedOrder  []maze.Point   `json:"visitedOrder"`
+	VisitedSides  []Side         `json:"visitedSides,omitempty"`
+	ExpandedNodes int            `json:"expandedNodes"`
+	PathLength    int            `json:"pathLength"`
+	PathCost      float64        `json:"pathCost"`
+	Teleports     []int          `json:"teleports,omitempty"`
+	Pickups       []int          `json:"pickups,omitempty"`
+	Breaks        []int          `json:"breaks,omitempty"`
+	Times         []int          `json:"times,omitempty"`
+	Obstacles     [][]maze.Point `json:"obstacles,omitempty"`
+	Heuristic     Heuristic      `json:"heuristic,omitempty"`
+	Weight        float64        `json:"weight,omitempty"`
+	Optimal       bool           `json:"optimal"`
 }
@@ -39,6 +39,11 @@ func Int64(key string, value int64) Field {
 	return Field{Key: key, Value: value}
 }
 
+// Float64 creates a float64 field
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Value: value}
+}
+
 // Bool creates a bool field
 func Bool(key string, value bool) Field {
 	return Field{Key: key, Value: value}
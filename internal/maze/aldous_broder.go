@@ -0,0 +1,52 @@
+package maze
+
+import "context"
+
+// AldousBroderGenerator implements Generator with the Aldous-Broder algorithm.
+type AldousBroderGenerator struct{}
+
+// NewAldousBroderGenerator creates an Aldous-Broder maze generator.
+func NewAldousBroderGenerator() Generator {
+	return &AldousBroderGenerator{}
+}
+
+// Generate random-walks the grid from a random cell, carving a passage whenever
+// the walk enters a cell for the first time, until every cell has been visited.
+// Like Wilson's algorithm it yields a uniform spanning tree, but it needs many
+// more steps on large grids because the walk keeps crossing visited areas.
+func (g *AldousBroderGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+	if width < 2 || height < 2 {
+		return GenerateResult{}, ErrInvalidDimensions
+	}
+
+	rng := newRNG(seed)
+	cv, err := newCellCanvas(width, height, opts)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+	visited := newVisited(width, height)
+
+	current := cell{x: rng.Intn(width), y: rng.Intn(height)}
+	visited[current.y][current.x] = true
+	carveCell(cv, current.x, current.y)
+
+	for remaining := width*height - 1; remaining > 0; {
+		if err := ctx.Err(); err != nil {
+			return GenerateResult{}, err
+		}
+
+		neighbors := cv.neighbors(current)
+		step := neighbors[rng.Intn(len(neighbors))]
+		if !visited[step.y][step.x] {
+			visited[step.y][step.x] = true
+			carvePassage(cv, current, step)
+			remaining--
+		}
+		current = step
+	}
+
+	return cv.result(AlgorithmAldousBroder, seed), nil
+}
@@ -0,0 +1,74 @@
+package maze
+
+import "strings"
+
+// Algorithm names a maze generation algorithm.
+type Algorithm string
+
+const (
+	// AlgorithmBacktracker is the iterative recursive backtracker: long, winding
+	// corridors with few branches.
+	AlgorithmBacktracker Algorithm = "backtracker"
+	// AlgorithmKruskal joins cells in random wall order: many short dead ends.
+	AlgorithmKruskal Algorithm = "kruskal"
+	// AlgorithmPrim grows the maze outwards from one cell: highly branched.
+	AlgorithmPrim Algorithm = "prim"
+	// AlgorithmWilson draws a uniform spanning tree with loop-erased random walks.
+	AlgorithmWilson Algorithm = "wilson"
+	// AlgorithmAldousBroder draws a uniform spanning tree with a plain random walk.
+	AlgorithmAldousBroder Algorithm = "aldous-broder"
+	// AlgorithmRecursiveDivision splits open space with walls: long straight
+	// corridors in nested rectangles.
+	AlgorithmRecursiveDivision Algorithm = "recursive-division"
+	// AlgorithmEller builds the maze row by row with O(width) memory.
+	AlgorithmEller Algorithm = "eller"
+	// AlgorithmCave grows organic open caves with a cellular automaton.
+	AlgorithmCave Algorithm = "cave"
+	// AlgorithmDungeon places rectangular rooms joined by corridors.
+	AlgorithmDungeon Algorithm = "dungeon"
+)
+
+// DefaultAlgorithm is used when a request does not name an algorithm.
+const DefaultAlgorithm = AlgorithmBacktracker
+
+// Algorithms lists every built-in generation algorithm.
+var Algorithms = []Algorithm{
+	AlgorithmBacktracker,
+	AlgorithmKruskal,
+	AlgorithmPrim,
+	AlgorithmWilson,
+	AlgorithmAldousBroder,
+	AlgorithmRecursiveDivision,
+	AlgorithmEller,
+	AlgorithmCave,
+	AlgorithmDungeon,
+}
+
+// ParseAlgorithm converts an algorithm name into an Algorithm. Matching is
+// case-insensitive and an empty name selects DefaultAlgorithm.
+func ParseAlgorithm(name string) (Algorithm, error) {
+	if name == "" {
+		return DefaultAlgorithm, nil
+	}
+	for _, a := range Algorithms {
+		if strings.EqualFold(name, string(a)) {
+			return a, nil
+		}
+	}
+	return "", ErrUnknownAlgorithm
+}
+
+// Generators returns a fresh generator for every built-in algorithm.
+func Generators() map[Algorithm]Generator {
+	return map[Algorithm]Generator{
+		AlgorithmBacktracker:       NewGenerator(),
+		AlgorithmKruskal:           NewKruskalGenerator(),
+		AlgorithmPrim:              NewPrimGenerator(),
+		AlgorithmWilson:            NewWilsonGenerator(),
+		AlgorithmAldousBroder:      NewAldousBroderGenerator(),
+		AlgorithmRecursiveDivision: NewRecursiveDivisionGenerator(),
+		AlgorithmEller:             NewEllerGenerator(),
+		AlgorithmCave:              NewCaveGenerator(DefaultCaveConfig()),
+		AlgorithmDungeon:           NewDungeonGenerator(DefaultDungeonConfig()),
+	}
+}
@@ -0,0 +1,132 @@
+package maze
+
+import (
+	"context"
+	"math"
+	"math/rand"
+)
+
+// BraidAlgorithms lists the algorithms whose mazes BraidedGenerator can braid:
+// those laid out as odd cells separated by single walls. Caves and dungeons
+// have no such lattice, so braiding them would carve through solid rock.
+var BraidAlgorithms = []Algorithm{
+	AlgorithmBacktracker,
+	AlgorithmKruskal,
+	AlgorithmPrim,
+	AlgorithmWilson,
+	AlgorithmAldousBroder,
+	AlgorithmRecursiveDivision,
+	AlgorithmEller,
+}
+
+// BraidedGenerator wraps another Generator and braids its mazes: it removes a
+// fraction of the dead ends by knocking out one of their walls, which turns the
+// perfect maze into one with loops and several routes between most cells.
+type BraidedGenerator struct {
+	base    Generator
+	density float64
+}
+
+// NewBraidedGenerator creates a generator that braids the mazes of base.
+// density is the fraction of dead ends to remove, between 0 (a perfect maze)
+// and 1 (no dead ends at all).
+func NewBraidedGenerator(base Generator, density float64) Generator {
+	return &BraidedGenerator{base: base, density: density}
+}
+
+// Generate builds a maze with the wrapped generator and braids it. The braiding
+// draws from its own random source seeded from seed, so the output stays
+// deterministic for a given seed.
+func (g *BraidedGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	if g.density < 0 || g.density > 1 || math.IsNaN(g.density) {
+		return GenerateResult{}, ErrInvalidBraid
+	}
+
+	result, err := g.base.Generate(ctx, width, height, seed, opts...)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+
+	cv := wrapCanvas(result.Grid, opts)
+	cv.wrap = result.Topology == TopologyTorus
+	braid(cv, g.density, newRNG(seed))
+	result.Braid = g.density
+	return result, nil
+}
+
+// Braid removes round(density * n) of the n dead ends of a maze grid in the
+// cell/wall layout produced by the generators. Dead ends are visited in an order
+// drawn from rng; each one still closed off when its turn comes gets a wall
+// knocked out, preferring a wall that also opens up a neighbouring dead end. It
+// returns the number of walls removed.
+func Braid(grid Grid, density float64, rng *rand.Rand) int {
+	return braid(wrapCanvas(grid, nil), density, rng)
+}
+
+// braid is Braid on a canvas, so braiding inside a generator emits events and
+// knocks out walls across the seams of a torus.
+func braid(cv *canvas, density float64, rng *rand.Rand) int {
+	width, height := cv.cells()
+
+	var deadEnds []cell
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if c := (cell{x: x, y: y}); isDeadEnd(cv, c) {
+				deadEnds = append(deadEnds, c)
+			}
+		}
+	}
+	rng.Shuffle(len(deadEnds), func(i, j int) {
+		deadEnds[i], deadEnds[j] = deadEnds[j], deadEnds[i]
+	})
+
+	removed := 0
+	target := int(math.Round(density * float64(len(deadEnds))))
+	for _, c := range deadEnds[:target] {
+		if !isDeadEnd(cv, c) {
+			continue
+		}
+
+		var closed []cell
+		for _, n := range cv.neighbors(c) {
+			if !hasPassage(cv, c, n) {
+				closed = append(closed, n)
+			}
+		}
+		rng.Shuffle(len(closed), func(i, j int) {
+			closed[i], closed[j] = closed[j], closed[i]
+		})
+
+		pick := closed[0]
+		for _, n := range closed {
+			if isDeadEnd(cv, n) {
+				pick = n
+				break
+			}
+		}
+		carvePassage(cv, c, pick)
+		removed++
+	}
+
+	return removed
+}
+
+// isDeadEnd reports whether exactly one passage leads out of c.
+func isDeadEnd(cv *canvas, c cell) bool {
+	open := 0
+	for _, n := range cv.neighbors(c) {
+		if hasPassage(cv, c, n) {
+			open++
+		}
+	}
+	return open == 1
+}
+
+// hasPassage reports whether the wall between the adjacent cells a and b is open.
+func hasPassage(cv *canvas, a, b cell) bool {
+	wall := cv.wallBetween(a, b)
+	return cv.grid[wall.Y][wall.X] == 0
+}
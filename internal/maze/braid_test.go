@@ -0,0 +1,84 @@
+package maze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countDeadEnds(grid Grid) int {
+	cv := wrapCanvas(grid, nil)
+	width, height := cv.cells()
+	count := 0
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if isDeadEnd(cv, cell{x: x, y: y}) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func countPassages(grid Grid) int {
+	count := 0
+	for y := 1; y < len(grid)-1; y++ {
+		for x := 1; x < len(grid[y])-1; x++ {
+			if (x+y)%2 == 1 && grid[y][x] == 0 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestBraidedGenerator_RemovesDeadEnds(t *testing.T) {
+	ctx := context.Background()
+	seed := int64(99)
+
+	perfect, err := NewGenerator().Generate(ctx, 20, 20, &seed)
+	require.NoError(t, err)
+	deadEnds := countDeadEnds(perfect.Grid)
+	require.Greater(t, deadEnds, 0)
+
+	none, err := NewBraidedGenerator(NewGenerator(), 0).Generate(ctx, 20, 20, &seed)
+	require.NoError(t, err)
+	assert.Equal(t, perfect.Grid, none.Grid)
+
+	half, err := NewBraidedGenerator(NewGenerator(), 0.5).Generate(ctx, 20, 20, &seed)
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, half.Braid)
+	assert.Less(t, countDeadEnds(half.Grid), deadEnds)
+	assert.LessOrEqual(t, countDeadEnds(half.Grid), deadEnds-deadEnds/2)
+	assert.Greater(t, countPassages(half.Grid), 20*20-1, "braiding must create loops")
+
+	full, err := NewBraidedGenerator(NewGenerator(), 1).Generate(ctx, 20, 20, &seed)
+	require.NoError(t, err)
+	assert.Zero(t, countDeadEnds(full.Grid))
+}
+
+func TestBraidedGenerator_Deterministic(t *testing.T) {
+	ctx := context.Background()
+	seed := int64(5)
+
+	for algorithm, base := range perfectGenerators() {
+		t.Run(string(algorithm), func(t *testing.T) {
+			gen := NewBraidedGenerator(base, 0.4)
+			first, err := gen.Generate(ctx, 15, 10, &seed)
+			require.NoError(t, err)
+			second, err := gen.Generate(ctx, 15, 10, &seed)
+			require.NoError(t, err)
+			assert.Equal(t, first.Grid, second.Grid)
+			assert.Equal(t, algorithm, first.Algorithm)
+		})
+	}
+}
+
+func TestBraidedGenerator_InvalidDensity(t *testing.T) {
+	for _, density := range []float64{-0.1, 1.5} {
+		_, err := NewBraidedGenerator(NewGenerator(), density).Generate(context.Background(), 5, 5, nil)
+		assert.ErrorIs(t, err, ErrInvalidBraid)
+	}
+}
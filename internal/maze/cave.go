@@ -0,0 +1,290 @@
+package maze
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// ErrInvalidCaveConfig indicates cave settings outside their valid ranges.
+var ErrInvalidCaveConfig = errors.New("cave settings must have fill between 0 and 1, passes of at least 0, limits between 0 and 8 and connectivity join or largest")
+
+// CaveConnectivity decides how a cave deals with floor pockets that smoothing
+// left disconnected from each other.
+type CaveConnectivity string
+
+const (
+	// CaveConnectivityJoin digs the shortest tunnel from the connected cave to
+	// each isolated pocket until every floor cell is reachable.
+	CaveConnectivityJoin CaveConnectivity = "join"
+	// CaveConnectivityLargest fills every pocket except the largest one.
+	CaveConnectivityLargest CaveConnectivity = "largest"
+)
+
+// CaveConfig tunes the cellular automaton behind CaveGenerator. Counts refer to
+// the eight cells around a cell, with cells beyond the border counting as walls.
+type CaveConfig struct {
+	// FillProbability is the chance that a cell starts out as wall.
+	FillProbability float64
+	// SmoothingPasses is how many times the automaton rules are applied.
+	SmoothingPasses int
+	// BirthLimit turns a floor cell into wall when at least this many neighbours are walls.
+	BirthLimit int
+	// SurvivalLimit keeps a wall cell standing when at least this many neighbours are walls.
+	SurvivalLimit int
+	// Connectivity selects how isolated pockets are handled.
+	Connectivity CaveConnectivity
+}
+
+// DefaultCaveConfig returns the classic 4-5 rule: 45% initial walls smoothed
+// four times, with pockets joined by tunnels.
+func DefaultCaveConfig() CaveConfig {
+	return CaveConfig{
+		FillProbability: 0.45,
+		SmoothingPasses: 4,
+		BirthLimit:      5,
+		SurvivalLimit:   4,
+		Connectivity:    CaveConnectivityJoin,
+	}
+}
+
+// Validate reports whether every setting is within range.
+func (c CaveConfig) Validate() error {
+	switch {
+	case c.FillProbability < 0 || c.FillProbability > 1 || math.IsNaN(c.FillProbability):
+		return ErrInvalidCaveConfig
+	case c.SmoothingPasses < 0:
+		return ErrInvalidCaveConfig
+	case c.BirthLimit < 0 || c.BirthLimit > 8 || c.SurvivalLimit < 0 || c.SurvivalLimit > 8:
+		return ErrInvalidCaveConfig
+	case c.Connectivity != CaveConnectivityJoin && c.Connectivity != CaveConnectivityLargest:
+		return ErrInvalidCaveConfig
+	}
+	return nil
+}
+
+// CaveGenerator implements Generator with a cellular automaton that produces
+// organic, open cave maps instead of corridor mazes.
+type CaveGenerator struct {
+	config CaveConfig
+}
+
+// NewCaveGenerator creates a cave generator using config.
+func NewCaveGenerator(config CaveConfig) Generator {
+	return &CaveGenerator{config: config}
+}
+
+// Generate fills a grid of the same (height*2+1) x (width*2+1) size as the
+// corridor generators with random walls, smooths it with the configured birth
+// and survival limits and then makes the floor a single connected region, so
+// any two open cells can serve as start and goal. The outer border is always
+// wall. If seed is nil, the generator uses the current time. Events cover the
+// initial noise, the cells each smoothing pass flips and the final clean-up.
+func (g *CaveGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+	if width < 2 || height < 2 {
+		return GenerateResult{}, ErrInvalidDimensions
+	}
+	if err := g.config.Validate(); err != nil {
+		return GenerateResult{}, err
+	}
+
+	rng := newRNG(seed)
+	cv := newCanvas(width, height, opts)
+	grid := cv.grid
+	for y := 1; y < len(grid)-1; y++ {
+		for x := 1; x < len(grid[y])-1; x++ {
+			if rng.Float64() >= g.config.FillProbability {
+				cv.carve(x, y)
+			}
+		}
+	}
+
+	for pass := 0; pass < g.config.SmoothingPasses; pass++ {
+		if err := ctx.Err(); err != nil {
+			return GenerateResult{}, err
+		}
+		g.smooth(cv)
+	}
+
+	regions := floorRegions(grid)
+	if len(regions) == 0 {
+		// Everything turned to rock; keep a single open cell in the middle.
+		cv.carve(len(grid[0])/2, len(grid)/2)
+	} else if g.config.Connectivity == CaveConnectivityLargest {
+		keepLargestRegion(cv, regions)
+	} else if err := joinRegions(ctx, cv, regions); err != nil {
+		return GenerateResult{}, err
+	}
+
+	return newResult(AlgorithmCave, grid, seed), nil
+}
+
+// smooth applies one pass of the automaton rules. Every cell is decided from the
+// grid as it was before the pass; the changes are then applied in row order.
+func (g *CaveGenerator) smooth(cv *canvas) {
+	grid := cv.grid
+	next := newWallGrid((len(grid[0])-1)/2, (len(grid)-1)/2)
+	for y := 1; y < len(grid)-1; y++ {
+		for x := 1; x < len(grid[y])-1; x++ {
+			walls := wallNeighbors(grid, x, y)
+			if grid[y][x] == 1 {
+				if walls < g.config.SurvivalLimit {
+					next[y][x] = 0
+				}
+			} else if walls < g.config.BirthLimit {
+				next[y][x] = 0
+			}
+		}
+	}
+	for y := 1; y < len(grid)-1; y++ {
+		for x := 1; x < len(grid[y])-1; x++ {
+			cv.set(Point{X: x, Y: y}, next[y][x])
+		}
+	}
+}
+
+// wallNeighbors counts the walls among the eight cells around (x, y).
+func wallNeighbors(grid Grid, x, y int) int {
+	count := 0
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+			nx, ny := x+dx, y+dy
+			if ny < 0 || ny >= len(grid) || nx < 0 || nx >= len(grid[ny]) || grid[ny][nx] == 1 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+var orthogonal = []Point{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}}
+
+// floorRegions groups the open cells into 4-connected regions, scanning in row
+// order so the result is deterministic.
+func floorRegions(grid Grid) [][]Point {
+	seen := make(map[Point]bool)
+	var regions [][]Point
+	for y := range grid {
+		for x := range grid[y] {
+			p := Point{X: x, Y: y}
+			if grid[y][x] != 0 || seen[p] {
+				continue
+			}
+
+			seen[p] = true
+			region := []Point{p}
+			for i := 0; i < len(region); i++ {
+				for _, d := range orthogonal {
+					n := Point{X: region[i].X + d.X, Y: region[i].Y + d.Y}
+					if grid[n.Y][n.X] == 0 && !seen[n] {
+						seen[n] = true
+						region = append(region, n)
+					}
+				}
+			}
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
+
+// largestRegion returns the index of the biggest region, preferring the first on ties.
+func largestRegion(regions [][]Point) int {
+	largest := 0
+	for i, region := range regions {
+		if len(region) > len(regions[largest]) {
+			largest = i
+		}
+	}
+	return largest
+}
+
+func keepLargestRegion(cv *canvas, regions [][]Point) {
+	largest := largestRegion(regions)
+	for i, region := range regions {
+		if i == largest {
+			continue
+		}
+		for _, p := range region {
+			cv.set(p, 1)
+		}
+	}
+}
+
+// joinRegions grows the connected cave from the largest region. Each round a
+// breadth-first search through rock finds the nearest open cell outside it and
+// digs the tunnel leading there, which pulls in that cell's whole region.
+func joinRegions(ctx context.Context, cv *canvas, regions [][]Point) error {
+	grid := cv.grid
+	connected := make(map[Point]bool)
+	for _, p := range regions[largestRegion(regions)] {
+		connected[p] = true
+	}
+
+	floor := 0
+	for _, region := range regions {
+		floor += len(region)
+	}
+
+	for len(connected) < floor {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		parents := make(map[Point]Point)
+		queue := make([]Point, 0, len(connected))
+		for y := range grid {
+			for x := range grid[y] {
+				if p := (Point{X: x, Y: y}); connected[p] {
+					parents[p] = p
+					queue = append(queue, p)
+				}
+			}
+		}
+
+		var target Point
+		found := false
+		for i := 0; i < len(queue) && !found; i++ {
+			for _, d := range orthogonal {
+				n := Point{X: queue[i].X + d.X, Y: queue[i].Y + d.Y}
+				if n.X <= 0 || n.Y <= 0 || n.Y >= len(grid)-1 || n.X >= len(grid[n.Y])-1 {
+					continue
+				}
+				if _, ok := parents[n]; ok {
+					continue
+				}
+				parents[n] = queue[i]
+				if grid[n.Y][n.X] == 0 {
+					target, found = n, true
+					break
+				}
+				queue = append(queue, n)
+			}
+		}
+
+		for p := parents[target]; !connected[p]; p = parents[p] {
+			cv.set(p, 0)
+			connected[p] = true
+			floor++
+		}
+
+		region := []Point{target}
+		connected[target] = true
+		for i := 0; i < len(region); i++ {
+			for _, d := range orthogonal {
+				n := Point{X: region[i].X + d.X, Y: region[i].Y + d.Y}
+				if grid[n.Y][n.X] == 0 && !connected[n] {
+					connected[n] = true
+					region = append(region, n)
+				}
+			}
+		}
+	}
+	return nil
+}
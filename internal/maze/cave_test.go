@@ -0,0 +1,97 @@
+package maze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaveGenerator_SingleConnectedRegion(t *testing.T) {
+	ctx := context.Background()
+
+	for _, connectivity := range []CaveConnectivity{CaveConnectivityJoin, CaveConnectivityLargest} {
+		t.Run(string(connectivity), func(t *testing.T) {
+			config := DefaultCaveConfig()
+			config.Connectivity = connectivity
+			gen := NewCaveGenerator(config)
+
+			for seed := int64(0); seed < 20; seed++ {
+				result, err := gen.Generate(ctx, 30, 20, &seed)
+				require.NoError(t, err)
+				assert.Equal(t, AlgorithmCave, result.Algorithm)
+				assert.Equal(t, 61, result.Width)
+				assert.Equal(t, 41, result.Height)
+				require.Len(t, floorRegions(result.Grid), 1, "seed %d", seed)
+
+				for x := range result.Grid[0] {
+					assert.Equal(t, 1, result.Grid[0][x])
+					assert.Equal(t, 1, result.Grid[len(result.Grid)-1][x])
+				}
+				for y := range result.Grid {
+					assert.Equal(t, 1, result.Grid[y][0])
+					assert.Equal(t, 1, result.Grid[y][len(result.Grid[y])-1])
+				}
+			}
+		})
+	}
+}
+
+func TestCaveGenerator_HasOpenAreas(t *testing.T) {
+	seed := int64(3)
+	result, err := NewCaveGenerator(DefaultCaveConfig()).Generate(context.Background(), 30, 30, &seed)
+	require.NoError(t, err)
+
+	openBlocks := 0
+	grid := result.Grid
+	for y := 0; y < len(grid)-1; y++ {
+		for x := 0; x < len(grid[y])-1; x++ {
+			if grid[y][x] == 0 && grid[y+1][x] == 0 && grid[y][x+1] == 0 && grid[y+1][x+1] == 0 {
+				openBlocks++
+			}
+		}
+	}
+	assert.Greater(t, openBlocks, 100, "caves should contain open 2x2 areas, unlike corridor mazes")
+}
+
+func TestCaveGenerator_JoinKeepsMoreFloorThanLargest(t *testing.T) {
+	ctx := context.Background()
+	seed := int64(11)
+
+	join := DefaultCaveConfig()
+	largest := DefaultCaveConfig()
+	largest.Connectivity = CaveConnectivityLargest
+	// Sparse fill and no smoothing leave plenty of isolated pockets.
+	join.FillProbability, largest.FillProbability = 0.6, 0.6
+	join.SmoothingPasses, largest.SmoothingPasses = 0, 0
+
+	joined, err := NewCaveGenerator(join).Generate(ctx, 20, 20, &seed)
+	require.NoError(t, err)
+	kept, err := NewCaveGenerator(largest).Generate(ctx, 20, 20, &seed)
+	require.NoError(t, err)
+
+	assert.Greater(t, len(floorRegions(joined.Grid)[0]), len(floorRegions(kept.Grid)[0]))
+}
+
+func TestCaveGenerator_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*CaveConfig)
+	}{
+		{"fill above one", func(c *CaveConfig) { c.FillProbability = 1.2 }},
+		{"negative passes", func(c *CaveConfig) { c.SmoothingPasses = -1 }},
+		{"birth limit too high", func(c *CaveConfig) { c.BirthLimit = 9 }},
+		{"negative survival limit", func(c *CaveConfig) { c.SurvivalLimit = -1 }},
+		{"unknown connectivity", func(c *CaveConfig) { c.Connectivity = "flood" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultCaveConfig()
+			tt.mutate(&config)
+			_, err := NewCaveGenerator(config).Generate(context.Background(), 5, 5, nil)
+			assert.ErrorIs(t, err, ErrInvalidCaveConfig)
+		})
+	}
+}
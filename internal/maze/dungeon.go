@@ -0,0 +1,253 @@
+package maze
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// ErrInvalidDungeonConfig indicates dungeon settings outside their valid ranges.
+var ErrInvalidDungeonConfig = errors.New("dungeon settings must have room sizes of at least 3 with min <= max, attempts of at least 0 and extra connections between 0 and 1")
+
+// Rect is an axis-aligned rectangle of grid squares.
+type Rect struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Contains reports whether p lies inside r.
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.X && p.X < r.X+r.Width && p.Y >= r.Y && p.Y < r.Y+r.Height
+}
+
+// Center returns the square in the middle of r.
+func (r Rect) Center() Point {
+	return Point{X: r.X + r.Width/2, Y: r.Y + r.Height/2}
+}
+
+// Room describes one room of a dungeon: the open squares in Bounds and the
+// squares of its surrounding wall that corridors pass through.
+type Room struct {
+	Bounds Rect    `json:"bounds"`
+	Doors  []Point `json:"doors"`
+}
+
+// DungeonConfig tunes DungeonGenerator. Room sizes are measured in grid squares.
+type DungeonConfig struct {
+	// MinRoomSize and MaxRoomSize bound the side length of a room. Sides are
+	// always odd so rooms line up with the corridors.
+	MinRoomSize int
+	MaxRoomSize int
+	// PlacementAttempts is how many random rooms are tried; rooms that overlap
+	// an earlier one are dropped. Zero derives a count from the map size.
+	PlacementAttempts int
+	// ExtraConnections is the chance that each of the shortest non-tree room
+	// pairs also gets a corridor, which adds loops to the dungeon.
+	ExtraConnections float64
+}
+
+// DefaultDungeonConfig returns rooms of 3 to 9 squares with a few extra loops.
+func DefaultDungeonConfig() DungeonConfig {
+	return DungeonConfig{
+		MinRoomSize:      3,
+		MaxRoomSize:      9,
+		ExtraConnections: 0.15,
+	}
+}
+
+// Validate reports whether every setting is within range.
+func (c DungeonConfig) Validate() error {
+	switch {
+	case c.MinRoomSize < 3 || c.MaxRoomSize < c.MinRoomSize:
+		return ErrInvalidDungeonConfig
+	case c.PlacementAttempts < 0:
+		return ErrInvalidDungeonConfig
+	case c.ExtraConnections < 0 || c.ExtraConnections > 1 || math.IsNaN(c.ExtraConnections):
+		return ErrInvalidDungeonConfig
+	}
+	return nil
+}
+
+// DungeonGenerator implements Generator with rectangular rooms joined by
+// corridors, in the style of classic roguelike dungeons.
+type DungeonGenerator struct {
+	config DungeonConfig
+}
+
+// NewDungeonGenerator creates a rooms-and-corridors generator using config.
+func NewDungeonGenerator(config DungeonConfig) Generator {
+	return &DungeonGenerator{config: config}
+}
+
+// roomLink is a candidate corridor between two rooms, weighted by the distance
+// between their anchors.
+type roomLink struct {
+	a, b     int
+	distance int
+}
+
+// Generate places non-overlapping rooms at random on a grid of the same
+// (height*2+1) x (width*2+1) size as the corridor generators, joins them along
+// a minimum spanning tree of L-shaped corridors and optionally adds extra
+// corridors between nearby rooms. Rooms and corridors sit on odd coordinates,
+// so corridors only ever cross room walls head-on. GenerateResult.Rooms lists
+// every room with its bounding box and doors. If seed is nil, the generator
+// uses the current time. Events carve whole rooms first, then each corridor.
+func (g *DungeonGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+	if width < 2 || height < 2 {
+		return GenerateResult{}, ErrInvalidDimensions
+	}
+	if err := g.config.Validate(); err != nil {
+		return GenerateResult{}, err
+	}
+
+	rng := newRNG(seed)
+	cv := newCanvas(width, height, opts)
+	grid := cv.grid
+
+	rooms := g.placeRooms(grid, rng)
+	for _, room := range rooms {
+		for y := room.Y; y < room.Y+room.Height; y++ {
+			for x := room.X; x < room.X+room.Width; x++ {
+				cv.carve(x, y)
+			}
+		}
+	}
+
+	// Each room gets an anchor on odd coordinates that its corridors start from.
+	anchors := make([]Point, len(rooms))
+	for i, room := range rooms {
+		anchors[i] = Point{
+			X: room.X + 2*rng.Intn((room.Width+1)/2),
+			Y: room.Y + 2*rng.Intn((room.Height+1)/2),
+		}
+	}
+
+	var links []roomLink
+	for a := range rooms {
+		for b := a + 1; b < len(rooms); b++ {
+			d := abs(anchors[a].X-anchors[b].X) + abs(anchors[a].Y-anchors[b].Y)
+			links = append(links, roomLink{a: a, b: b, distance: d})
+		}
+	}
+	slices.SortStableFunc(links, func(l, r roomLink) int {
+		return cmp.Compare(l.distance, r.distance)
+	})
+
+	sets := newDisjointSet(len(rooms))
+	extraBudget := len(rooms)
+	for _, link := range links {
+		if err := ctx.Err(); err != nil {
+			return GenerateResult{}, err
+		}
+		if !sets.union(link.a, link.b) {
+			if extraBudget == 0 {
+				continue
+			}
+			extraBudget--
+			if rng.Float64() >= g.config.ExtraConnections {
+				continue
+			}
+		}
+		carveCorridor(cv, anchors[link.a], anchors[link.b], rng.Intn(2) == 0)
+	}
+
+	result := newResult(AlgorithmDungeon, grid, seed)
+	result.Rooms = make([]Room, len(rooms))
+	for i, bounds := range rooms {
+		result.Rooms[i] = Room{Bounds: bounds, Doors: roomDoors(grid, bounds)}
+	}
+	return result, nil
+}
+
+// placeRooms tries random odd-sized, odd-aligned rooms and keeps those that do
+// not overlap or touch an earlier room.
+func (g *DungeonGenerator) placeRooms(grid Grid, rng *rand.Rand) []Rect {
+	gridWidth, gridHeight := len(grid[0]), len(grid)
+	attempts := g.config.PlacementAttempts
+	if attempts == 0 {
+		attempts = max(8, gridWidth*gridHeight/20)
+	}
+
+	var rooms []Rect
+	for range attempts {
+		w := g.roomSide(rng, gridWidth-2)
+		h := g.roomSide(rng, gridHeight-2)
+		// Odd positions from 1 up to the last one that keeps the room inside the border.
+		candidate := Rect{
+			X:      1 + 2*rng.Intn((gridWidth-w)/2),
+			Y:      1 + 2*rng.Intn((gridHeight-h)/2),
+			Width:  w,
+			Height: h,
+		}
+
+		overlaps := slices.ContainsFunc(rooms, func(r Rect) bool {
+			return candidate.X <= r.X+r.Width && r.X <= candidate.X+candidate.Width &&
+				candidate.Y <= r.Y+r.Height && r.Y <= candidate.Y+candidate.Height
+		})
+		if !overlaps {
+			rooms = append(rooms, candidate)
+		}
+	}
+	return rooms
+}
+
+// roomSide draws an odd side length within the configured bounds and limit.
+func (g *DungeonGenerator) roomSide(rng *rand.Rand, limit int) int {
+	lo := g.config.MinRoomSize | 1
+	hi := min(g.config.MaxRoomSize, limit)
+	if hi%2 == 0 {
+		hi--
+	}
+	if hi <= lo {
+		return hi
+	}
+	return lo + 2*rng.Intn((hi-lo)/2+1)
+}
+
+// carveCorridor digs an L-shaped corridor from a to b, going horizontally first
+// when horizontalFirst is set.
+func carveCorridor(cv *canvas, a, b Point, horizontalFirst bool) {
+	corner := Point{X: b.X, Y: a.Y}
+	if !horizontalFirst {
+		corner = Point{X: a.X, Y: b.Y}
+	}
+	carveLine(cv, a, corner)
+	carveLine(cv, corner, b)
+}
+
+// carveLine opens every square on the straight line from a to b.
+func carveLine(cv *canvas, a, b Point) {
+	dx, dy := cmp.Compare(b.X, a.X), cmp.Compare(b.Y, a.Y)
+	for p := a; ; p = (Point{X: p.X + dx, Y: p.Y + dy}) {
+		cv.set(p, 0)
+		if p == b {
+			return
+		}
+	}
+}
+
+// roomDoors lists the open squares of the wall ring around bounds, skipping
+// its corners, in row order.
+func roomDoors(grid Grid, bounds Rect) []Point {
+	doors := []Point{}
+	for y := bounds.Y - 1; y <= bounds.Y+bounds.Height; y++ {
+		for x := bounds.X - 1; x <= bounds.X+bounds.Width; x++ {
+			p := Point{X: x, Y: y}
+			onRing := !bounds.Contains(p)
+			corner := (x == bounds.X-1 || x == bounds.X+bounds.Width) && (y == bounds.Y-1 || y == bounds.Y+bounds.Height)
+			if onRing && !corner && grid[y][x] == 0 {
+				doors = append(doors, p)
+			}
+		}
+	}
+	return doors
+}
@@ -0,0 +1,108 @@
+package maze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDungeonGenerator_RoomsAndCorridors(t *testing.T) {
+	ctx := context.Background()
+	gen := NewDungeonGenerator(DefaultDungeonConfig())
+
+	for seed := int64(0); seed < 20; seed++ {
+		result, err := gen.Generate(ctx, 25, 20, &seed)
+		require.NoError(t, err)
+		assert.Equal(t, AlgorithmDungeon, result.Algorithm)
+		require.Len(t, floorRegions(result.Grid), 1, "seed %d: every room must be reachable", seed)
+		require.GreaterOrEqual(t, len(result.Rooms), 2, "seed %d", seed)
+
+		for i, room := range result.Rooms {
+			b := room.Bounds
+			assert.Equal(t, 1, b.X%2, "rooms sit on odd coordinates")
+			assert.Equal(t, 1, b.Width%2, "room sides are odd")
+			assert.GreaterOrEqual(t, b.Width, 3)
+			assert.LessOrEqual(t, b.Height, 9)
+			for y := b.Y; y < b.Y+b.Height; y++ {
+				for x := b.X; x < b.X+b.Width; x++ {
+					assert.Equal(t, 0, result.Grid[y][x])
+				}
+			}
+			for j, other := range result.Rooms {
+				if i != j {
+					assert.False(t, b.Contains(other.Bounds.Center()), "rooms %d and %d overlap", i, j)
+				}
+			}
+
+			assert.NotEmpty(t, room.Doors, "seed %d: room %d has no door", seed, i)
+			for _, door := range room.Doors {
+				assert.Equal(t, 0, result.Grid[door.Y][door.X])
+				assert.False(t, b.Contains(door))
+				onVerticalWall := door.X == b.X-1 || door.X == b.X+b.Width
+				onHorizontalWall := door.Y == b.Y-1 || door.Y == b.Y+b.Height
+				assert.True(t, onVerticalWall != onHorizontalWall, "door %v is not on the wall of %v", door, b)
+			}
+		}
+	}
+}
+
+func TestDungeonGenerator_Deterministic(t *testing.T) {
+	ctx := context.Background()
+	seed := int64(21)
+	gen := NewDungeonGenerator(DefaultDungeonConfig())
+
+	first, err := gen.Generate(ctx, 30, 30, &seed)
+	require.NoError(t, err)
+	second, err := gen.Generate(ctx, 30, 30, &seed)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Grid, second.Grid)
+	assert.Equal(t, first.Rooms, second.Rooms)
+}
+
+func TestDungeonGenerator_ExtraConnectionsAddLoops(t *testing.T) {
+	ctx := context.Background()
+	seed := int64(4)
+
+	tree := DefaultDungeonConfig()
+	tree.ExtraConnections = 0
+	loops := DefaultDungeonConfig()
+	loops.ExtraConnections = 1
+
+	sparse, err := NewDungeonGenerator(tree).Generate(ctx, 40, 40, &seed)
+	require.NoError(t, err)
+	dense, err := NewDungeonGenerator(loops).Generate(ctx, 40, 40, &seed)
+	require.NoError(t, err)
+
+	doors := func(rooms []Room) int {
+		n := 0
+		for _, room := range rooms {
+			n += len(room.Doors)
+		}
+		return n
+	}
+	assert.Equal(t, len(sparse.Rooms), len(dense.Rooms))
+	assert.Greater(t, doors(dense.Rooms), doors(sparse.Rooms))
+}
+
+func TestDungeonGenerator_TinyGrid(t *testing.T) {
+	seed := int64(1)
+	result, err := NewDungeonGenerator(DefaultDungeonConfig()).Generate(context.Background(), 2, 2, &seed)
+	require.NoError(t, err)
+	require.Len(t, result.Rooms, 1)
+	assert.Equal(t, Rect{X: 1, Y: 1, Width: 3, Height: 3}, result.Rooms[0].Bounds)
+}
+
+func TestDungeonGenerator_InvalidConfig(t *testing.T) {
+	for _, config := range []DungeonConfig{
+		{MinRoomSize: 2, MaxRoomSize: 5},
+		{MinRoomSize: 7, MaxRoomSize: 5},
+		{MinRoomSize: 3, MaxRoomSize: 5, PlacementAttempts: -1},
+		{MinRoomSize: 3, MaxRoomSize: 5, ExtraConnections: 2},
+	} {
+		_, err := NewDungeonGenerator(config).Generate(context.Background(), 5, 5, nil)
+		assert.ErrorIs(t, err, ErrInvalidDungeonConfig, "%+v", config)
+	}
+}
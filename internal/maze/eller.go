@@ -0,0 +1,211 @@
+package maze
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"iter"
+	"math/rand"
+)
+
+// EllerGenerator implements Generator with Eller's algorithm.
+type EllerGenerator struct{}
+
+// NewEllerGenerator creates an Eller's algorithm maze generator.
+func NewEllerGenerator() Generator {
+	return &EllerGenerator{}
+}
+
+// Generate builds the whole grid from EllerRows. Use EllerRows or WriteEller
+// directly for mazes too tall to hold in memory.
+func (g *EllerGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+	if width < 2 || height < 2 {
+		return GenerateResult{}, ErrInvalidDimensions
+	}
+
+	cv := newCanvas(width, height, opts)
+	y := 0
+	for row, err := range EllerRows(ctx, width, height, seed) {
+		if err != nil {
+			return GenerateResult{}, err
+		}
+		for x, v := range row {
+			if v == 0 {
+				cv.carve(x, y)
+			}
+		}
+		y++
+	}
+
+	return newResult(AlgorithmEller, cv.grid, seed), nil
+}
+
+// EllerRows generates a perfect maze with Eller's algorithm and yields its grid
+// one row at a time, top to bottom: the 2*height+1 rows of the usual
+// (height*2+1) x (width*2+1) layout. Only the set membership of the current
+// cell row is kept, so memory stays O(width) however tall the maze is. Every
+// row is a fresh slice the caller may keep. The sequence stops after yielding
+// an error, which is ErrInvalidDimensions for sizes below 2x2 or the context's
+// error once it is cancelled. If seed is nil, the generator uses the current time.
+func EllerRows(ctx context.Context, width, height int, seed *int64) iter.Seq2[[]int, error] {
+	return func(yield func([]int, error) bool) {
+		if width < 2 || height < 2 {
+			yield(nil, ErrInvalidDimensions)
+			return
+		}
+
+		e := newEller(width, newRNG(seed))
+		if !yield(wallRow(width), nil) {
+			return
+		}
+		for y := 0; y < height; y++ {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			cells, south := e.row(y == height-1)
+			if !yield(cells, nil) || !yield(south, nil) {
+				return
+			}
+		}
+	}
+}
+
+// WriteRows writes rows to w as text, one line of '0' (passage) and '1' (wall)
+// characters per grid row. It stops at the first error of rows or w.
+func WriteRows(w io.Writer, rows iter.Seq2[[]int, error]) error {
+	bw := bufio.NewWriter(w)
+	var line []byte
+	for row, err := range rows {
+		if err != nil {
+			return err
+		}
+		line = line[:0]
+		for _, v := range row {
+			line = append(line, byte('0'+v))
+		}
+		line = append(line, '\n')
+		if _, err := bw.Write(line); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteEller streams an Eller maze to w in the WriteRows format.
+func WriteEller(ctx context.Context, w io.Writer, width, height int, seed *int64) error {
+	return WriteRows(w, EllerRows(ctx, width, height, seed))
+}
+
+// eller holds the state carried from one cell row to the next: the set label
+// of every column, or -1 for a cell that no passage from above reaches.
+// Labels are column-sized, so a union-find over them is rebuilt cheaply per row.
+type eller struct {
+	width  int
+	rng    *rand.Rand
+	labels []int
+	sets   *disjointSet
+	used   []bool
+	// Per-label scratch space for choosing the passages down.
+	down []bool
+	seen []int
+	pick []int
+}
+
+func newEller(width int, rng *rand.Rand) *eller {
+	e := &eller{
+		width:  width,
+		rng:    rng,
+		labels: make([]int, width),
+		sets:   newDisjointSet(width),
+		used:   make([]bool, width),
+		down:   make([]bool, width),
+		seen:   make([]int, width),
+		pick:   make([]int, width),
+	}
+	for x := range e.labels {
+		e.labels[x] = -1
+	}
+	return e
+}
+
+// row produces the next cell row and the wall row below it. Adjacent cells in
+// different sets are joined at random, or always on the last row; then every
+// set sends at least one passage down so no part of the maze is cut off.
+func (e *eller) row(last bool) (cells, south []int) {
+	// Give cells without a set a label no other column uses.
+	clear(e.used)
+	for _, l := range e.labels {
+		if l >= 0 {
+			e.used[l] = true
+		}
+	}
+	free := 0
+	for x, l := range e.labels {
+		if l < 0 {
+			for e.used[free] {
+				free++
+			}
+			e.labels[x] = free
+			e.used[free] = true
+		}
+	}
+	for i := range e.sets.parent {
+		e.sets.parent[i] = i
+		e.sets.size[i] = 1
+	}
+
+	cells = wallRow(e.width)
+	for x := 0; x < e.width; x++ {
+		cells[2*x+1] = 0
+		if x+1 < e.width && (last || e.rng.Intn(2) == 0) && e.sets.union(e.labels[x], e.labels[x+1]) {
+			cells[2*x+2] = 0
+		}
+	}
+	for x, l := range e.labels {
+		e.labels[x] = e.sets.find(l)
+	}
+
+	south = wallRow(e.width)
+	if last {
+		return cells, south
+	}
+
+	// Every set that sent no passage down opens one below a member picked by
+	// reservoir sampling, which keeps the pick uniform in a single pass.
+	clear(e.down)
+	clear(e.seen)
+	for x, l := range e.labels {
+		if e.rng.Intn(2) == 0 {
+			south[2*x+1] = 0
+			e.down[l] = true
+		}
+		e.seen[l]++
+		if e.rng.Intn(e.seen[l]) == 0 {
+			e.pick[l] = x
+		}
+	}
+	for x, l := range e.labels {
+		if !e.down[l] && e.pick[l] == x {
+			south[2*x+1] = 0
+		}
+	}
+	for x := range e.labels {
+		if south[2*x+1] != 0 {
+			e.labels[x] = -1
+		}
+	}
+	return cells, south
+}
+
+// wallRow returns a grid row for width cells that is wall throughout.
+func wallRow(width int) []int {
+	row := make([]int, 2*width+1)
+	for x := range row {
+		row[x] = 1
+	}
+	return row
+}
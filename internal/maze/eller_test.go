@@ -0,0 +1,100 @@
+package maze
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEllerRows_MatchGenerate(t *testing.T) {
+	ctx := context.Background()
+	seed := int64(17)
+
+	var grid Grid
+	for row, err := range EllerRows(ctx, 8, 6, &seed) {
+		require.NoError(t, err)
+		grid = append(grid, row)
+	}
+	assertPerfectMaze(t, grid, 8, 6)
+
+	result, err := NewEllerGenerator().Generate(ctx, 8, 6, &seed)
+	require.NoError(t, err)
+	assert.Equal(t, AlgorithmEller, result.Algorithm)
+	assert.Equal(t, grid, result.Grid)
+}
+
+func TestEllerRows_InvalidInput(t *testing.T) {
+	for _, err := range EllerRows(context.Background(), 1, 5, nil) {
+		assert.ErrorIs(t, err, ErrInvalidDimensions)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rows := 0
+	var last error
+	for _, err := range EllerRows(ctx, 5, 100, nil) {
+		if err != nil {
+			last = err
+			break
+		}
+		rows++
+		if rows == 10 {
+			cancel()
+		}
+	}
+	assert.ErrorIs(t, last, context.Canceled)
+	assert.Less(t, rows, 201)
+}
+
+func TestWriteEller_Format(t *testing.T) {
+	seed := int64(4)
+	var buf bytes.Buffer
+	require.NoError(t, WriteEller(context.Background(), &buf, 3, 2, &seed))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 5)
+	var grid Grid
+	for _, line := range lines {
+		require.Len(t, line, 7)
+		row := make([]int, len(line))
+		for x, ch := range line {
+			row[x] = int(ch - '0')
+		}
+		grid = append(grid, row)
+	}
+	assertPerfectMaze(t, grid, 3, 2)
+}
+
+func TestWriteEller_TallMaze(t *testing.T) {
+	if testing.Short() {
+		t.Skip("streams a tall maze")
+	}
+	seed := int64(1)
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(WriteEller(context.Background(), w, 100, 50000, &seed))
+	}()
+
+	// Every set sends a passage down, so no wall row between two cell rows may be solid.
+	scanner := bufio.NewScanner(r)
+	lines := 0
+	var last string
+	for scanner.Scan() {
+		line := scanner.Text()
+		require.Len(t, line, 201)
+		if lines > 0 && lines%2 == 0 && lines < 100000 {
+			require.Contains(t, line, "0", "row %d is sealed off", lines)
+		}
+		last = line
+		lines++
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, 100001, lines)
+	assert.Equal(t, strings.Repeat("1", 201), last)
+}
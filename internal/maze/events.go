@@ -0,0 +1,100 @@
+package maze
+
+// EventKind identifies what a generation step did to a grid square.
+type EventKind string
+
+const (
+	// EventCarve reports a square turning from wall into passage.
+	EventCarve EventKind = "carve"
+	// EventWall reports a square turning from passage into wall.
+	EventWall EventKind = "wall"
+	// EventStairs reports stairs joining Point to the same square on the floor
+	// above.
+	EventStairs EventKind = "stairs"
+	// EventPortal reports Point becoming one end of the portal with id Portal.
+	EventPortal EventKind = "portal"
+	// EventDoor reports Point becoming a door opened by the key of colour Color.
+	EventDoor EventKind = "door"
+	// EventKey reports Point receiving the key of colour Color.
+	EventKey EventKind = "key"
+)
+
+// Event is emitted by a Generator for every square it changes. Replaying the
+// events in order on a grid of the same size that starts out as solid wall
+// reproduces the finished maze, which lets clients animate generation the way
+// VisitedOrder animates solving. On multi-level mazes Point.Z names the floor.
+// Portal is only set on EventPortal, and Color on EventDoor and EventKey.
+type Event struct {
+	Kind   EventKind `json:"kind"`
+	Point  Point     `json:"point"`
+	Portal int       `json:"portal,omitempty"`
+	Color  int       `json:"color,omitempty"`
+}
+
+// Option customises a single Generate call.
+type Option func(*options)
+
+type options struct {
+	emit func(Event)
+	wrap bool
+}
+
+// WithEvents streams every generation event to emit, in order, while the maze
+// is being built.
+func WithEvents(emit func(Event)) Option {
+	return func(o *options) {
+		o.emit = emit
+	}
+}
+
+// canvas is a grid under construction that reports every change it undergoes.
+// floor is stamped on the events of one floor of a multi-level maze, and wrap
+// marks a torus, whose cells neighbour each other across the grid edges.
+type canvas struct {
+	grid  Grid
+	floor int
+	wrap  bool
+	emit  func(Event)
+}
+
+// newCanvas returns a canvas over a fresh wall grid for width x height cells.
+func newCanvas(width, height int, opts []Option) *canvas {
+	return wrapCanvas(newWallGrid(width, height), opts)
+}
+
+// wrapCanvas returns a canvas over an existing grid.
+func wrapCanvas(grid Grid, opts []Option) *canvas {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &canvas{grid: grid, emit: o.emit}
+}
+
+// set changes the square at p to v (0 for passage, 1 for wall) and emits the
+// matching event. Setting a square to the value it already has is a no-op.
+func (cv *canvas) set(p Point, v int) {
+	if cv.grid[p.Y][p.X] == v {
+		return
+	}
+	cv.grid[p.Y][p.X] = v
+	if cv.emit == nil {
+		return
+	}
+	kind := EventCarve
+	if v == 1 {
+		kind = EventWall
+	}
+	p.Z = cv.floor
+	cv.emit(Event{Kind: kind, Point: p})
+}
+
+// carve opens the square at (x, y).
+func (cv *canvas) carve(x, y int) {
+	cv.set(Point{X: x, Y: y}, 0)
+}
+
+// wall closes the square at (x, y).
+func (cv *canvas) wall(x, y int) {
+	cv.set(Point{X: x, Y: y}, 1)
+}
@@ -0,0 +1,147 @@
+package maze
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidFloors indicates a multi-level maze whose floors differ in shape or
+// whose stairs do not join open squares on adjacent floors.
+var ErrInvalidFloors = errors.New("floors must all have the same shape and stairs must join open squares on adjacent floors")
+
+// MultiLevelGenerator implements Generator for mazes of several floors stacked
+// on top of each other, joined by stairs, with the iterative backtracker.
+type MultiLevelGenerator struct {
+	floors int
+}
+
+// NewMultiLevelGenerator creates a generator for mazes with the given number of
+// floors, which must be at least 2.
+func NewMultiLevelGenerator(floors int) Generator {
+	return &MultiLevelGenerator{floors: floors}
+}
+
+// Generate constructs a perfect maze spanning every floor, each of which has
+// width x height cells on the usual (height*2+1) x (width*2+1) grid. The walk
+// prefers staying on its floor and only takes stairs from cells with no
+// unvisited neighbour left on theirs, so floors are joined by a handful of
+// stairs rather than riddled with them. Stairs occupy a cell on each of the two
+// floors they join and are listed in Stairs by their lower end.
+func (g *MultiLevelGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+	if width < 2 || height < 2 || g.floors < 2 {
+		return GenerateResult{}, ErrInvalidDimensions
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	rng := newRNG(seed)
+	canvases := make([]*canvas, g.floors)
+	visited := make([][][]bool, g.floors)
+	for z := range canvases {
+		canvases[z] = &canvas{grid: newWallGrid(width, height), floor: z, emit: o.emit}
+		visited[z] = newVisited(width, height)
+	}
+
+	// floorCell is a cell on floor z.
+	type floorCell struct {
+		cell
+		z int
+	}
+	var stairs []Point
+
+	stack := []floorCell{{}}
+	visited[0][0][0] = true
+	carveCell(canvases[0], 0, 0)
+
+	for len(stack) > 0 {
+		if err := ctx.Err(); err != nil {
+			return GenerateResult{}, err
+		}
+
+		current := stack[len(stack)-1]
+		var unvisited []floorCell
+		for _, n := range availableNeighbors(current.cell, visited[current.z], width, height) {
+			unvisited = append(unvisited, floorCell{cell: n, z: current.z})
+		}
+		if len(unvisited) == 0 {
+			for _, z := range []int{current.z - 1, current.z + 1} {
+				if z >= 0 && z < g.floors && !visited[z][current.y][current.x] {
+					unvisited = append(unvisited, floorCell{cell: current.cell, z: z})
+				}
+			}
+		}
+
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[rng.Intn(len(unvisited))]
+		if next.z == current.z {
+			carvePassage(canvases[next.z], current.cell, next.cell)
+		} else {
+			carveCell(canvases[next.z], next.x, next.y)
+			stair := Point{X: next.x*2 + 1, Y: next.y*2 + 1, Z: min(current.z, next.z)}
+			stairs = append(stairs, stair)
+			if o.emit != nil {
+				o.emit(Event{Kind: EventStairs, Point: stair})
+			}
+		}
+		visited[next.z][next.y][next.x] = true
+		stack = append(stack, next)
+	}
+
+	floors := make([]Grid, g.floors)
+	for z, cv := range canvases {
+		floors[z] = cv.grid
+	}
+	var seedCopy *int64
+	if seed != nil {
+		v := *seed
+		seedCopy = &v
+	}
+	return GenerateResult{
+		Width:     len(floors[0][0]),
+		Height:    len(floors[0]),
+		Grid:      floors[0],
+		Seed:      seedCopy,
+		Algorithm: AlgorithmBacktracker,
+		Topology:  TopologySquare,
+		Floors:    floors,
+		Stairs:    stairs,
+	}, nil
+}
+
+// ValidateFloors checks that floors all have the same shape and that every
+// stair joins an open square on its floor to the same open square on the floor
+// above.
+func ValidateFloors(floors []Grid, stairs []Point) error {
+	if len(floors) == 0 {
+		return ErrInvalidFloors
+	}
+	for _, floor := range floors[1:] {
+		if len(floor) != len(floors[0]) {
+			return ErrInvalidFloors
+		}
+		for y, row := range floor {
+			if len(row) != len(floors[0][y]) {
+				return ErrInvalidFloors
+			}
+		}
+	}
+	for _, s := range stairs {
+		if s.Z < 0 || s.Z+1 >= len(floors) || s.Y < 0 || s.Y >= len(floors[0]) || s.X < 0 || s.X >= len(floors[0][s.Y]) {
+			return ErrInvalidFloors
+		}
+		if floors[s.Z][s.Y][s.X] != 0 || floors[s.Z+1][s.Y][s.X] != 0 {
+			return ErrInvalidFloors
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package maze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiLevelGenerator_ProducesPerfectMaze(t *testing.T) {
+	ctx := context.Background()
+	width, height, floors := 6, 5, 3
+	seed := int64(7)
+
+	result, err := NewMultiLevelGenerator(floors).Generate(ctx, width, height, &seed)
+	require.NoError(t, err)
+	require.Len(t, result.Floors, floors)
+	assert.Equal(t, result.Floors[0], result.Grid)
+	require.NoError(t, ValidateFloors(result.Floors, result.Stairs))
+	assert.NotEmpty(t, result.Stairs)
+
+	passages := len(result.Stairs)
+	for z, grid := range result.Floors {
+		require.Len(t, grid, height*2+1)
+		for y, row := range grid {
+			for x, v := range row {
+				if x%2 == 1 && y%2 == 1 {
+					require.Equal(t, 0, v, "cell closed at (%d,%d,%d)", x, y, z)
+				} else if v == 0 {
+					passages++
+				}
+			}
+		}
+	}
+	assert.Equal(t, width*height*floors-1, passages, "a spanning tree has one passage fewer than cells")
+
+	up := make(map[Point]bool)
+	for _, s := range result.Stairs {
+		up[s] = true
+	}
+	seen := map[Point]bool{{X: 1, Y: 1}: true}
+	queue := []Point{{X: 1, Y: 1}}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		next := []Point{
+			{X: p.X, Y: p.Y - 1, Z: p.Z},
+			{X: p.X + 1, Y: p.Y, Z: p.Z},
+			{X: p.X, Y: p.Y + 1, Z: p.Z},
+			{X: p.X - 1, Y: p.Y, Z: p.Z},
+		}
+		if up[p] {
+			next = append(next, Point{X: p.X, Y: p.Y, Z: p.Z + 1})
+		}
+		if below := (Point{X: p.X, Y: p.Y, Z: p.Z - 1}); up[below] {
+			next = append(next, below)
+		}
+		for _, n := range next {
+			if result.Floors[n.Z][n.Y][n.X] != 0 || seen[n] {
+				continue
+			}
+			seen[n] = true
+			queue = append(queue, n)
+		}
+	}
+	assert.Len(t, seen, width*height*floors+passages-len(result.Stairs), "every open square must be reachable")
+
+	again, err := NewMultiLevelGenerator(floors).Generate(ctx, width, height, &seed)
+	require.NoError(t, err)
+	assert.Equal(t, result.Floors, again.Floors)
+	assert.Equal(t, result.Stairs, again.Stairs)
+}
+
+func TestMultiLevelGenerator_EventsReplayToFloors(t *testing.T) {
+	seed := int64(3)
+	var events []Event
+	result, err := NewMultiLevelGenerator(2).Generate(context.Background(), 4, 4, &seed, WithEvents(func(e Event) {
+		events = append(events, e)
+	}))
+	require.NoError(t, err)
+
+	floors := []Grid{newWallGrid(4, 4), newWallGrid(4, 4)}
+	var stairs []Point
+	for _, ev := range events {
+		switch ev.Kind {
+		case EventStairs:
+			stairs = append(stairs, ev.Point)
+		case EventCarve:
+			floors[ev.Point.Z][ev.Point.Y][ev.Point.X] = 0
+		default:
+			t.Fatalf("unexpected %s event", ev.Kind)
+		}
+	}
+	assert.Equal(t, result.Floors, floors)
+	assert.Equal(t, result.Stairs, stairs)
+}
+
+func TestMultiLevelGenerator_InvalidDimensions(t *testing.T) {
+	_, err := NewMultiLevelGenerator(1).Generate(context.Background(), 5, 5, nil)
+	assert.ErrorIs(t, err, ErrInvalidDimensions)
+}
+
+func TestValidateFloors(t *testing.T) {
+	open := Grid{{0, 0}, {0, 1}}
+	assert.NoError(t, ValidateFloors([]Grid{open, open}, []Point{{X: 0, Y: 1}}))
+	assert.ErrorIs(t, ValidateFloors(nil, nil), ErrInvalidFloors)
+	assert.ErrorIs(t, ValidateFloors([]Grid{open, {{0, 0}}}, nil), ErrInvalidFloors)
+	assert.ErrorIs(t, ValidateFloors([]Grid{open, open}, []Point{{X: 1, Y: 1}}), ErrInvalidFloors, "stairs onto a wall")
+	assert.ErrorIs(t, ValidateFloors([]Grid{open, open}, []Point{{X: 0, Y: 0, Z: 1}}), ErrInvalidFloors, "stairs above the top floor")
+}
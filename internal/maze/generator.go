@@ -10,11 +10,16 @@ import (
 var (
 	// ErrInvalidDimensions indicates the requested maze size is too small.
 	ErrInvalidDimensions = errors.New("maze dimensions must be at least 2x2")
+	// ErrUnknownAlgorithm indicates an unsupported generation algorithm.
+	ErrUnknownAlgorithm = errors.New("algorithm must be one of: backtracker, kruskal, prim, wilson, aldous-broder, recursive-division, eller, cave, dungeon")
+	// ErrInvalidBraid indicates a braid density outside [0, 1].
+	ErrInvalidBraid = errors.New("braid must be between 0 and 1")
 )
 
-// Generator defines the interface for maze generation services
+// Generator defines the interface for maze generation services. Options such
+// as WithEvents let callers observe the squares a generator changes.
 type Generator interface {
-	Generate(ctx context.Context, width, height int, seed *int64) (GenerateResult, error)
+	Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error)
 }
 
 // DefaultGenerator implements Generator using iterative backtracker
@@ -35,7 +40,8 @@ type cell struct {
 // Generate constructs a perfect maze using the Iterative Backtracker algorithm.
 // The resulting grid has dimensions (height*2+1) x (width*2+1) to encode walls
 // and passages explicitly. If seed is nil, the generator uses the current time.
-func (g *DefaultGenerator) Generate(ctx context.Context, width, height int, seed *int64) (GenerateResult, error) {
+// Events follow the depth-first walk, carving each wall and then the cell behind it.
+func (g *DefaultGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
 	// Check context cancellation
 	if err := ctx.Err(); err != nil {
 		return GenerateResult{}, err
@@ -44,31 +50,17 @@ func (g *DefaultGenerator) Generate(ctx context.Context, width, height int, seed
 		return GenerateResult{}, ErrInvalidDimensions
 	}
 
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	if seed != nil {
-		rng = rand.New(rand.NewSource(*seed))
-	}
-
-	gridWidth := width*2 + 1
-	gridHeight := height*2 + 1
-
-	grid := make(Grid, gridHeight)
-	for y := range grid {
-		row := make([]int, gridWidth)
-		for x := range row {
-			row[x] = 1
-		}
-		grid[y] = row
+	rng := newRNG(seed)
+	cv, err := newCellCanvas(width, height, opts)
+	if err != nil {
+		return GenerateResult{}, err
 	}
 
-	visited := make([][]bool, height)
-	for i := range visited {
-		visited[i] = make([]bool, width)
-	}
+	visited := newVisited(width, height)
 
 	stack := []cell{{x: 0, y: 0}}
 	visited[0][0] = true
-	carveCell(grid, 0, 0)
+	carveCell(cv, 0, 0)
 
 	for len(stack) > 0 {
 		// Check context cancellation periodically in long-running operations
@@ -77,7 +69,7 @@ func (g *DefaultGenerator) Generate(ctx context.Context, width, height int, seed
 		}
 
 		current := stack[len(stack)-1]
-		neighbors := availableNeighbors(current, visited, width, height)
+		neighbors := unvisitedNeighbors(cv, current, visited)
 
 		if len(neighbors) == 0 {
 			stack = stack[:len(stack)-1]
@@ -86,25 +78,91 @@ func (g *DefaultGenerator) Generate(ctx context.Context, width, height int, seed
 
 		nextCell := neighbors[rng.Intn(len(neighbors))]
 
-		carvePassage(grid, current, nextCell)
+		carvePassage(cv, current, nextCell)
 		visited[nextCell.y][nextCell.x] = true
 		stack = append(stack, nextCell)
 	}
 
+	return cv.result(AlgorithmBacktracker, seed), nil
+}
+
+// newRNG seeds a random source from seed, or from the current time when seed is nil.
+func newRNG(seed *int64) *rand.Rand {
+	if seed != nil {
+		return rand.New(rand.NewSource(*seed))
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// newWallGrid returns a grid for width x height cells with every wall standing
+// and no cell carved yet.
+func newWallGrid(width, height int) Grid {
+	grid := make(Grid, height*2+1)
+	for y := range grid {
+		row := make([]int, width*2+1)
+		for x := range row {
+			row[x] = 1
+		}
+		grid[y] = row
+	}
+	return grid
+}
+
+// newVisited returns a width x height table of unvisited cells.
+func newVisited(width, height int) [][]bool {
+	visited := make([][]bool, height)
+	for i := range visited {
+		visited[i] = make([]bool, width)
+	}
+	return visited
+}
+
+// newResult wraps a finished square grid, copying seed so callers cannot mutate it.
+func newResult(algorithm Algorithm, grid Grid, seed *int64) GenerateResult {
 	var seedCopy *int64
 	if seed != nil {
 		v := *seed
 		seedCopy = &v
 	}
 
-	result := GenerateResult{
-		Width:  gridWidth,
-		Height: gridHeight,
-		Grid:   grid,
-		Seed:   seedCopy,
+	return GenerateResult{
+		Width:     len(grid[0]),
+		Height:    len(grid),
+		Grid:      grid,
+		Seed:      seedCopy,
+		Algorithm: algorithm,
+		Topology:  TopologySquare,
 	}
+}
 
-	return result, nil
+// cellNeighbors lists the in-bounds cells next to c in the order north, east,
+// south, west.
+func cellNeighbors(c cell, width, height int) []cell {
+	candidates := make([]cell, 0, 4)
+	if c.y > 0 {
+		candidates = append(candidates, cell{x: c.x, y: c.y - 1})
+	}
+	if c.x+1 < width {
+		candidates = append(candidates, cell{x: c.x + 1, y: c.y})
+	}
+	if c.y+1 < height {
+		candidates = append(candidates, cell{x: c.x, y: c.y + 1})
+	}
+	if c.x > 0 {
+		candidates = append(candidates, cell{x: c.x - 1, y: c.y})
+	}
+	return candidates
+}
+
+// unvisitedNeighbors lists the neighbours of c on cv that are not yet visited.
+func unvisitedNeighbors(cv *canvas, c cell, visited [][]bool) []cell {
+	var unvisited []cell
+	for _, n := range cv.neighbors(c) {
+		if !visited[n.y][n.x] {
+			unvisited = append(unvisited, n)
+		}
+	}
+	return unvisited
 }
 
 func availableNeighbors(c cell, visited [][]bool, width, height int) []cell {
@@ -124,21 +182,13 @@ func availableNeighbors(c cell, visited [][]bool, width, height int) []cell {
 	return candidates
 }
 
-func carveCell(grid Grid, cellX, cellY int) {
-	gridY := cellY*2 + 1
-	gridX := cellX*2 + 1
-	grid[gridY][gridX] = 0
+func carveCell(cv *canvas, cellX, cellY int) {
+	cv.carve(cellX*2+1, cellY*2+1)
 }
 
-func carvePassage(grid Grid, from, to cell) {
-	fromGridX := from.x*2 + 1
-	fromGridY := from.y*2 + 1
-	toGridX := to.x*2 + 1
-	toGridY := to.y*2 + 1
-
-	wallX := (fromGridX + toGridX) / 2
-	wallY := (fromGridY + toGridY) / 2
-
-	grid[toGridY][toGridX] = 0
-	grid[wallY][wallX] = 0
+// carvePassage opens the wall between from and to, then the cell to itself.
+func carvePassage(cv *canvas, from, to cell) {
+	wall := cv.wallBetween(from, to)
+	cv.carve(wall.X, wall.Y)
+	cv.carve(to.x*2+1, to.y*2+1)
 }
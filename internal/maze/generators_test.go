@@ -0,0 +1,205 @@
+package maze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertPerfectMaze checks that every cell is carved, the passages form a
+// spanning tree and the outer wall is intact.
+func assertPerfectMaze(t *testing.T, grid Grid, width, height int) {
+	t.Helper()
+	require.Len(t, grid, height*2+1)
+
+	passages := 0
+	for y, row := range grid {
+		require.Len(t, row, width*2+1)
+		for x, v := range row {
+			switch {
+			case y == 0 || x == 0 || y == len(grid)-1 || x == len(row)-1:
+				require.Equal(t, 1, v, "outer wall open at (%d,%d)", x, y)
+			case x%2 == 1 && y%2 == 1:
+				require.Equal(t, 0, v, "cell closed at (%d,%d)", x, y)
+			case x%2 == 0 && y%2 == 0:
+				require.Equal(t, 1, v, "pillar open at (%d,%d)", x, y)
+			case v == 0:
+				passages++
+			}
+		}
+	}
+	assert.Equal(t, width*height-1, passages, "a spanning tree has one passage fewer than cells")
+
+	seen := map[Point]bool{{X: 1, Y: 1}: true}
+	queue := []Point{{X: 1, Y: 1}}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, d := range []Point{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}} {
+			n := Point{X: p.X + d.X, Y: p.Y + d.Y}
+			if grid[n.Y][n.X] == 0 && !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	assert.Len(t, seen, 2*width*height-1, "every open square must be reachable")
+}
+
+// perfectGenerators returns the built-in generators that carve spanning trees
+// on the cell/wall lattice.
+func perfectGenerators() map[Algorithm]Generator {
+	generators := Generators()
+	delete(generators, AlgorithmCave)
+	delete(generators, AlgorithmDungeon)
+	return generators
+}
+
+func TestGenerators_ProducePerfectMazes(t *testing.T) {
+	ctx := context.Background()
+	seed := int64(42)
+
+	for algorithm, gen := range perfectGenerators() {
+		t.Run(string(algorithm), func(t *testing.T) {
+			for _, size := range [][2]int{{2, 2}, {7, 4}, {15, 15}} {
+				result, err := gen.Generate(ctx, size[0], size[1], &seed)
+				require.NoError(t, err)
+				assert.Equal(t, algorithm, result.Algorithm)
+				assert.Equal(t, size[0]*2+1, result.Width)
+				assert.Equal(t, size[1]*2+1, result.Height)
+				assertPerfectMaze(t, result.Grid, size[0], size[1])
+			}
+		})
+	}
+}
+
+func TestGenerators_DeterministicPerSeed(t *testing.T) {
+	ctx := context.Background()
+	seed1, seed2 := int64(7), int64(8)
+
+	for algorithm, gen := range Generators() {
+		t.Run(string(algorithm), func(t *testing.T) {
+			first, err := gen.Generate(ctx, 12, 9, &seed1)
+			require.NoError(t, err)
+			second, err := gen.Generate(ctx, 12, 9, &seed1)
+			require.NoError(t, err)
+			other, err := gen.Generate(ctx, 12, 9, &seed2)
+			require.NoError(t, err)
+
+			assert.Equal(t, first.Grid, second.Grid)
+			assert.NotEqual(t, first.Grid, other.Grid)
+		})
+	}
+}
+
+func TestGenerators_RejectInvalidInput(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for algorithm, gen := range Generators() {
+		t.Run(string(algorithm), func(t *testing.T) {
+			_, err := gen.Generate(context.Background(), 1, 5, nil)
+			assert.ErrorIs(t, err, ErrInvalidDimensions)
+
+			_, err = gen.Generate(cancelled, 5, 5, nil)
+			assert.ErrorIs(t, err, context.Canceled)
+		})
+	}
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	a, err := ParseAlgorithm("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultAlgorithm, a)
+
+	a, err = ParseAlgorithm("Aldous-Broder")
+	require.NoError(t, err)
+	assert.Equal(t, AlgorithmAldousBroder, a)
+
+	a, err = ParseAlgorithm("recursive-division")
+	require.NoError(t, err)
+	assert.Equal(t, AlgorithmRecursiveDivision, a)
+
+	_, err = ParseAlgorithm("sidewinder")
+	assert.ErrorIs(t, err, ErrUnknownAlgorithm)
+}
+
+// replayEvents applies events to a solid wall grid of the given size.
+func replayEvents(t *testing.T, events []Event, width, height int) Grid {
+	t.Helper()
+	grid := newWallGrid(width, height)
+	for _, ev := range events {
+		want := 1
+		if ev.Kind == EventWall {
+			want = 0
+		}
+		require.Equal(t, want, grid[ev.Point.Y][ev.Point.X], "%s at %v does not change the square", ev.Kind, ev.Point)
+		grid[ev.Point.Y][ev.Point.X] = 1 - want
+	}
+	return grid
+}
+
+func TestGenerators_EventsReplayToGrid(t *testing.T) {
+	ctx := context.Background()
+	seed := int64(3)
+
+	generators := Generators()
+	generators["braided"] = NewBraidedGenerator(NewKruskalGenerator(), 0.5)
+	for name, gen := range generators {
+		t.Run(string(name), func(t *testing.T) {
+			var events []Event
+			result, err := gen.Generate(ctx, 9, 6, &seed, WithEvents(func(ev Event) {
+				events = append(events, ev)
+			}))
+			require.NoError(t, err)
+			require.NotEmpty(t, events)
+			assert.Equal(t, result.Grid, replayEvents(t, events, 9, 6))
+
+			plain, err := gen.Generate(ctx, 9, 6, &seed)
+			require.NoError(t, err)
+			assert.Equal(t, plain.Grid, result.Grid, "observing events must not change the maze")
+		})
+	}
+}
+
+func TestBacktracker_EventsFollowTheWalk(t *testing.T) {
+	seed := int64(5)
+	var events []Event
+	_, err := NewGenerator().Generate(context.Background(), 4, 4, &seed, WithEvents(func(ev Event) {
+		events = append(events, ev)
+	}))
+	require.NoError(t, err)
+
+	// 16 cells plus the 15 walls of the spanning tree, all carved.
+	require.Len(t, events, 31)
+	assert.Equal(t, Event{Kind: EventCarve, Point: Point{X: 1, Y: 1}}, events[0])
+	for i, ev := range events {
+		assert.Equal(t, EventCarve, ev.Kind)
+		if i > 0 && i%2 == 0 {
+			// Every cell is entered through the wall carved just before it.
+			wall := events[i-1].Point
+			assert.Equal(t, 1, abs(wall.X-ev.Point.X)+abs(wall.Y-ev.Point.Y))
+		}
+	}
+}
+
+func TestRecursiveDivision_AddsWallsAfterClearing(t *testing.T) {
+	seed := int64(9)
+	var events []Event
+	result, err := NewRecursiveDivisionGenerator().Generate(context.Background(), 6, 5, &seed, WithEvents(func(ev Event) {
+		events = append(events, ev)
+	}))
+	require.NoError(t, err)
+	assertPerfectMaze(t, result.Grid, 6, 5)
+
+	interior := 11 * 9
+	require.Greater(t, len(events), interior)
+	for _, ev := range events[:interior] {
+		assert.Equal(t, EventCarve, ev.Kind)
+	}
+	for _, ev := range events[interior:] {
+		assert.Equal(t, EventWall, ev.Kind)
+	}
+}
@@ -0,0 +1,76 @@
+package maze
+
+import "context"
+
+// HexGenerator implements Generator for hex mazes with the iterative
+// backtracker.
+type HexGenerator struct{}
+
+// NewHexGenerator creates a hex maze generator.
+func NewHexGenerator() Generator {
+	return &HexGenerator{}
+}
+
+// Generate constructs a perfect maze of width x height hex cells arranged as a
+// rhombus in axial coordinates. The grid uses the same (height*2+1) x
+// (width*2+1) layout as the square generators, read with TopologyHex: cell
+// (q, r) sits at grid[2r+1][2q+1] and the tile between two neighbouring cells
+// is the wall that separates them, so every grid tile that is not a cell is a
+// wall between exactly one pair of cells or part of the outer border.
+func (g *HexGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+	if width < 2 || height < 2 {
+		return GenerateResult{}, ErrInvalidDimensions
+	}
+
+	rng := newRNG(seed)
+	cv := newCanvas(width, height, opts)
+	visited := newVisited(width, height)
+
+	stack := []cell{{x: 0, y: 0}}
+	visited[0][0] = true
+	carveCell(cv, 0, 0)
+
+	for len(stack) > 0 {
+		if err := ctx.Err(); err != nil {
+			return GenerateResult{}, err
+		}
+
+		current := stack[len(stack)-1]
+		neighbors := hexNeighbors(current, width, height)
+		unvisited := neighbors[:0]
+		for _, n := range neighbors {
+			if !visited[n.y][n.x] {
+				unvisited = append(unvisited, n)
+			}
+		}
+
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[rng.Intn(len(unvisited))]
+		carvePassage(cv, current, next)
+		visited[next.y][next.x] = true
+		stack = append(stack, next)
+	}
+
+	result := newResult(AlgorithmBacktracker, cv.grid, seed)
+	result.Topology = TopologyHex
+	return result, nil
+}
+
+// hexNeighbors lists the in-bounds cells next to c in HexDirections order.
+func hexNeighbors(c cell, width, height int) []cell {
+	neighbors := make([]cell, 0, len(HexDirections))
+	for _, dir := range HexDirections {
+		n := cell{x: c.x + dir.X, y: c.y + dir.Y}
+		if n.x >= 0 && n.x < width && n.y >= 0 && n.y < height {
+			neighbors = append(neighbors, n)
+		}
+	}
+	return neighbors
+}
@@ -0,0 +1,108 @@
+package maze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHexGenerator_ProducesPerfectMaze(t *testing.T) {
+	ctx := context.Background()
+	width, height := 9, 6
+	seed := int64(5)
+
+	result, err := NewHexGenerator().Generate(ctx, width, height, &seed)
+	require.NoError(t, err)
+	assert.Equal(t, TopologyHex, result.Topology)
+	assert.Equal(t, AlgorithmBacktracker, result.Algorithm)
+
+	grid := result.Grid
+	require.Len(t, grid, height*2+1)
+	passages := 0
+	for y, row := range grid {
+		require.Len(t, row, width*2+1)
+		for x, v := range row {
+			switch {
+			case y == 0 || x == 0 || y == len(grid)-1 || x == len(row)-1:
+				require.Equal(t, 1, v, "outer wall open at (%d,%d)", x, y)
+			case x%2 == 1 && y%2 == 1:
+				require.Equal(t, 0, v, "cell closed at (%d,%d)", x, y)
+			case v == 0:
+				passages++
+			}
+		}
+	}
+	assert.Equal(t, width*height-1, passages, "a spanning tree has one passage fewer than cells")
+
+	seen := map[Point]bool{{X: 1, Y: 1}: true}
+	queue := []Point{{X: 1, Y: 1}}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, d := range HexDirections {
+			n := Point{X: p.X + d.X, Y: p.Y + d.Y}
+			if grid[n.Y][n.X] == 0 && !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	assert.Len(t, seen, 2*width*height-1, "every open tile must be reachable through hex neighbours")
+
+	again, err := NewHexGenerator().Generate(ctx, width, height, &seed)
+	require.NoError(t, err)
+	assert.Equal(t, grid, again.Grid)
+}
+
+func TestHexGenerator_UsesDiagonalPassages(t *testing.T) {
+	seed := int64(11)
+	result, err := NewHexGenerator().Generate(context.Background(), 12, 12, &seed)
+	require.NoError(t, err)
+
+	// Walls at (even, even) separate cells along the (1,-1) axis, which only
+	// exists on hex grids.
+	diagonal := 0
+	for y := 2; y < len(result.Grid)-1; y += 2 {
+		for x := 2; x < len(result.Grid[y])-1; x += 2 {
+			if result.Grid[y][x] == 0 {
+				diagonal++
+			}
+		}
+	}
+	assert.Positive(t, diagonal)
+}
+
+func TestHexGenerator_EventsReplayToGrid(t *testing.T) {
+	seed := int64(3)
+	var events []Event
+	result, err := NewHexGenerator().Generate(context.Background(), 5, 4, &seed, WithEvents(func(e Event) {
+		events = append(events, e)
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, result.Grid, replayEvents(t, events, 5, 4))
+}
+
+func TestParseTopology(t *testing.T) {
+	topology, err := ParseTopology("")
+	require.NoError(t, err)
+	assert.Equal(t, TopologySquare, topology)
+
+	topology, err = ParseTopology("HEX")
+	require.NoError(t, err)
+	assert.Equal(t, TopologyHex, topology)
+
+	_, err = ParseTopology("triangle")
+	assert.ErrorIs(t, err, ErrUnknownTopology)
+}
+
+func TestHexDistance(t *testing.T) {
+	origin := Point{}
+	for _, d := range HexDirections {
+		assert.Equal(t, 1, HexDistance(origin, d))
+	}
+	assert.Equal(t, 3, HexDistance(origin, Point{X: 3, Y: -3}))
+	assert.Equal(t, 6, HexDistance(origin, Point{X: 3, Y: 3}))
+	assert.Equal(t, 4, HexDistance(Point{X: -1, Y: 2}, Point{X: 2, Y: -2}))
+}
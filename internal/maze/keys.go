@@ -0,0 +1,173 @@
+package maze
+
+import "context"
+
+// MaxKeys is the number of key colours a maze may use. Colours run from 1 to
+// MaxKeys. Keys and doors are stored in a Grid as negative values, so they
+// never clash with walls or portal ids: the key of colour c is Key(c), -c, and
+// its door is Door(c), -100-c. Key squares are as walkable as passages and
+// hand their key to whoever steps on them; a door can only be passed once its
+// key has been picked up, and is a wall to anyone without it.
+const MaxKeys = 16
+
+const doorOffset = 100
+
+// Key returns the grid value of the key of colour c.
+func Key(c int) int {
+	return -c
+}
+
+// Door returns the grid value of the door that the key of colour c opens.
+func Door(c int) int {
+	return -doorOffset - c
+}
+
+// KeyColor returns the colour of the key a square holding v carries, if any.
+func KeyColor(v int) (int, bool) {
+	c := -v
+	return c, c >= 1 && c <= MaxKeys
+}
+
+// DoorColor returns the colour of the key that opens a door square holding v,
+// if v is a door.
+func DoorColor(v int) (int, bool) {
+	c := -v - doorOffset
+	return c, c >= 1 && c <= MaxKeys
+}
+
+// HasKeys reports whether any of floors holds a key or a door.
+func HasKeys(floors ...Grid) bool {
+	for _, grid := range floors {
+		for _, row := range grid {
+			for _, v := range row {
+				if _, ok := KeyColor(v); ok {
+					return true
+				}
+				if _, ok := DoorColor(v); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// KeyGenerator wraps another Generator and locks parts of its mazes behind
+// coloured doors.
+type KeyGenerator struct {
+	base   Generator
+	colors int
+}
+
+// NewKeyGenerator creates a generator that places up to colors doors, each
+// with its key, in the mazes of base. colors must not exceed MaxKeys.
+func NewKeyGenerator(base Generator, colors int) Generator {
+	return &KeyGenerator{base: base, colors: colors}
+}
+
+// Generate builds a maze with the wrapped generator, then turns passage squares
+// drawn from a random source seeded from seed into doors and drops their keys.
+// The key of colour c lies where a walker setting out from the first open
+// square, in reading order, can reach it holding the keys of the colours below
+// c, so every key, and everything the base maze connects to that square, can be
+// collected from there. Reachability is worked out on square steps alone,
+// which is safe on square and torus mazes. When no square is left for a key
+// its door is dropped too, so small mazes may get fewer colours. Each door and
+// key is reported as an EventDoor or EventKey.
+func (g *KeyGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	result, err := g.base.Generate(ctx, width, height, seed, opts...)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+
+	var open []Point
+	for y, row := range result.Grid {
+		for x, v := range row {
+			if v == 0 {
+				open = append(open, Point{X: x, Y: y})
+			}
+		}
+	}
+	if len(open) < 2 {
+		return result, nil
+	}
+
+	grid := result.Grid
+	entrance := open[0]
+	rng := newRNG(seed)
+	candidates := open[1:]
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	colors := min(g.colors, MaxKeys, len(candidates))
+	doors := candidates[:colors]
+	for c, p := range doors {
+		grid[p.Y][p.X] = Door(c + 1)
+	}
+
+	keys := make([]Point, 0, colors)
+	for c := 1; c <= colors; c++ {
+		reachable := reachableSquares(grid, entrance, c)
+		rng.Shuffle(len(reachable), func(i, j int) {
+			reachable[i], reachable[j] = reachable[j], reachable[i]
+		})
+		placed := false
+		for _, p := range reachable {
+			if grid[p.Y][p.X] == 0 {
+				grid[p.Y][p.X] = Key(c)
+				keys = append(keys, p)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			for _, p := range doors[c-1:] {
+				grid[p.Y][p.X] = 0
+			}
+			doors = doors[:c-1]
+			break
+		}
+	}
+
+	cv := wrapCanvas(grid, opts)
+	if cv.emit != nil {
+		for i := range keys {
+			cv.emit(Event{Kind: EventDoor, Point: doors[i], Color: i + 1})
+			cv.emit(Event{Kind: EventKey, Point: keys[i], Color: i + 1})
+		}
+	}
+	return result, nil
+}
+
+// reachableSquares lists the squares of grid a walker starting at from reaches
+// by square steps while holding the keys of every colour below color.
+func reachableSquares(grid Grid, from Point, color int) []Point {
+	passable := func(p Point) bool {
+		if p.Y < 0 || p.Y >= len(grid) || p.X < 0 || p.X >= len(grid[p.Y]) {
+			return false
+		}
+		v := grid[p.Y][p.X]
+		if c, ok := DoorColor(v); ok {
+			return c < color
+		}
+		return IsOpen(v)
+	}
+
+	seen := map[Point]bool{from: true}
+	queue := []Point{from}
+	for i := 0; i < len(queue); i++ {
+		p := queue[i]
+		for _, d := range orthogonal {
+			next := Point{X: p.X + d.X, Y: p.Y + d.Y}
+			if !seen[next] && passable(next) {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return queue
+}
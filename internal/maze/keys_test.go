@@ -0,0 +1,103 @@
+package maze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeys_Encoding(t *testing.T) {
+	for c := 1; c <= MaxKeys; c++ {
+		color, ok := KeyColor(Key(c))
+		assert.True(t, ok)
+		assert.Equal(t, c, color)
+		color, ok = DoorColor(Door(c))
+		assert.True(t, ok)
+		assert.Equal(t, c, color)
+
+		_, ok = DoorColor(Key(c))
+		assert.False(t, ok)
+		_, ok = KeyColor(Door(c))
+		assert.False(t, ok)
+		assert.True(t, IsOpen(Key(c)), "keys can be walked on")
+		assert.False(t, IsOpen(Door(c)), "doors need their key")
+	}
+
+	for _, v := range []int{0, 1, FirstPortal, Key(MaxKeys + 1), Door(MaxKeys + 1)} {
+		_, ok := KeyColor(v)
+		assert.False(t, ok, "%d is no key", v)
+		_, ok = DoorColor(v)
+		assert.False(t, ok, "%d is no door", v)
+	}
+
+	assert.False(t, HasKeys(Grid{{0, 1, 2, 2}}))
+	assert.True(t, HasKeys(Grid{{0, 1}}, Grid{{Door(3), 0}}))
+}
+
+func TestKeyGenerator_PlacesKeys(t *testing.T) {
+	seed := int64(9)
+	var events []Event
+	result, err := NewKeyGenerator(NewGenerator(), 4).Generate(context.Background(), 8, 6, &seed, WithEvents(func(e Event) {
+		events = append(events, e)
+	}))
+	require.NoError(t, err)
+
+	doors, keys := 0, 0
+	grid := newWallGrid(8, 6)
+	for _, ev := range events {
+		switch ev.Kind {
+		case EventCarve:
+			grid[ev.Point.Y][ev.Point.X] = 0
+		case EventDoor:
+			doors++
+			grid[ev.Point.Y][ev.Point.X] = Door(ev.Color)
+		case EventKey:
+			keys++
+			grid[ev.Point.Y][ev.Point.X] = Key(ev.Color)
+		}
+	}
+	assert.Equal(t, result.Grid, grid, "events must replay to the finished maze")
+	assert.Equal(t, 4, doors)
+	assert.Equal(t, 4, keys)
+
+	// Holding every key opens every door, and each key must be reachable with
+	// the keys below it.
+	entrance := Point{X: 1, Y: 1}
+	for c := 1; c <= 4; c++ {
+		found := false
+		for _, p := range reachableSquares(result.Grid, entrance, c) {
+			if color, ok := KeyColor(result.Grid[p.Y][p.X]); ok && color == c {
+				found = true
+			}
+		}
+		assert.True(t, found, "key %d is out of reach", c)
+	}
+	// A perfect 8x6 maze has 48 cells and 47 passages between them.
+	assert.Len(t, reachableSquares(result.Grid, entrance, MaxKeys+1), 95)
+
+	again, err := NewKeyGenerator(NewGenerator(), 4).Generate(context.Background(), 8, 6, &seed)
+	require.NoError(t, err)
+	assert.Equal(t, result.Grid, again.Grid, "the same seed must place the same keys")
+}
+
+func TestKeyGenerator_RunsOutOfSquares(t *testing.T) {
+	seed := int64(3)
+	result, err := NewKeyGenerator(NewGenerator(), MaxKeys).Generate(context.Background(), 2, 2, &seed)
+	require.NoError(t, err)
+
+	doors, keys := 0, 0
+	for _, row := range result.Grid {
+		for _, v := range row {
+			if _, ok := DoorColor(v); ok {
+				doors++
+			}
+			if _, ok := KeyColor(v); ok {
+				keys++
+			}
+		}
+	}
+	assert.Equal(t, doors, keys, "every door must come with its key")
+	assert.Less(t, doors, MaxKeys)
+}
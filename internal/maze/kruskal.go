@@ -0,0 +1,100 @@
+package maze
+
+import "context"
+
+// KruskalGenerator implements Generator with randomized Kruskal's algorithm.
+type KruskalGenerator struct{}
+
+// NewKruskalGenerator creates a randomized Kruskal maze generator.
+func NewKruskalGenerator() Generator {
+	return &KruskalGenerator{}
+}
+
+type wall struct {
+	a cell
+	b cell
+}
+
+// Generate visits every interior wall in random order and knocks it down when
+// the cells on either side are not yet connected, tracked with a union-find.
+// The result is a perfect maze with many short dead ends. On a torus the walls
+// across the seams are interior walls too.
+func (g *KruskalGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+	if width < 2 || height < 2 {
+		return GenerateResult{}, ErrInvalidDimensions
+	}
+
+	rng := newRNG(seed)
+	cv, err := newCellCanvas(width, height, opts)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	walls := make([]wall, 0, 2*width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			carveCell(cv, x, y)
+			if x+1 < width || cv.wrap {
+				walls = append(walls, wall{a: cell{x: x, y: y}, b: cell{x: (x + 1) % width, y: y}})
+			}
+			if y+1 < height || cv.wrap {
+				walls = append(walls, wall{a: cell{x: x, y: y}, b: cell{x: x, y: (y + 1) % height}})
+			}
+		}
+	}
+	rng.Shuffle(len(walls), func(i, j int) {
+		walls[i], walls[j] = walls[j], walls[i]
+	})
+
+	sets := newDisjointSet(width * height)
+	for _, w := range walls {
+		if err := ctx.Err(); err != nil {
+			return GenerateResult{}, err
+		}
+		if sets.union(w.a.y*width+w.a.x, w.b.y*width+w.b.x) {
+			carvePassage(cv, w.a, w.b)
+		}
+	}
+
+	return cv.result(AlgorithmKruskal, seed), nil
+}
+
+// disjointSet is a union-find over cell indices with path halving and union by size.
+type disjointSet struct {
+	parent []int
+	size   []int
+}
+
+func newDisjointSet(n int) *disjointSet {
+	d := &disjointSet{parent: make([]int, n), size: make([]int, n)}
+	for i := range d.parent {
+		d.parent[i] = i
+		d.size[i] = 1
+	}
+	return d
+}
+
+func (d *disjointSet) find(i int) int {
+	for d.parent[i] != i {
+		d.parent[i] = d.parent[d.parent[i]]
+		i = d.parent[i]
+	}
+	return i
+}
+
+// union merges the sets holding a and b and reports whether they were apart.
+func (d *disjointSet) union(a, b int) bool {
+	ra, rb := d.find(a), d.find(b)
+	if ra == rb {
+		return false
+	}
+	if d.size[ra] < d.size[rb] {
+		ra, rb = rb, ra
+	}
+	d.parent[rb] = ra
+	d.size[ra] += d.size[rb]
+	return true
+}
@@ -0,0 +1,220 @@
+package maze
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// ErrInvalidPolarGrid indicates a grid that does not have the polar layout
+// described on TopologyPolar.
+var ErrInvalidPolarGrid = errors.New("polar grid must be a centre tile followed by rings whose cell counts divide the next ring's")
+
+// PolarGenerator implements Generator for circular mazes with the iterative
+// backtracker.
+type PolarGenerator struct{}
+
+// NewPolarGenerator creates a polar maze generator.
+func NewPolarGenerator() Generator {
+	return &PolarGenerator{}
+}
+
+// Generate constructs a perfect circular maze: a centre cell surrounded by
+// height rings, the innermost of which has width cells. Outer rings split
+// their cells in two (or more) whenever the cells would otherwise grow wider
+// than they are deep, so cells keep roughly the same size throughout. The
+// grid uses the TopologyPolar layout, and the centre cell at (0, 0) is the
+// natural goal.
+func (g *PolarGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+	if width < 2 || height < 2 {
+		return GenerateResult{}, ErrInvalidDimensions
+	}
+
+	rng := newRNG(seed)
+	sizes := polarRingSizes(width, height)
+	cv := wrapCanvas(newPolarWallGrid(sizes), opts)
+
+	// cell.y is the ring, 0 being the centre, and cell.x the index in the ring.
+	visited := make([][]bool, len(sizes))
+	for r, n := range sizes {
+		visited[r] = make([]bool, n)
+	}
+
+	visited[0][0] = true
+	cv.carve(0, 0)
+	stack := []cell{{x: 0, y: 0}}
+
+	for len(stack) > 0 {
+		if err := ctx.Err(); err != nil {
+			return GenerateResult{}, err
+		}
+
+		current := stack[len(stack)-1]
+		var unvisited []cell
+		for _, n := range polarCellNeighbors(current, sizes) {
+			if !visited[n.y][n.x] {
+				unvisited = append(unvisited, n)
+			}
+		}
+
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[rng.Intn(len(unvisited))]
+		wall := polarWallBetween(current, next, sizes)
+		cv.carve(wall.X, wall.Y)
+		tile := polarCellTile(next)
+		cv.carve(tile.X, tile.Y)
+		visited[next.y][next.x] = true
+		stack = append(stack, next)
+	}
+
+	grid := cv.grid
+	var seedCopy *int64
+	if seed != nil {
+		v := *seed
+		seedCopy = &v
+	}
+	return GenerateResult{
+		Width:     len(grid[len(grid)-1]),
+		Height:    len(grid),
+		Grid:      grid,
+		Seed:      seedCopy,
+		Algorithm: AlgorithmBacktracker,
+		Topology:  TopologyPolar,
+	}, nil
+}
+
+// polarRingSizes returns the cell count of the centre and of each of the rings
+// around it. Ring r is one unit deep with inner radius r, so its cells are
+// 2πr/n units wide; a ring multiplies the count of the ring inside it by
+// however many cells of unit width fit in one of those.
+func polarRingSizes(inner, rings int) []int {
+	sizes := make([]int, rings+1)
+	sizes[0] = 1
+	sizes[1] = inner
+	for r := 2; r <= rings; r++ {
+		ratio := int(math.Round(2 * math.Pi * float64(r) / float64(sizes[r-1])))
+		sizes[r] = sizes[r-1] * max(1, ratio)
+	}
+	return sizes
+}
+
+// newPolarWallGrid returns a polar grid for the given ring sizes with every
+// tile walled.
+func newPolarWallGrid(sizes []int) Grid {
+	grid := make(Grid, 2*len(sizes)-1)
+	grid[0] = []int{1}
+	for r := 1; r < len(sizes); r++ {
+		grid[2*r-1] = make([]int, sizes[r])
+		grid[2*r] = make([]int, 2*sizes[r])
+	}
+	for _, row := range grid {
+		for x := range row {
+			row[x] = 1
+		}
+	}
+	return grid
+}
+
+// polarCellTile is the grid tile of a cell.
+func polarCellTile(c cell) Point {
+	return Point{X: 2 * c.x, Y: 2 * c.y}
+}
+
+// polarCellNeighbors lists the cells next to c: its two neighbours along the
+// ring, the cell inwards and the cells outwards.
+func polarCellNeighbors(c cell, sizes []int) []cell {
+	var neighbors []cell
+	if c.y > 0 {
+		n := sizes[c.y]
+		neighbors = append(neighbors,
+			cell{x: (c.x + 1) % n, y: c.y},
+			cell{x: (c.x + n - 1) % n, y: c.y},
+			cell{x: c.x / (n / sizes[c.y-1]), y: c.y - 1},
+		)
+	}
+	if c.y+1 < len(sizes) {
+		ratio := sizes[c.y+1] / sizes[c.y]
+		for i := c.x * ratio; i < (c.x+1)*ratio; i++ {
+			neighbors = append(neighbors, cell{x: i, y: c.y + 1})
+		}
+	}
+	return neighbors
+}
+
+// polarWallBetween is the tile separating two neighbouring cells.
+func polarWallBetween(a, b cell, sizes []int) Point {
+	switch {
+	case a.y < b.y:
+		return Point{X: b.x, Y: 2*b.y - 1}
+	case a.y > b.y:
+		return Point{X: a.x, Y: 2*a.y - 1}
+	case (a.x+1)%sizes[a.y] == b.x:
+		return Point{X: 2*a.x + 1, Y: 2 * a.y}
+	default:
+		return Point{X: 2*b.x + 1, Y: 2 * b.y}
+	}
+}
+
+// ValidatePolarGrid checks that grid has the TopologyPolar layout.
+func ValidatePolarGrid(grid Grid) error {
+	if len(grid) < 3 || len(grid)%2 == 0 || len(grid[0]) != 1 {
+		return ErrInvalidPolarGrid
+	}
+	inner := 1
+	for y := 1; y < len(grid); y += 2 {
+		n := len(grid[y])
+		if n < 2 || n%inner != 0 || len(grid[y+1]) != 2*n {
+			return ErrInvalidPolarGrid
+		}
+		inner = n
+	}
+	return nil
+}
+
+// PolarNeighbors returns the tiles next to p on a grid with the TopologyPolar
+// layout, which must have been checked with ValidatePolarGrid.
+func PolarNeighbors(grid Grid, p Point) []Point {
+	switch {
+	case p.Y == 0:
+		neighbors := make([]Point, len(grid[1]))
+		for x := range neighbors {
+			neighbors[x] = Point{X: x, Y: 1}
+		}
+		return neighbors
+
+	case p.Y%2 == 1:
+		// A wall between the cell outwards and the cell it sits on.
+		outward := Point{X: 2 * p.X, Y: p.Y + 1}
+		if p.Y == 1 {
+			return []Point{{X: 0, Y: 0}, outward}
+		}
+		ratio := len(grid[p.Y]) / (len(grid[p.Y-1]) / 2)
+		return []Point{{X: 2 * (p.X / ratio), Y: p.Y - 1}, outward}
+	}
+
+	width := len(grid[p.Y])
+	if p.X%2 == 1 {
+		// A wall between two cells of the ring.
+		return []Point{{X: p.X - 1, Y: p.Y}, {X: (p.X + 1) % width, Y: p.Y}}
+	}
+
+	neighbors := []Point{
+		{X: p.X + 1, Y: p.Y},
+		{X: (p.X + width - 1) % width, Y: p.Y},
+		{X: p.X / 2, Y: p.Y - 1},
+	}
+	if p.Y+1 < len(grid) {
+		ratio := len(grid[p.Y+1]) / (width / 2)
+		for x := p.X / 2 * ratio; x < (p.X/2+1)*ratio; x++ {
+			neighbors = append(neighbors, Point{X: x, Y: p.Y + 1})
+		}
+	}
+	return neighbors
+}
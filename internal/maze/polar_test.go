@@ -0,0 +1,124 @@
+package maze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolarGenerator_ProducesPerfectMaze(t *testing.T) {
+	ctx := context.Background()
+	seed := int64(5)
+
+	result, err := NewPolarGenerator().Generate(ctx, 6, 8, &seed)
+	require.NoError(t, err)
+	assert.Equal(t, TopologyPolar, result.Topology)
+	assert.Equal(t, AlgorithmBacktracker, result.Algorithm)
+
+	grid := result.Grid
+	require.NoError(t, ValidatePolarGrid(grid))
+	require.Len(t, grid, 2*8+1)
+	assert.Len(t, grid[1], 6, "the innermost ring has width cells")
+	assert.Equal(t, len(grid[len(grid)-1]), result.Width)
+	assert.Equal(t, len(grid), result.Height)
+	assert.Greater(t, len(grid[len(grid)-1]), 2*len(grid[1]), "outer rings must be split into more cells")
+
+	cells, passages := 0, 0
+	for y, row := range grid {
+		for x, v := range row {
+			if y%2 == 0 && x%2 == 0 {
+				cells++
+				require.Equal(t, 0, v, "cell closed at (%d,%d)", x, y)
+			} else if v == 0 {
+				passages++
+			}
+		}
+	}
+	assert.Equal(t, cells-1, passages, "a spanning tree has one passage fewer than cells")
+
+	seen := map[Point]bool{{X: 0, Y: 0}: true}
+	queue := []Point{{X: 0, Y: 0}}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, n := range PolarNeighbors(grid, p) {
+			if grid[n.Y][n.X] == 0 && !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	assert.Len(t, seen, cells+passages, "every open tile must be reachable from the centre")
+
+	again, err := NewPolarGenerator().Generate(ctx, 6, 8, &seed)
+	require.NoError(t, err)
+	assert.Equal(t, grid, again.Grid)
+}
+
+func TestPolarGenerator_EventsReplayToGrid(t *testing.T) {
+	seed := int64(3)
+	var events []Event
+	result, err := NewPolarGenerator().Generate(context.Background(), 4, 5, &seed, WithEvents(func(e Event) {
+		events = append(events, e)
+	}))
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+
+	grid := make(Grid, len(result.Grid))
+	for y, row := range result.Grid {
+		grid[y] = make([]int, len(row))
+		for x := range grid[y] {
+			grid[y][x] = 1
+		}
+	}
+	for _, ev := range events {
+		require.Equal(t, EventCarve, ev.Kind)
+		require.Equal(t, 1, grid[ev.Point.Y][ev.Point.X], "tile %v carved twice", ev.Point)
+		grid[ev.Point.Y][ev.Point.X] = 0
+	}
+	assert.Equal(t, result.Grid, grid)
+}
+
+func TestPolarGenerator_InvalidDimensions(t *testing.T) {
+	_, err := NewPolarGenerator().Generate(context.Background(), 1, 5, nil)
+	assert.ErrorIs(t, err, ErrInvalidDimensions)
+}
+
+func TestPolarNeighbors(t *testing.T) {
+	// A centre, a ring of 2 cells and a ring of 4.
+	grid := newPolarWallGrid([]int{1, 2, 4})
+	require.NoError(t, ValidatePolarGrid(grid))
+
+	assert.ElementsMatch(t, []Point{{X: 0, Y: 1}, {X: 1, Y: 1}}, PolarNeighbors(grid, Point{X: 0, Y: 0}))
+	// Inner walls of the first ring lead to the centre.
+	assert.ElementsMatch(t, []Point{{X: 0, Y: 0}, {X: 2, Y: 2}}, PolarNeighbors(grid, Point{X: 1, Y: 1}))
+	// Cell 1 of the first ring: its ring walls, the wall inwards and the walls
+	// of its two outer cells.
+	assert.ElementsMatch(t, []Point{{X: 3, Y: 2}, {X: 1, Y: 2}, {X: 1, Y: 1}, {X: 2, Y: 3}, {X: 3, Y: 3}},
+		PolarNeighbors(grid, Point{X: 2, Y: 2}))
+	// The wall between the last and first cells of a ring wraps around.
+	assert.ElementsMatch(t, []Point{{X: 6, Y: 4}, {X: 0, Y: 4}}, PolarNeighbors(grid, Point{X: 7, Y: 4}))
+	// Outer cells sit on the wall of the cell they split from.
+	assert.ElementsMatch(t, []Point{{X: 2, Y: 2}, {X: 6, Y: 4}}, PolarNeighbors(grid, Point{X: 3, Y: 3}))
+	// The outermost ring has nothing beyond it.
+	assert.ElementsMatch(t, []Point{{X: 1, Y: 4}, {X: 7, Y: 4}, {X: 0, Y: 3}}, PolarNeighbors(grid, Point{X: 0, Y: 4}))
+
+	for y, row := range grid {
+		for x := range row {
+			p := Point{X: x, Y: y}
+			for _, n := range PolarNeighbors(grid, p) {
+				assert.Contains(t, PolarNeighbors(grid, n), p, "%v touches %v but not the other way round", p, n)
+			}
+		}
+	}
+}
+
+func TestValidatePolarGrid(t *testing.T) {
+	assert.NoError(t, ValidatePolarGrid(newPolarWallGrid([]int{1, 3, 6, 6})))
+	assert.ErrorIs(t, ValidatePolarGrid(newWallGrid(3, 3)), ErrInvalidPolarGrid)
+	assert.ErrorIs(t, ValidatePolarGrid(newPolarWallGrid([]int{1, 3, 4})), ErrInvalidPolarGrid)
+	assert.ErrorIs(t, ValidatePolarGrid(newPolarWallGrid([]int{1, 1})), ErrInvalidPolarGrid)
+	assert.ErrorIs(t, ValidatePolarGrid(Grid{{0}}), ErrInvalidPolarGrid)
+}
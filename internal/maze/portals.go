@@ -0,0 +1,106 @@
+package maze
+
+import (
+	"context"
+	"errors"
+)
+
+// FirstPortal is the smallest value marking a portal square. Grids may hold
+// values from FirstPortal upwards besides 0 and 1: each such value is a portal
+// id and must mark exactly two squares, which are then the two ends of a
+// portal. Portal squares are as walkable as passages, and a walker standing on
+// one may teleport to its partner.
+const FirstPortal = 2
+
+// ErrInvalidPortals indicates a portal id that does not mark exactly two
+// squares.
+var ErrInvalidPortals = errors.New("every portal id must mark exactly two squares")
+
+// IsOpen reports whether a square holding v can be walked on: passages,
+// portals and keys can, walls cannot. Doors are open only to walkers holding
+// their key, which IsOpen does not know about, so it reports them closed.
+func IsOpen(v int) bool {
+	if _, ok := KeyColor(v); ok {
+		return true
+	}
+	return v == 0 || v >= FirstPortal
+}
+
+// Portals pairs up the portal squares of a maze, given as its floors from the
+// ground up; single-floor mazes pass their one grid. It maps either end of
+// every portal to the other, with Point.Z naming the floor, so a portal may
+// join two floors. A maze without portals yields an empty map.
+func Portals(floors ...Grid) (map[Point]Point, error) {
+	ends := make(map[int][]Point)
+	for z, grid := range floors {
+		for y, row := range grid {
+			for x, v := range row {
+				if v >= FirstPortal {
+					ends[v] = append(ends[v], Point{X: x, Y: y, Z: z})
+				}
+			}
+		}
+	}
+
+	portals := make(map[Point]Point, len(ends)*2)
+	for _, pair := range ends {
+		if len(pair) != 2 {
+			return nil, ErrInvalidPortals
+		}
+		portals[pair[0]] = pair[1]
+		portals[pair[1]] = pair[0]
+	}
+	return portals, nil
+}
+
+// PortalGenerator wraps another Generator and drops portals into its mazes.
+type PortalGenerator struct {
+	base  Generator
+	pairs int
+}
+
+// NewPortalGenerator creates a generator that places up to pairs portals in
+// the mazes of base.
+func NewPortalGenerator(base Generator, pairs int) Generator {
+	return &PortalGenerator{base: base, pairs: pairs}
+}
+
+// Generate builds a maze with the wrapped generator and turns pairs of its
+// passage squares, drawn from a random source seeded from seed, into portals
+// numbered from FirstPortal. Passage squares run out before portals do on
+// small mazes, in which case fewer portals are placed. Each portal end is
+// reported as an EventPortal.
+func (g *PortalGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	result, err := g.base.Generate(ctx, width, height, seed, opts...)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+
+	var open []Point
+	for y, row := range result.Grid {
+		for x, v := range row {
+			if v == 0 {
+				open = append(open, Point{X: x, Y: y})
+			}
+		}
+	}
+
+	cv := wrapCanvas(result.Grid, opts)
+	rng := newRNG(seed)
+	rng.Shuffle(len(open), func(i, j int) {
+		open[i], open[j] = open[j], open[i]
+	})
+	for i := 0; i < g.pairs && 2*i+1 < len(open); i++ {
+		id := FirstPortal + i
+		for _, p := range open[2*i : 2*i+2] {
+			cv.grid[p.Y][p.X] = id
+			if cv.emit != nil {
+				cv.emit(Event{Kind: EventPortal, Point: p, Portal: id})
+			}
+		}
+	}
+	return result, nil
+}
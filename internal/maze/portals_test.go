@@ -0,0 +1,85 @@
+package maze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortals_PairsEnds(t *testing.T) {
+	ground := Grid{
+		{2, 0, 3},
+		{1, 1, 1},
+	}
+	upper := Grid{
+		{0, 3, 2},
+		{1, 1, 1},
+	}
+
+	portals, err := Portals(ground, upper)
+	require.NoError(t, err)
+	assert.Equal(t, map[Point]Point{
+		{X: 0, Y: 0}:       {X: 2, Y: 0, Z: 1},
+		{X: 2, Y: 0, Z: 1}: {X: 0, Y: 0},
+		{X: 2, Y: 0}:       {X: 1, Y: 0, Z: 1},
+		{X: 1, Y: 0, Z: 1}: {X: 2, Y: 0},
+	}, portals)
+
+	portals, err = Portals(Grid{{0, 1}})
+	require.NoError(t, err)
+	assert.Empty(t, portals)
+}
+
+func TestPortals_RejectsUnpairedIDs(t *testing.T) {
+	for name, grid := range map[string]Grid{
+		"single end": {{2, 0, 0}},
+		"three ends": {{2, 2, 2}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Portals(grid)
+			assert.ErrorIs(t, err, ErrInvalidPortals)
+		})
+	}
+}
+
+func TestPortalGenerator_PlacesPairs(t *testing.T) {
+	seed := int64(4)
+	var events []Event
+	result, err := NewPortalGenerator(NewGenerator(), 3).Generate(context.Background(), 6, 5, &seed, WithEvents(func(e Event) {
+		events = append(events, e)
+	}))
+	require.NoError(t, err)
+
+	portals, err := Portals(result.Grid)
+	require.NoError(t, err)
+	assert.Len(t, portals, 6)
+
+	grid := newWallGrid(6, 5)
+	for _, ev := range events {
+		switch ev.Kind {
+		case EventCarve:
+			grid[ev.Point.Y][ev.Point.X] = 0
+		case EventPortal:
+			require.GreaterOrEqual(t, ev.Portal, FirstPortal)
+			grid[ev.Point.Y][ev.Point.X] = ev.Portal
+		}
+	}
+	assert.Equal(t, result.Grid, grid, "events must replay to the finished maze")
+
+	again, err := NewPortalGenerator(NewGenerator(), 3).Generate(context.Background(), 6, 5, &seed)
+	require.NoError(t, err)
+	assert.Equal(t, result.Grid, again.Grid, "the same seed must place the same portals")
+}
+
+func TestPortalGenerator_RunsOutOfSquares(t *testing.T) {
+	seed := int64(1)
+	result, err := NewPortalGenerator(NewGenerator(), 100).Generate(context.Background(), 2, 2, &seed)
+	require.NoError(t, err)
+
+	portals, err := Portals(result.Grid)
+	require.NoError(t, err)
+	// A 2x2 maze has 4 cells and 3 passages between them.
+	assert.Len(t, portals, 6)
+}
@@ -0,0 +1,63 @@
+package maze
+
+import "context"
+
+// PrimGenerator implements Generator with randomized Prim's algorithm.
+type PrimGenerator struct{}
+
+// NewPrimGenerator creates a randomized Prim maze generator.
+func NewPrimGenerator() Generator {
+	return &PrimGenerator{}
+}
+
+// Generate grows the maze from a random cell. Each step picks a random wall on
+// the boundary of the maze and, if the cell behind it is still unvisited, carves
+// through and adds that cell's walls to the boundary. The result is a perfect
+// maze with many branches radiating from the starting cell.
+func (g *PrimGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+	if width < 2 || height < 2 {
+		return GenerateResult{}, ErrInvalidDimensions
+	}
+
+	rng := newRNG(seed)
+	cv, err := newCellCanvas(width, height, opts)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+	visited := newVisited(width, height)
+
+	var boundary []wall
+	visit := func(c cell) {
+		visited[c.y][c.x] = true
+		carveCell(cv, c.x, c.y)
+		for _, n := range cv.neighbors(c) {
+			if !visited[n.y][n.x] {
+				boundary = append(boundary, wall{a: c, b: n})
+			}
+		}
+	}
+
+	visit(cell{x: rng.Intn(width), y: rng.Intn(height)})
+
+	for len(boundary) > 0 {
+		if err := ctx.Err(); err != nil {
+			return GenerateResult{}, err
+		}
+
+		i := rng.Intn(len(boundary))
+		w := boundary[i]
+		boundary[i] = boundary[len(boundary)-1]
+		boundary = boundary[:len(boundary)-1]
+
+		if visited[w.b.y][w.b.x] {
+			continue
+		}
+		carvePassage(cv, w.a, w.b)
+		visit(w.b)
+	}
+
+	return cv.result(AlgorithmPrim, seed), nil
+}
@@ -0,0 +1,90 @@
+package maze
+
+import "context"
+
+// RecursiveDivisionGenerator implements Generator with recursive division.
+type RecursiveDivisionGenerator struct{}
+
+// NewRecursiveDivisionGenerator creates a recursive-division maze generator.
+func NewRecursiveDivisionGenerator() Generator {
+	return &RecursiveDivisionGenerator{}
+}
+
+// chamber is a rectangle of cells that is still open inside.
+type chamber struct {
+	x, y          int
+	width, height int
+}
+
+// Generate is a wall adder rather than a passage carver: it opens the whole
+// interior, then splits it with a wall holding a single gap and recurses into
+// both halves until every chamber is one cell wide or high. Walls run across the
+// narrower side of a chamber, or a random one for squares, which gives the maze
+// long straight corridors and a visible rectangular structure. Its events carve
+// the interior first and then raise the dividing walls.
+func (g *RecursiveDivisionGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+	if width < 2 || height < 2 {
+		return GenerateResult{}, ErrInvalidDimensions
+	}
+
+	rng := newRNG(seed)
+	cv := newCanvas(width, height, opts)
+	for y := 1; y < 2*height; y++ {
+		for x := 1; x < 2*width; x++ {
+			cv.carve(x, y)
+		}
+	}
+
+	// An explicit stack keeps deep divisions of large mazes off the call stack.
+	stack := []chamber{{x: 0, y: 0, width: width, height: height}}
+	for len(stack) > 0 {
+		if err := ctx.Err(); err != nil {
+			return GenerateResult{}, err
+		}
+
+		ch := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if ch.width < 2 || ch.height < 2 {
+			continue
+		}
+
+		horizontal := ch.width < ch.height
+		if ch.width == ch.height {
+			horizontal = rng.Intn(2) == 0
+		}
+
+		if horizontal {
+			// The wall runs between cell rows split-1 and split, with a gap above one column.
+			split := 1 + rng.Intn(ch.height-1)
+			gap := ch.x + rng.Intn(ch.width)
+			wallY := 2 * (ch.y + split)
+			for x := 2*ch.x + 1; x < 2*(ch.x+ch.width); x++ {
+				if x != 2*gap+1 {
+					cv.wall(x, wallY)
+				}
+			}
+			stack = append(stack,
+				chamber{x: ch.x, y: ch.y + split, width: ch.width, height: ch.height - split},
+				chamber{x: ch.x, y: ch.y, width: ch.width, height: split},
+			)
+		} else {
+			split := 1 + rng.Intn(ch.width-1)
+			gap := ch.y + rng.Intn(ch.height)
+			wallX := 2 * (ch.x + split)
+			for y := 2*ch.y + 1; y < 2*(ch.y+ch.height); y++ {
+				if y != 2*gap+1 {
+					cv.wall(wallX, y)
+				}
+			}
+			stack = append(stack,
+				chamber{x: ch.x + split, y: ch.y, width: ch.width - split, height: ch.height},
+				chamber{x: ch.x, y: ch.y, width: split, height: ch.height},
+			)
+		}
+	}
+
+	return newResult(AlgorithmRecursiveDivision, cv.grid, seed), nil
+}
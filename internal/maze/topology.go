@@ -0,0 +1,86 @@
+package maze
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnknownTopology indicates an unsupported grid topology.
+var ErrUnknownTopology = errors.New("topology must be one of: square, hex, polar, torus")
+
+// Topology names the shape of the tiles a Grid is made of, which decides how
+// they are drawn and which tiles are neighbours.
+type Topology string
+
+const (
+	// TopologySquare is the usual grid of square tiles: grid[y][x] touches the
+	// four tiles above, below, left and right of it.
+	TopologySquare Topology = "square"
+	// TopologyHex is a grid of pointy-top hexagons in axial coordinates, with
+	// Point.X as the q axis and Point.Y as the r axis, stored as grid[r][q].
+	// Every tile touches six others, along HexDirections. Drawn with each row
+	// half a tile right of the row above, the grid is a rhombus.
+	TopologyHex Topology = "hex"
+	// TopologyPolar is a circular maze of concentric rings around a centre
+	// tile, with rows of different lengths. Row 0 holds the centre. Ring r ≥ 1
+	// with n cells takes two rows: row 2r-1 holds the n walls on its inner
+	// edge, where wall j sits between cell j and the cell inwards of it, and
+	// row 2r holds cell i at x = 2i and the wall between cells i and i+1,
+	// clockwise, at x = 2i+1. Each ring has at least two cells and a multiple
+	// of the count of the ring inside it. PolarNeighbors lists the tiles
+	// touching a tile.
+	TopologyPolar Topology = "polar"
+	// TopologyTorus is a grid of square tiles whose edges wrap around: stepping
+	// off the right edge enters the left edge of the same row, and stepping off
+	// the bottom enters the top of the same column. Grids built WithWrap have
+	// (height*2) x (width*2) squares with cell (x, y) at grid[2y+1][2x+1]; row
+	// 0 and column 0 hold the walls across the seams.
+	TopologyTorus Topology = "torus"
+)
+
+// DefaultTopology is used when a request does not name a topology.
+const DefaultTopology = TopologySquare
+
+// Topologies lists every supported topology.
+var Topologies = []Topology{TopologySquare, TopologyHex, TopologyPolar, TopologyTorus}
+
+// HexDirections are the axial offsets of the six neighbours of a hex tile,
+// clockwise from east.
+var HexDirections = []Point{
+	{X: 1, Y: 0},
+	{X: 0, Y: 1},
+	{X: -1, Y: 1},
+	{X: -1, Y: 0},
+	{X: 0, Y: -1},
+	{X: 1, Y: -1},
+}
+
+// ParseTopology converts a topology name into a Topology. Matching is
+// case-insensitive and an empty name selects DefaultTopology.
+func ParseTopology(name string) (Topology, error) {
+	if name == "" {
+		return DefaultTopology, nil
+	}
+	for _, t := range Topologies {
+		if strings.EqualFold(name, string(t)) {
+			return t, nil
+		}
+	}
+	return "", ErrUnknownTopology
+}
+
+// HexDistance is the number of steps between two hex tiles in axial
+// coordinates.
+func HexDistance(a, b Point) int {
+	dq := a.X - b.X
+	dr := a.Y - b.Y
+	return (abs(dq) + abs(dr) + abs(dq+dr)) / 2
+}
+
+// abs returns the absolute value of v.
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
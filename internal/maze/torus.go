@@ -0,0 +1,121 @@
+package maze
+
+import "errors"
+
+// ErrInvalidTorusDimensions indicates a torus maze narrower or shorter than
+// three cells. With two, both ways around a seam reach the same cell, so the
+// seam wall could never be told apart from the inner one.
+var ErrInvalidTorusDimensions = errors.New("torus maze dimensions must be at least 3x3")
+
+// WrapAlgorithms lists the algorithms whose generators honour WithWrap. The
+// others only build bounded grids and ignore it.
+var WrapAlgorithms = []Algorithm{
+	AlgorithmBacktracker,
+	AlgorithmKruskal,
+	AlgorithmPrim,
+	AlgorithmWilson,
+	AlgorithmAldousBroder,
+}
+
+// WithWrap builds a toroidal maze: cells on the right edge neighbour those on
+// the left edge of the same row and cells on the bottom edge those on the top
+// edge of the same column, so passages may cross the seams. The result has
+// TopologyTorus and a grid of (height*2) x (width*2) squares.
+func WithWrap() Option {
+	return func(o *options) {
+		o.wrap = true
+	}
+}
+
+// newTorusGrid returns a torus grid for width x height cells with every wall
+// standing and no cell carved yet. Row 0 and column 0 hold the walls across
+// the seams, so the grid has no outer border.
+func newTorusGrid(width, height int) Grid {
+	grid := make(Grid, height*2)
+	for y := range grid {
+		row := make([]int, width*2)
+		for x := range row {
+			row[x] = 1
+		}
+		grid[y] = row
+	}
+	return grid
+}
+
+// newCellCanvas is newCanvas for the generators in WrapAlgorithms, which walk
+// the cells through the canvas: with WithWrap the canvas is a torus, which
+// must be at least 3x3 cells or it yields ErrInvalidTorusDimensions.
+func newCellCanvas(width, height int, opts []Option) (*canvas, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.wrap {
+		return newCanvas(width, height, opts), nil
+	}
+	if width < 3 || height < 3 {
+		return nil, ErrInvalidTorusDimensions
+	}
+	cv := wrapCanvas(newTorusGrid(width, height), opts)
+	cv.wrap = true
+	return cv, nil
+}
+
+// result wraps the finished canvas like newResult, with TopologyTorus when the
+// canvas wraps.
+func (cv *canvas) result(algorithm Algorithm, seed *int64) GenerateResult {
+	result := newResult(algorithm, cv.grid, seed)
+	if cv.wrap {
+		result.Topology = TopologyTorus
+	}
+	return result
+}
+
+// cells returns the number of cells across and down the canvas. Both the
+// bounded and the torus layout keep cell (x, y) at grid[2y+1][2x+1].
+func (cv *canvas) cells() (width, height int) {
+	return len(cv.grid[0]) / 2, len(cv.grid) / 2
+}
+
+// neighbors lists the cells next to c in the order north, east, south, west.
+// On a torus every cell has four, wrapping across the seams.
+func (cv *canvas) neighbors(c cell) []cell {
+	width, height := cv.cells()
+	if !cv.wrap {
+		return cellNeighbors(c, width, height)
+	}
+	return []cell{
+		{x: c.x, y: (c.y + height - 1) % height},
+		{x: (c.x + 1) % width, y: c.y},
+		{x: c.x, y: (c.y + 1) % height},
+		{x: (c.x + width - 1) % width, y: c.y},
+	}
+}
+
+// wallBetween returns the square between the neighbouring cells a and b. On a
+// torus two cells more than one column or row apart are neighbours across a
+// seam, whose wall sits in column or row 0.
+func (cv *canvas) wallBetween(a, b cell) Point {
+	dx, dy := b.x-a.x, b.y-a.y
+	if !cv.wrap {
+		return Point{X: a.x*2 + 1 + dx, Y: a.y*2 + 1 + dy}
+	}
+	dx, dy = seamStep(dx), seamStep(dy)
+	return Point{
+		X: (a.x*2 + 1 + dx + len(cv.grid[0])) % len(cv.grid[0]),
+		Y: (a.y*2 + 1 + dy + len(cv.grid)) % len(cv.grid),
+	}
+}
+
+// seamStep turns the offset between two neighbouring cells of a torus into a
+// single step, reading a jump across the whole grid as one step over the seam.
+func seamStep(d int) int {
+	switch {
+	case d > 1:
+		return -1
+	case d < -1:
+		return 1
+	default:
+		return d
+	}
+}
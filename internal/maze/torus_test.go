@@ -0,0 +1,122 @@
+package maze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// torusReachable counts the open squares reachable from (1, 1) when steps
+// wrap around the edges of grid.
+func torusReachable(grid Grid) int {
+	height, width := len(grid), len(grid[0])
+	seen := map[Point]bool{{X: 1, Y: 1}: true}
+	queue := []Point{{X: 1, Y: 1}}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, n := range []Point{
+			{X: p.X, Y: (p.Y + height - 1) % height},
+			{X: (p.X + 1) % width, Y: p.Y},
+			{X: p.X, Y: (p.Y + 1) % height},
+			{X: (p.X + width - 1) % width, Y: p.Y},
+		} {
+			if grid[n.Y][n.X] == 0 && !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	return len(seen)
+}
+
+func TestWrapGenerators_ProducePerfectTorus(t *testing.T) {
+	width, height := 8, 6
+	generators := Generators()
+	for _, algorithm := range WrapAlgorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			seed := int64(5)
+			result, err := generators[algorithm].Generate(context.Background(), width, height, &seed, WithWrap())
+			require.NoError(t, err)
+			assert.Equal(t, TopologyTorus, result.Topology)
+			assert.Equal(t, algorithm, result.Algorithm)
+			require.Len(t, result.Grid, height*2)
+			require.Len(t, result.Grid[0], width*2)
+			assert.Equal(t, width*2, result.Width)
+			assert.Equal(t, height*2, result.Height)
+
+			passages, seams := 0, 0
+			for y, row := range result.Grid {
+				for x, v := range row {
+					switch {
+					case x%2 == 1 && y%2 == 1:
+						require.Equal(t, 0, v, "cell closed at (%d,%d)", x, y)
+					case x%2 == 0 && y%2 == 0:
+						require.Equal(t, 1, v, "pillar open at (%d,%d)", x, y)
+					case v == 0:
+						passages++
+						if x == 0 || y == 0 {
+							seams++
+						}
+					}
+				}
+			}
+			assert.Equal(t, width*height-1, passages, "a spanning tree has one passage fewer than cells")
+			assert.Positive(t, seams, "no passage crosses a seam")
+			assert.Equal(t, width*height+passages, torusReachable(result.Grid), "every open square must be reachable")
+		})
+	}
+}
+
+func TestWrapGenerators_TooNarrowTorus(t *testing.T) {
+	generators := Generators()
+	for _, algorithm := range WrapAlgorithms {
+		for _, size := range [][2]int{{2, 5}, {5, 2}} {
+			_, err := generators[algorithm].Generate(context.Background(), size[0], size[1], nil, WithWrap())
+			assert.ErrorIs(t, err, ErrInvalidTorusDimensions, "%s %dx%d", algorithm, size[0], size[1])
+		}
+		_, err := generators[algorithm].Generate(context.Background(), 2, 2, nil)
+		assert.NoError(t, err, "%s bounded 2x2", algorithm)
+	}
+}
+
+func TestWrapGenerators_EventsReplayToGrid(t *testing.T) {
+	seed := int64(9)
+	var events []Event
+	result, err := NewKruskalGenerator().Generate(context.Background(), 5, 4, &seed, WithWrap(), WithEvents(func(e Event) {
+		events = append(events, e)
+	}))
+	require.NoError(t, err)
+
+	grid := newTorusGrid(5, 4)
+	for _, ev := range events {
+		require.Equal(t, EventCarve, ev.Kind)
+		grid[ev.Point.Y][ev.Point.X] = 0
+	}
+	assert.Equal(t, result.Grid, grid)
+}
+
+func TestBraidedGenerator_Torus(t *testing.T) {
+	seed := int64(13)
+	result, err := NewBraidedGenerator(NewGenerator(), 1).Generate(context.Background(), 7, 5, &seed, WithWrap())
+	require.NoError(t, err)
+	assert.Equal(t, TopologyTorus, result.Topology)
+
+	cv := wrapCanvas(result.Grid, nil)
+	cv.wrap = true
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 7; x++ {
+			assert.False(t, isDeadEnd(cv, cell{x: x, y: y}), "dead end left at cell (%d,%d)", x, y)
+		}
+	}
+}
+
+func TestWithWrap_IgnoredByBoundedGenerators(t *testing.T) {
+	seed := int64(2)
+	result, err := NewEllerGenerator().Generate(context.Background(), 4, 4, &seed, WithWrap())
+	require.NoError(t, err)
+	assert.Equal(t, TopologySquare, result.Topology)
+	assert.Len(t, result.Grid, 9)
+}
@@ -1,19 +1,51 @@
 package maze
 
 // Point represents a 2D coordinate in the grid space where X increases to the right
-// and Y increases downward.
+// and Y increases downward. Z is the floor of a multi-level maze, 0 being the
+// ground floor; single-floor mazes leave it at 0. It is always written to
+// JSON, so clients can tell the floor of every point.
 type Point struct {
 	X int `json:"x"`
 	Y int `json:"y"`
+	Z int `json:"z"`
 }
 
 // Grid models a maze grid where 0 indicates a walkable cell and 1 indicates a wall.
+// Values from FirstPortal upwards mark the walkable ends of portals, and
+// negative values keys and the doors they open, see MaxKeys.
 type Grid [][]int
 
 // GenerateResult captures the payload returned to clients after maze generation.
+// Topology tells clients how to lay out the grid tiles.
+// Braid is the fraction of dead ends removed to create loops; zero means a perfect maze.
+// Rooms is only set by generators that lay out rooms, such as the dungeon generator.
+// Floors and Stairs are only set for multi-level mazes: Floors lists every floor
+// from the ground up, with Grid repeating the ground floor, and each stair joins
+// its square on floor Z to the same square on floor Z+1.
+// Events is only set when the caller asked to record the generation steps.
 type GenerateResult struct {
-	Width  int    `json:"width"`
-	Height int    `json:"height"`
-	Grid   Grid   `json:"grid"`
-	Seed   *int64 `json:"seed,omitempty"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+	Grid      Grid      `json:"grid"`
+	Seed      *int64    `json:"seed,omitempty"`
+	Algorithm Algorithm `json:"algorithm,omitempty"`
+	Topology  Topology  `json:"topology"`
+	Braid     float64   `json:"braid,omitempty"`
+	Rooms     []Room    `json:"rooms,omitempty"`
+	Floors    []Grid    `json:"floors,omitempty"`
+	Stairs    []Point   `json:"stairs,omitempty"`
+	Events    []Event   `json:"events,omitempty"`
+}
+
+// CostGrid assigns a movement cost to every cell of a Grid, which lets a maze
+// model terrain such as roads, mud or water. A step costs whatever the entered
+// cell costs. A nil CostGrid stands for a uniform cost of 1 per step.
+type CostGrid [][]float64
+
+// Cost returns the cost of entering p.
+func (c CostGrid) Cost(p Point) float64 {
+	if c == nil {
+		return 1
+	}
+	return c[p.Y][p.X]
 }
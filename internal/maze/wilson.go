@@ -0,0 +1,71 @@
+package maze
+
+import "context"
+
+// WilsonGenerator implements Generator with Wilson's algorithm.
+type WilsonGenerator struct{}
+
+// NewWilsonGenerator creates a Wilson's algorithm maze generator.
+func NewWilsonGenerator() Generator {
+	return &WilsonGenerator{}
+}
+
+// Generate starts with one random cell in the maze. From every cell not yet in
+// the maze it performs a random walk until it hits the maze, erasing loops as
+// they form, and carves the loop-free walk. Every spanning tree of the grid is
+// equally likely, so the maze has no directional bias.
+func (g *WilsonGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...Option) (GenerateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+	if width < 2 || height < 2 {
+		return GenerateResult{}, ErrInvalidDimensions
+	}
+
+	rng := newRNG(seed)
+	cv, err := newCellCanvas(width, height, opts)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+	inMaze := newVisited(width, height)
+
+	root := cell{x: rng.Intn(width), y: rng.Intn(height)}
+	inMaze[root.y][root.x] = true
+	carveCell(cv, root.x, root.y)
+
+	// next remembers the last exit taken from each cell of the current walk;
+	// overwriting it on revisits is what erases the loops.
+	next := make([][]cell, height)
+	for y := range next {
+		next[y] = make([]cell, width)
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if inMaze[y][x] {
+				continue
+			}
+
+			start := cell{x: x, y: y}
+			for current := start; !inMaze[current.y][current.x]; {
+				if err := ctx.Err(); err != nil {
+					return GenerateResult{}, err
+				}
+				neighbors := cv.neighbors(current)
+				step := neighbors[rng.Intn(len(neighbors))]
+				next[current.y][current.x] = step
+				current = step
+			}
+
+			for current := start; !inMaze[current.y][current.x]; {
+				step := next[current.y][current.x]
+				inMaze[current.y][current.x] = true
+				carveCell(cv, current.x, current.y)
+				carvePassage(cv, current, step)
+				current = step
+			}
+		}
+	}
+
+	return cv.result(AlgorithmWilson, seed), nil
+}
@@ -0,0 +1,183 @@
+package maze
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+)
+
+const (
+	// DefaultChunkSize is the side of a world chunk in cells.
+	DefaultChunkSize = 16
+	// MaxChunkSize bounds the side of a world chunk in cells.
+	MaxChunkSize = 64
+)
+
+// ErrInvalidChunkSize indicates a chunk side outside [2, MaxChunkSize].
+var ErrInvalidChunkSize = errors.New("chunk size must be between 2 and 64 cells")
+
+// Salts that keep the random streams of a chunk and its two owned borders apart.
+const (
+	saltChunk int64 = iota
+	saltWestBorder
+	saltNorthBorder
+)
+
+// World is an infinite maze split into square chunks of ChunkSize x ChunkSize
+// cells. A chunk's contents depend only on the world seed and the chunk
+// coordinates, so any chunk can be generated on its own, in any order, and
+// always comes out the same.
+//
+// World coordinates use the same lattice as a single maze, with cells on odd
+// coordinates and walls between them, extended in every direction. Chunk
+// (cx, cy) covers the 2*ChunkSize squares per side starting at
+// (cx*2*ChunkSize, cy*2*ChunkSize), including the wall lines along its west and
+// north edges but not those along its east and south edges, which belong to
+// its neighbours. Each chunk is a perfect maze, and every border gets doors
+// drawn from the seed and position of that border alone, so the two chunks on
+// either side of it always agree and the whole world is connected.
+type World struct {
+	seed      int64
+	chunkSize int
+	generator Generator
+}
+
+// NewWorld creates a world of chunkSize x chunkSize cell chunks.
+func NewWorld(seed int64, chunkSize int) (*World, error) {
+	if chunkSize < 2 || chunkSize > MaxChunkSize {
+		return nil, ErrInvalidChunkSize
+	}
+	return &World{seed: seed, chunkSize: chunkSize, generator: NewGenerator()}, nil
+}
+
+// Seed returns the world seed.
+func (w *World) Seed() int64 {
+	return w.seed
+}
+
+// ChunkSize returns the side of a chunk in cells.
+func (w *World) ChunkSize() int {
+	return w.chunkSize
+}
+
+// ChunkCoord identifies a chunk of a World.
+type ChunkCoord struct {
+	X int `json:"cx"`
+	Y int `json:"cy"`
+}
+
+// Chunk is one tile of a World. Origin is the world coordinate of Grid[0][0]
+// and Size the number of squares per side, twice the chunk size in cells.
+type Chunk struct {
+	Coord  ChunkCoord `json:"coord"`
+	Origin Point      `json:"origin"`
+	Size   int        `json:"size"`
+	Seed   int64      `json:"seed"`
+	Grid   Grid       `json:"grid"`
+}
+
+// Chunk generates the chunk at (cx, cy).
+func (w *World) Chunk(ctx context.Context, cx, cy int) (Chunk, error) {
+	seed := mixSeed(w.seed, int64(cx), int64(cy), saltChunk)
+	result, err := w.generator.Generate(ctx, w.chunkSize, w.chunkSize, &seed)
+	if err != nil {
+		return Chunk{}, err
+	}
+
+	// Drop the east and south wall lines; the neighbouring chunks own them.
+	side := 2 * w.chunkSize
+	grid := make(Grid, side)
+	for y := range grid {
+		grid[y] = result.Grid[y][:side:side]
+	}
+
+	for _, cell := range w.borderDoors(cx, cy, saltWestBorder) {
+		grid[2*cell+1][0] = 0
+	}
+	for _, cell := range w.borderDoors(cx, cy, saltNorthBorder) {
+		grid[0][2*cell+1] = 0
+	}
+
+	return Chunk{
+		Coord:  ChunkCoord{X: cx, Y: cy},
+		Origin: Point{X: cx * side, Y: cy * side},
+		Size:   side,
+		Seed:   seed,
+		Grid:   grid,
+	}, nil
+}
+
+// ChunkOf returns the chunk holding the world point p and p's position inside it.
+func (w *World) ChunkOf(p Point) (ChunkCoord, Point) {
+	side := 2 * w.chunkSize
+	coord := ChunkCoord{X: floorDiv(p.X, side), Y: floorDiv(p.Y, side)}
+	return coord, Point{X: p.X - coord.X*side, Y: p.Y - coord.Y*side}
+}
+
+// borderDoors picks the cells, counted along the border, whose wall to the
+// neighbouring chunk is open. Larger chunks get more doors so that routes do
+// not all funnel through a single gap.
+func (w *World) borderDoors(cx, cy int, salt int64) []int {
+	rng := rand.New(rand.NewSource(mixSeed(w.seed, int64(cx), int64(cy), salt)))
+	doors := max(1, w.chunkSize/8)
+	return rng.Perm(w.chunkSize)[:doors]
+}
+
+// mixSeed hashes values into a single seed with the SplitMix64 finaliser, so
+// neighbouring chunks get unrelated random streams.
+func mixSeed(values ...int64) int64 {
+	h := uint64(0x9E3779B97F4A7C15)
+	for _, v := range values {
+		h ^= uint64(v)
+		h += 0x9E3779B97F4A7C15
+		h = (h ^ (h >> 30)) * 0xBF58476D1CE4E5B9
+		h = (h ^ (h >> 27)) * 0x94D049BB133111EB
+		h ^= h >> 31
+	}
+	return int64(h)
+}
+
+// floorDiv divides rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// ChunkCache loads the chunks of a World the first time one of their squares
+// is looked up and keeps them for later lookups. It lets a solver explore the
+// infinite world while only generating the chunks its search reaches.
+// A ChunkCache is not safe for concurrent use.
+type ChunkCache struct {
+	world  *World
+	chunks map[ChunkCoord]Grid
+	loaded []ChunkCoord
+}
+
+// NewChunkCache creates an empty cache over world.
+func NewChunkCache(world *World) *ChunkCache {
+	return &ChunkCache{world: world, chunks: make(map[ChunkCoord]Grid)}
+}
+
+// Walkable reports whether the world point p is open, loading its chunk if needed.
+func (c *ChunkCache) Walkable(ctx context.Context, p Point) (bool, error) {
+	coord, local := c.world.ChunkOf(p)
+	grid, ok := c.chunks[coord]
+	if !ok {
+		chunk, err := c.world.Chunk(ctx, coord.X, coord.Y)
+		if err != nil {
+			return false, err
+		}
+		grid = chunk.Grid
+		c.chunks[coord] = grid
+		c.loaded = append(c.loaded, coord)
+	}
+	return grid[local.Y][local.X] == 0, nil
+}
+
+// Loaded lists the chunks loaded so far, in the order they were first needed.
+func (c *ChunkCache) Loaded() []ChunkCoord {
+	return c.loaded
+}
@@ -0,0 +1,127 @@
+package maze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWorld_InvalidChunkSize(t *testing.T) {
+	for _, size := range []int{0, 1, MaxChunkSize + 1} {
+		_, err := NewWorld(1, size)
+		assert.ErrorIs(t, err, ErrInvalidChunkSize)
+	}
+}
+
+func TestWorld_ChunksAreDeterministic(t *testing.T) {
+	ctx := context.Background()
+	world, err := NewWorld(99, 8)
+	require.NoError(t, err)
+
+	first, err := world.Chunk(ctx, -3, 5)
+	require.NoError(t, err)
+	_, err = world.Chunk(ctx, 0, 0)
+	require.NoError(t, err)
+	again, err := world.Chunk(ctx, -3, 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, again)
+	assert.Equal(t, Point{X: -48, Y: 80}, first.Origin)
+	assert.Equal(t, 16, first.Size)
+	require.Len(t, first.Grid, 16)
+	for _, row := range first.Grid {
+		require.Len(t, row, 16)
+	}
+
+	other, err := NewWorld(100, 8)
+	require.NoError(t, err)
+	different, err := other.Chunk(ctx, -3, 5)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Grid, different.Grid)
+}
+
+func TestWorld_ChunksJoinIntoConnectedMaze(t *testing.T) {
+	ctx := context.Background()
+	const size = 6
+	world, err := NewWorld(7, size)
+	require.NoError(t, err)
+
+	// Stitch the 3x3 block of chunks around the origin together, closing it off
+	// with a wall line on the east and south edges.
+	side := 2 * size
+	block := make(Grid, 3*side+1)
+	for y := range block {
+		block[y] = make([]int, 3*side+1)
+		for x := range block[y] {
+			block[y][x] = 1
+		}
+	}
+	for cy := -1; cy <= 1; cy++ {
+		for cx := -1; cx <= 1; cx++ {
+			chunk, err := world.Chunk(ctx, cx, cy)
+			require.NoError(t, err)
+			for y, row := range chunk.Grid {
+				copy(block[(cy+1)*side+y][(cx+1)*side:], row)
+			}
+		}
+	}
+
+	// Only consider the interior; doors on the outer west and north lines lead
+	// into chunks outside the block.
+	inner := func(p Point) bool {
+		return p.X > 0 && p.Y > 0 && p.X < len(block[0])-1 && p.Y < len(block)-1
+	}
+	start := Point{X: 1, Y: 1}
+	seen := map[Point]bool{start: true}
+	queue := []Point{start}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, d := range orthogonal {
+			n := Point{X: p.X + d.X, Y: p.Y + d.Y}
+			if inner(n) && block[n.Y][n.X] == 0 && !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	for y := 1; y < len(block); y += 2 {
+		for x := 1; x < len(block[y]); x += 2 {
+			assert.True(t, seen[Point{X: x, Y: y}], "cell (%d,%d) is cut off", x, y)
+		}
+	}
+}
+
+func TestWorld_ChunkOf(t *testing.T) {
+	world, err := NewWorld(1, 4)
+	require.NoError(t, err)
+
+	coord, local := world.ChunkOf(Point{X: 9, Y: -1})
+	assert.Equal(t, ChunkCoord{X: 1, Y: -1}, coord)
+	assert.Equal(t, Point{X: 1, Y: 7}, local)
+
+	coord, local = world.ChunkOf(Point{X: -8, Y: 0})
+	assert.Equal(t, ChunkCoord{X: -1, Y: 0}, coord)
+	assert.Equal(t, Point{X: 0, Y: 0}, local)
+}
+
+func TestChunkCache_LoadsOnDemand(t *testing.T) {
+	ctx := context.Background()
+	world, err := NewWorld(5, 4)
+	require.NoError(t, err)
+	cache := NewChunkCache(world)
+
+	open, err := cache.Walkable(ctx, Point{X: 1, Y: 1})
+	require.NoError(t, err)
+	assert.True(t, open)
+	open, err = cache.Walkable(ctx, Point{X: -8, Y: -8})
+	require.NoError(t, err)
+	assert.False(t, open, "pillars are always wall")
+	_, err = cache.Walkable(ctx, Point{X: 3, Y: 5})
+	require.NoError(t, err)
+
+	assert.Equal(t, []ChunkCoord{{X: 0, Y: 0}, {X: -1, Y: -1}}, cache.Loaded())
+}
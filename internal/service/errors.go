@@ -0,0 +1,23 @@
+package service
+
+import (
+	"fmt"
+
+	apierrors "github.com/JoshuaPangaribuan/pathfinder/internal/errors"
+)
+
+// validationErrorf reports a request the service rejects as a typed
+// validation error, so callers need not recognise it by its message
+func validationErrorf(format string, args ...any) error {
+	return apierrors.NewValidationError(fmt.Sprintf(format, args...))
+}
+
+// invalidDimensionsErrorf reports maze dimensions the service rejects
+func invalidDimensionsErrorf(format string, args ...any) error {
+	return apierrors.NewInvalidDimensionsError(fmt.Sprintf(format, args...))
+}
+
+// unknownAlgorithmErrorf reports an algorithm name the service does not know
+func unknownAlgorithmErrorf(format string, args ...any) error {
+	return apierrors.NewUnknownAlgorithmError(fmt.Sprintf(format, args...))
+}
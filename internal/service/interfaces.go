@@ -3,16 +3,27 @@ package service
 import (
 	"context"
 
+	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/simulation"
 )
 
 // MazeServiceInterface defines the interface for maze service operations
 type MazeServiceInterface interface {
 	GenerateMaze(ctx context.Context, req GenerateMazeRequest) (maze.GenerateResult, error)
+	StreamMaze(ctx context.Context, req StreamMazeRequest) (MazeStream, error)
+	WorldChunk(ctx context.Context, req WorldChunkRequest) (maze.Chunk, error)
 }
 
 // SimulationServiceInterface defines the interface for simulation service operations
 type SimulationServiceInterface interface {
 	RunSimulation(ctx context.Context, req RunSimulationRequest) (RunSimulationResult, error)
+	StreamSimulation(ctx context.Context, req RunSimulationRequest, emit simulation.EmitFunc) (RunSimulationResult, error)
+	ListAlgorithms(ctx context.Context) []algorithm.Info
+	SolveWorld(ctx context.Context, req SolveWorldRequest) (SolveWorldResult, error)
+	SolveMulti(ctx context.Context, req SolveMultiRequest) (SolveMultiResult, error)
+	CreatePlannerSession(ctx context.Context, req CreatePlannerSessionRequest) (PlannerSessionResult, error)
+	UpdatePlannerSession(ctx context.Context, req UpdatePlannerSessionRequest) (PlannerSessionResult, error)
+	DeletePlannerSession(ctx context.Context, id string) error
 }
 
@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
@@ -11,23 +15,57 @@ import (
 
 // MazeService handles maze generation business logic
 type MazeService struct {
-	generator maze.Generator
-	logger    log.Logger
+	generators map[maze.Algorithm]maze.Generator
+	hex        maze.Generator
+	polar      maze.Generator
+	logger     log.Logger
 }
 
-// NewMazeService creates a new maze service
+// NewMazeService creates a new maze service that serves the default algorithm with gen
+// and every other algorithm with its built-in generator
 func NewMazeService(gen maze.Generator, logger log.Logger) *MazeService {
+	generators := maze.Generators()
+	generators[maze.DefaultAlgorithm] = gen
+	return NewMazeServiceWithGenerators(generators, logger)
+}
+
+// NewMazeServiceWithGenerators creates a maze service backed by one generator per algorithm
+func NewMazeServiceWithGenerators(generators map[maze.Algorithm]maze.Generator, logger log.Logger) *MazeService {
 	return &MazeService{
-		generator: gen,
-		logger:    logger,
+		generators: generators,
+		hex:        maze.NewHexGenerator(),
+		polar:      maze.NewPolarGenerator(),
+		logger:     logger,
 	}
 }
 
 // GenerateMazeRequest represents a request to generate a maze
 type GenerateMazeRequest struct {
-	Width  int
-	Height int
-	Seed   *int64
+	Width     int
+	Height    int
+	Seed      *int64
+	Algorithm string
+	// Topology is the tile shape, square by default; hex and polar mazes are
+	// only built with the backtracker. Polar mazes have Height rings around a
+	// centre, the innermost of which has Width cells. Torus mazes wrap around
+	// their edges and are built by the algorithms in maze.WrapAlgorithms
+	Topology string
+	// Levels is the number of floors; more than one builds a multi-level
+	// maze with the backtracker, joined by stairs
+	Levels int
+	// Braid is the fraction of dead ends to remove, from 0 (perfect maze) to 1
+	Braid float64
+	// Portals is the number of portal pairs to drop into the maze
+	Portals int
+	// Keys is the number of coloured doors to lock, each with a key that can
+	// be reached from the first open square of the maze
+	Keys int
+	// Cave overrides the cellular-automaton settings of the cave algorithm
+	Cave *maze.CaveConfig
+	// Dungeon overrides the room and corridor settings of the dungeon algorithm
+	Dungeon *maze.DungeonConfig
+	// Events records the carve/wall steps of the generation in the result
+	Events bool
 }
 
 // GenerateMaze generates a maze with service-level validation and error handling
@@ -35,6 +73,9 @@ func (s *MazeService) GenerateMaze(ctx context.Context, req GenerateMazeRequest)
 	s.logger.Info(ctx, "maze generation requested",
 		log.Int("width", req.Width),
 		log.Int("height", req.Height),
+		log.String("algorithm", req.Algorithm),
+		log.String("topology", req.Topology),
+		log.Float64("braid", req.Braid),
 	)
 
 	// Service-level validation
@@ -47,8 +88,46 @@ func (s *MazeService) GenerateMaze(ctx context.Context, req GenerateMazeRequest)
 		return maze.GenerateResult{}, err
 	}
 
+	// validateRequest guarantees both the name and its generator exist
+	algorithm, _ := maze.ParseAlgorithm(req.Algorithm)
+	generator := s.generators[algorithm]
+	if algorithm == maze.AlgorithmCave && req.Cave != nil {
+		generator = maze.NewCaveGenerator(*req.Cave)
+	}
+	if algorithm == maze.AlgorithmDungeon && req.Dungeon != nil {
+		generator = maze.NewDungeonGenerator(*req.Dungeon)
+	}
+	var opts []maze.Option
+	switch topology, _ := maze.ParseTopology(req.Topology); topology {
+	case maze.TopologyHex:
+		generator = s.hex
+	case maze.TopologyPolar:
+		generator = s.polar
+	case maze.TopologyTorus:
+		opts = append(opts, maze.WithWrap())
+	}
+	if req.Levels > 1 {
+		generator = maze.NewMultiLevelGenerator(req.Levels)
+	}
+	if req.Braid > 0 {
+		generator = maze.NewBraidedGenerator(generator, req.Braid)
+	}
+	if req.Keys > 0 {
+		generator = maze.NewKeyGenerator(generator, req.Keys)
+	}
+	if req.Portals > 0 {
+		generator = maze.NewPortalGenerator(generator, req.Portals)
+	}
+
+	var events []maze.Event
+	if req.Events {
+		opts = append(opts, maze.WithEvents(func(ev maze.Event) {
+			events = append(events, ev)
+		}))
+	}
+
 	// Business logic, logging, metrics can go here
-	result, err := s.generator.Generate(ctx, req.Width, req.Height, req.Seed)
+	result, err := generator.Generate(ctx, req.Width, req.Height, req.Seed, opts...)
 	if err != nil {
 		s.logger.Error(ctx, "maze generation failed", err,
 			log.Int("width", req.Width),
@@ -57,23 +136,215 @@ func (s *MazeService) GenerateMaze(ctx context.Context, req GenerateMazeRequest)
 		// Service-level error handling
 		return maze.GenerateResult{}, fmt.Errorf("maze generation failed: %w", err)
 	}
+	if req.Events {
+		result.Events = events
+	}
 
 	s.logger.Info(ctx, "maze generation completed",
 		log.Int("width", result.Width),
 		log.Int("height", result.Height),
+		log.String("algorithm", string(algorithm)),
+		log.String("topology", string(result.Topology)),
+		log.Int("rooms", len(result.Rooms)),
+		log.Int("events", len(result.Events)),
 	)
 
 	return result, nil
 }
 
+// Streamed mazes only hold one row in memory, so they may be far taller than
+// the mazes GenerateMaze returns whole.
+const (
+	maxStreamWidth  = 1000
+	maxStreamHeight = 1000000
+)
+
+// StreamMazeRequest represents a request to stream a maze row by row
+type StreamMazeRequest struct {
+	Width  int
+	Height int
+	Seed   *int64
+}
+
+// MazeStream is a validated maze stream. Width and Height are the grid size,
+// Seed is the seed actually used so the maze can be regenerated, and Rows
+// yields the grid rows from top to bottom.
+type MazeStream struct {
+	Width     int
+	Height    int
+	Seed      int64
+	Algorithm maze.Algorithm
+	Rows      iter.Seq2[[]int, error]
+}
+
+// StreamMaze validates req and prepares an Eller's-algorithm maze whose rows are
+// generated lazily as the caller ranges over them
+func (s *MazeService) StreamMaze(ctx context.Context, req StreamMazeRequest) (MazeStream, error) {
+	s.logger.Info(ctx, "maze stream requested",
+		log.Int("width", req.Width),
+		log.Int("height", req.Height),
+	)
+
+	if req.Width < 2 || req.Height < 2 {
+		return MazeStream{}, invalidDimensionsErrorf("dimensions must be at least 2x2")
+	}
+	if req.Width > maxStreamWidth || req.Height > maxStreamHeight {
+		return MazeStream{}, invalidDimensionsErrorf("dimensions must be at most %dx%d", maxStreamWidth, maxStreamHeight)
+	}
+
+	// Pick the seed here so clients can regenerate the maze they received
+	seed := time.Now().UnixNano()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+
+	return MazeStream{
+		Width:     req.Width*2 + 1,
+		Height:    req.Height*2 + 1,
+		Seed:      seed,
+		Algorithm: maze.AlgorithmEller,
+		Rows:      maze.EllerRows(ctx, req.Width, req.Height, &seed),
+	}, nil
+}
+
+// WorldChunkRequest represents a request for one chunk of an infinite world
+type WorldChunkRequest struct {
+	Seed int64
+	// ChunkSize is the chunk side in cells; zero selects maze.DefaultChunkSize
+	ChunkSize int
+	X         int
+	Y         int
+}
+
+// WorldChunk generates one chunk of the world identified by req.Seed
+func (s *MazeService) WorldChunk(ctx context.Context, req WorldChunkRequest) (maze.Chunk, error) {
+	chunkSize := req.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = maze.DefaultChunkSize
+	}
+	world, err := maze.NewWorld(req.Seed, chunkSize)
+	if err != nil {
+		s.logger.Warn(ctx, "world chunk validation failed",
+			log.Error(err),
+			log.Int("chunk_size", req.ChunkSize),
+		)
+		return maze.Chunk{}, err
+	}
+
+	chunk, err := world.Chunk(ctx, req.X, req.Y)
+	if err != nil {
+		s.logger.Error(ctx, "world chunk generation failed", err,
+			log.Int("cx", req.X),
+			log.Int("cy", req.Y),
+		)
+		return maze.Chunk{}, fmt.Errorf("world chunk generation failed: %w", err)
+	}
+	return chunk, nil
+}
+
+// maxLevels caps the floors of a multi-level maze, maxPortals the portal
+// pairs and maxKeys the locked doors of any maze
+const (
+	maxLevels  = 8
+	maxPortals = 10
+	maxKeys    = 8
+)
+
 // validateRequest performs service-level validation
 func (s *MazeService) validateRequest(req GenerateMazeRequest) error {
 	if req.Width < 2 || req.Height < 2 {
-		return errors.New("dimensions must be at least 2x2")
+		return invalidDimensionsErrorf("dimensions must be at least 2x2")
 	}
 	if req.Width > 100 || req.Height > 100 {
-		return errors.New("dimensions must be at most 100x100")
+		return invalidDimensionsErrorf("dimensions must be at most 100x100")
+	}
+
+	if req.Levels < 0 || req.Levels > maxLevels {
+		return validationErrorf("levels must be between 0 and %d", maxLevels)
+	}
+
+	if req.Portals < 0 || req.Portals > maxPortals {
+		return validationErrorf("portals must be between 0 and %d", maxPortals)
+	}
+	if req.Levels > 1 && req.Portals > 0 {
+		return validationErrorf("multi-level mazes do not support portals")
+	}
+
+	if req.Keys < 0 || req.Keys > maxKeys {
+		return validationErrorf("keys must be between 0 and %d", maxKeys)
+	}
+	if req.Keys > 0 && req.Levels > 1 {
+		return validationErrorf("multi-level mazes do not support keys")
+	}
+
+	if req.Braid < 0 || req.Braid > 1 || math.IsNaN(req.Braid) {
+		return maze.ErrInvalidBraid
+	}
+
+	if req.Cave != nil {
+		if err := req.Cave.Validate(); err != nil {
+			return err
+		}
+	}
+	if req.Dungeon != nil {
+		if err := req.Dungeon.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate algorithm name against the generators this service was built with
+	algorithm, err := maze.ParseAlgorithm(req.Algorithm)
+	if _, ok := s.generators[algorithm]; err != nil || !ok {
+		return unknownAlgorithmErrorf("algorithm must be one of: %s", strings.Join(s.algorithmNames(), ", "))
+	}
+
+	if req.Braid > 0 && !slices.Contains(maze.BraidAlgorithms, algorithm) {
+		return validationErrorf("braid is only supported by the %s algorithms", algorithmList(maze.BraidAlgorithms))
+	}
+
+	topology, err := maze.ParseTopology(req.Topology)
+	if err != nil {
+		return err
+	}
+	// Braiding and the other generators walk square neighbourhoods
+	switch topology {
+	case maze.TopologySquare:
+	case maze.TopologyTorus:
+		if !slices.Contains(maze.WrapAlgorithms, algorithm) {
+			return validationErrorf("torus topology is only supported by the %s algorithms", algorithmList(maze.WrapAlgorithms))
+		}
+	default:
+		if algorithm != maze.AlgorithmBacktracker || req.Braid > 0 {
+			return validationErrorf("%s topology is only supported by the backtracker algorithm without braid", topology)
+		}
+	}
+	// Keys are placed by walking square steps, which hex and polar tiles lack
+	if req.Keys > 0 && topology != maze.TopologySquare && topology != maze.TopologyTorus {
+		return validationErrorf("keys are only supported on square and torus mazes")
+	}
+	if req.Levels > 1 && (topology != maze.TopologySquare || algorithm != maze.AlgorithmBacktracker || req.Braid > 0) {
+		return validationErrorf("multi-level mazes are only supported by the backtracker algorithm on square tiles without braid")
 	}
 	return nil
 }
 
+// algorithmList joins algorithm names for an error message
+func algorithmList(algorithms []maze.Algorithm) string {
+	names := make([]string, len(algorithms))
+	for i, a := range algorithms {
+		names[i] = string(a)
+	}
+	return strings.Join(names, ", ")
+}
+
+// algorithmNames lists the generation algorithms this service can run
+func (s *MazeService) algorithmNames() []string {
+	var names []string
+	for _, algorithm := range maze.Algorithms {
+		if _, ok := s.generators[algorithm]; ok {
+			names = append(names, string(algorithm))
+		}
+	}
+	return names
+}
+
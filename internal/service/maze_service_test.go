@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "github.com/JoshuaPangaribuan/pathfinder/internal/errors"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+)
+
+func TestMazeService_BraidOnlyOnLatticeMazes(t *testing.T) {
+	svc := NewMazeServiceWithGenerators(maze.Generators(), log.NewNoOpLogger())
+	seed := int64(3)
+
+	cases := []struct {
+		algorithm string
+		valid     bool
+	}{
+		{"kruskal", true},
+		{"recursive-division", true},
+		{"eller", true},
+		{"cave", false},
+		{"dungeon", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.algorithm, func(t *testing.T) {
+			_, err := svc.GenerateMaze(context.Background(), GenerateMazeRequest{Width: 12, Height: 12, Seed: &seed, Algorithm: tc.algorithm, Braid: 0.5})
+			if tc.valid {
+				require.NoError(t, err)
+				return
+			}
+			var apiErr *apierrors.APIError
+			require.True(t, errors.As(err, &apiErr), "got %v", err)
+			assert.Equal(t, apierrors.ErrCodeValidation, apiErr.Code)
+			assert.Contains(t, apiErr.Message, "braid")
+		})
+	}
+}
+
+func TestMazeService_LevelsBounds(t *testing.T) {
+	svc := NewMazeServiceWithGenerators(maze.Generators(), log.NewNoOpLogger())
+	seed := int64(5)
+
+	cases := []struct {
+		levels int
+		valid  bool
+	}{
+		{-1, false},
+		{0, true},
+		{1, true},
+		{8, true},
+		{9, false},
+	}
+	for _, tc := range cases {
+		_, err := svc.GenerateMaze(context.Background(), GenerateMazeRequest{Width: 6, Height: 6, Seed: &seed, Levels: tc.levels})
+		if tc.valid {
+			require.NoError(t, err, "levels %d", tc.levels)
+			continue
+		}
+		var apiErr *apierrors.APIError
+		require.True(t, errors.As(err, &apiErr), "levels %d: got %v", tc.levels, err)
+		assert.Equal(t, apierrors.ErrCodeValidation, apiErr.Code)
+		assert.Equal(t, "levels must be between 0 and 8", apiErr.Message)
+	}
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+)
+
+// ErrPlannerSessionNotFound indicates a planner session that never existed,
+// was deleted or expired.
+var ErrPlannerSessionNotFound = errors.New("planner session not found")
+
+// Planner sessions keep a whole grid and its distances in memory, so their
+// number, the size of each and the squares they hold between them are all
+// bounded; sessions left idle expire.
+const (
+	maxPlannerSessions     = 256
+	maxPlannerSquares      = 65536
+	maxPlannerTotalSquares = 1 << 20
+	maxWallChanges         = 1024
+	plannerSessionTTL      = 30 * time.Minute
+)
+
+// CreatePlannerSessionRequest represents a request to start an incremental
+// planner between two squares of a grid
+type CreatePlannerSessionRequest struct {
+	Grid      maze.Grid
+	Start     maze.Point
+	Goal      maze.Point
+	Movement  string
+	Heuristic string
+	// Topology is the tile shape of Grid, square by default; polar grids are
+	// not supported
+	Topology string
+}
+
+// UpdatePlannerSessionRequest represents a batch of wall changes to replan
+// a session around
+type UpdatePlannerSessionRequest struct {
+	SessionID string
+	Changes   []algorithm.WallChange
+}
+
+// PlannerSessionResult contains the plan of a planner session. Plan counts
+// the work of this plan alone; RerunExpanded and RerunTouched count that of
+// planning the same grid from scratch, for comparison.
+type PlannerSessionResult struct {
+	SessionID     string
+	Plan          *algorithm.Replan
+	RerunExpanded int
+	RerunTouched  int
+	Elapsed       time.Duration
+}
+
+// plannerSession is a live planner and what it takes to plan its grid again
+// from scratch.
+type plannerSession struct {
+	mu       sync.Mutex
+	planner  *algorithm.DStarLite
+	start    maze.Point
+	goal     maze.Point
+	opts     []algorithm.Option
+	squares  int
+	lastUsed time.Time
+}
+
+// plannerSessions is the store of planner sessions by id, along with the
+// squares they hold between them.
+type plannerSessions struct {
+	mu       sync.Mutex
+	sessions map[string]*plannerSession
+	squares  int
+}
+
+func newPlannerSessions() *plannerSessions {
+	return &plannerSessions{sessions: make(map[string]*plannerSession)}
+}
+
+// add stores session under a fresh id, first dropping expired sessions and,
+// while the store is still full or would hold too many squares, the one idle
+// the longest.
+func (p *plannerSessions) add(session *plannerSession) (string, error) {
+	var raw [16]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(raw[:])
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	for key, s := range p.sessions {
+		if now.Sub(s.lastUsed) > plannerSessionTTL {
+			p.drop(key)
+		}
+	}
+	for len(p.sessions) > 0 && (len(p.sessions) >= maxPlannerSessions || p.squares+session.squares > maxPlannerTotalSquares) {
+		var oldest string
+		for key, s := range p.sessions {
+			if oldest == "" || s.lastUsed.Before(p.sessions[oldest].lastUsed) {
+				oldest = key
+			}
+		}
+		p.drop(oldest)
+	}
+	session.lastUsed = now
+	p.sessions[id] = session
+	p.squares += session.squares
+	return id, nil
+}
+
+// drop deletes the session with id, which must be present, and releases its
+// squares. The caller holds p.mu.
+func (p *plannerSessions) drop(id string) {
+	p.squares -= p.sessions[id].squares
+	delete(p.sessions, id)
+}
+
+// get returns the live session with id and marks it used.
+func (p *plannerSessions) get(id string) (*plannerSession, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	session, ok := p.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	now := time.Now()
+	if now.Sub(session.lastUsed) > plannerSessionTTL {
+		p.drop(id)
+		return nil, false
+	}
+	session.lastUsed = now
+	return session, true
+}
+
+// remove drops the session with id, reporting whether there was one.
+func (p *plannerSessions) remove(id string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.sessions[id]; !ok {
+		return false
+	}
+	p.drop(id)
+	return true
+}
+
+// CreatePlannerSession starts a D* Lite planner session for req and returns
+// its first plan
+func (s *SimulationService) CreatePlannerSession(ctx context.Context, req CreatePlannerSessionRequest) (PlannerSessionResult, error) {
+	s.logger.Info(ctx, "planner session requested",
+		log.Int("grid_height", len(req.Grid)),
+		log.Int("start_x", req.Start.X),
+		log.Int("start_y", req.Start.Y),
+		log.Int("goal_x", req.Goal.X),
+		log.Int("goal_y", req.Goal.Y),
+	)
+
+	if err := s.validatePlannerRequest(req); err != nil {
+		s.logger.Warn(ctx, "planner session validation failed", log.Error(err))
+		return PlannerSessionResult{}, err
+	}
+
+	opts := simulationOptions(RunSimulationRequest{Movement: req.Movement, Heuristic: req.Heuristic, Topology: req.Topology})
+	planner, err := algorithm.NewDStarLite(req.Grid, req.Start, req.Goal, opts...)
+	if err != nil {
+		s.logger.Error(ctx, "planner session failed", err)
+		return PlannerSessionResult{}, fmt.Errorf("planner session failed: %w", err)
+	}
+	began := time.Now()
+	plan := planner.Plan()
+	elapsed := time.Since(began)
+
+	squares := len(req.Grid) * len(req.Grid[0])
+	id, err := s.sessions.add(&plannerSession{planner: planner, start: req.Start, goal: req.Goal, opts: opts, squares: squares})
+	if err != nil {
+		s.logger.Error(ctx, "planner session failed", err)
+		return PlannerSessionResult{}, fmt.Errorf("planner session failed: %w", err)
+	}
+
+	s.logger.Info(ctx, "planner session created",
+		log.String("session_id", id),
+		log.Int("expanded", plan.Expanded),
+		log.Int("path_length", plan.PathLength),
+		log.Int64("elapsed_ms", elapsed.Milliseconds()),
+		log.Bool("found", plan.Found),
+	)
+
+	return PlannerSessionResult{
+		SessionID:     id,
+		Plan:          plan,
+		RerunExpanded: plan.Expanded,
+		RerunTouched:  plan.Touched,
+		Elapsed:       elapsed,
+	}, nil
+}
+
+// UpdatePlannerSession applies the wall changes of req to its session and
+// repairs the plan, comparing the work done with planning from scratch
+func (s *SimulationService) UpdatePlannerSession(ctx context.Context, req UpdatePlannerSessionRequest) (PlannerSessionResult, error) {
+	s.logger.Info(ctx, "replan requested",
+		log.String("session_id", req.SessionID),
+		log.Int("changes", len(req.Changes)),
+	)
+
+	if len(req.Changes) == 0 || len(req.Changes) > maxWallChanges {
+		err := validationErrorf("changes must number between 1 and %d", maxWallChanges)
+		s.logger.Warn(ctx, "replan validation failed", log.Error(err))
+		return PlannerSessionResult{}, err
+	}
+	session, ok := s.sessions.get(req.SessionID)
+	if !ok {
+		return PlannerSessionResult{}, ErrPlannerSessionNotFound
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	began := time.Now()
+	plan, err := session.planner.Update(req.Changes)
+	elapsed := time.Since(began)
+	if err != nil {
+		s.logger.Error(ctx, "replan failed", err, log.String("session_id", req.SessionID))
+		return PlannerSessionResult{}, fmt.Errorf("replan failed: %w", err)
+	}
+
+	fresh, err := algorithm.NewDStarLite(session.planner.Grid(), session.start, session.goal, session.opts...)
+	if err != nil {
+		s.logger.Error(ctx, "replan failed", err, log.String("session_id", req.SessionID))
+		return PlannerSessionResult{}, fmt.Errorf("replan failed: %w", err)
+	}
+	rerun := fresh.Plan()
+
+	s.logger.Info(ctx, "replan completed",
+		log.String("session_id", req.SessionID),
+		log.Int("expanded", plan.Expanded),
+		log.Int("touched", plan.Touched),
+		log.Int("rerun_expanded", rerun.Expanded),
+		log.Int("path_length", plan.PathLength),
+		log.Int64("elapsed_ms", elapsed.Milliseconds()),
+		log.Bool("found", plan.Found),
+	)
+
+	return PlannerSessionResult{
+		SessionID:     req.SessionID,
+		Plan:          plan,
+		RerunExpanded: rerun.Expanded,
+		RerunTouched:  rerun.Touched,
+		Elapsed:       elapsed,
+	}, nil
+}
+
+// DeletePlannerSession ends the planner session with id
+func (s *SimulationService) DeletePlannerSession(ctx context.Context, id string) error {
+	if !s.sessions.remove(id) {
+		return ErrPlannerSessionNotFound
+	}
+	s.logger.Info(ctx, "planner session deleted", log.String("session_id", id))
+	return nil
+}
+
+// validatePlannerRequest performs service-level validation of a planner session
+func (s *SimulationService) validatePlannerRequest(req CreatePlannerSessionRequest) error {
+	if _, err := algorithm.ParseMovement(req.Movement); err != nil {
+		return err
+	}
+	if _, err := algorithm.ParseHeuristic(req.Heuristic); err != nil {
+		return err
+	}
+	if _, err := maze.ParseTopology(req.Topology); err != nil {
+		return err
+	}
+	if len(req.Grid) == 0 {
+		return validationErrorf("grid must be non-empty")
+	}
+	width := len(req.Grid[0])
+	if width == 0 {
+		return validationErrorf("grid rows must be non-empty")
+	}
+	if len(req.Grid)*width > maxPlannerSquares {
+		return validationErrorf("grid must have at most %d squares", maxPlannerSquares)
+	}
+	for i, row := range req.Grid {
+		if len(row) != width {
+			return validationErrorf("grid has inconsistent dimensions: row %d has width %d, expected %d", i, len(row), width)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "github.com/JoshuaPangaribuan/pathfinder/internal/errors"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/simulation"
+)
+
+func TestSimulationService_PlannerGridValidation(t *testing.T) {
+	svc := NewSimulationService(simulation.NewRunner(), log.NewNoOpLogger())
+
+	cases := []struct {
+		name string
+		grid maze.Grid
+	}{
+		{"empty", maze.Grid{}},
+		{"empty rows", maze.Grid{{}, {}}},
+		{"ragged", maze.Grid{{0, 0, 0}, {0, 0}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.CreatePlannerSession(context.Background(), CreatePlannerSessionRequest{Grid: tc.grid, Goal: maze.Point{X: 1}})
+			var apiErr *apierrors.APIError
+			require.True(t, errors.As(err, &apiErr), "got %v", err)
+			assert.Equal(t, apierrors.ErrCodeValidation, apiErr.Code)
+			assert.Contains(t, apiErr.Message, "grid")
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -15,15 +16,17 @@ import (
 
 // SimulationService handles simulation business logic
 type SimulationService struct {
-	runner simulation.Runner
-	logger log.Logger
+	runner   simulation.Runner
+	logger   log.Logger
+	sessions *plannerSessions
 }
 
 // NewSimulationService creates a new simulation service
 func NewSimulationService(runner simulation.Runner, logger log.Logger) *SimulationService {
 	return &SimulationService{
-		runner: runner,
-		logger: logger,
+		runner:   runner,
+		logger:   logger,
+		sessions: newPlannerSessions(),
 	}
 }
 
@@ -33,12 +36,43 @@ type RunSimulationRequest struct {
 	Grid      maze.Grid
 	Start     maze.Point
 	Goal      maze.Point
+	Costs     maze.CostGrid
+	Movement  string
+	Heuristic string
+	Weight    *float64
+	// Topology is the tile shape of Grid, square by default
+	Topology string
+	// Floors are stacked above Grid, the ground floor, to make a multi-level
+	// maze, and Stairs join a square on floor Z to the same square on floor Z+1
+	Floors []maze.Grid
+	Stairs []maze.Point
+	// PortalCost is what jumping between the two ends of a portal in Grid
+	// costs, 1 by default
+	PortalCost *float64
+	// WallBreaks is how many walls the path may break through, 0 by default
+	WallBreaks int
+	// Obstacles move over the grid one trajectory point per time step; the
+	// path may wait in place and must never run into one
+	Obstacles []algorithm.Obstacle
 }
 
+// maxWallBreaks bounds WallBreaks; every extra break multiplies the states a
+// search may visit
+const maxWallBreaks = 10
+
+// Moving obstacles are bounded so their frames stay small enough to animate.
+const (
+	maxObstacles          = 32
+	maxObstacleTrajectory = 256
+)
+
 // RunSimulationResult contains the result of a simulation
 type RunSimulationResult struct {
 	Result  *algorithm.Result
 	Elapsed time.Duration
+	// BreakProfile holds, when walls may be broken, the outcome of the same
+	// search for every budget from 0 up to WallBreaks
+	BreakProfile []algorithm.BreakBudget
 }
 
 // RunSimulation runs a pathfinding simulation with service-level validation and error handling
@@ -69,7 +103,7 @@ func (s *SimulationService) RunSimulation(ctx context.Context, req RunSimulation
 	}
 
 	// Business logic, logging, metrics can go here
-	result, elapsed, err := s.runner.Run(ctx, req.Algorithm, req.Grid, req.Start, req.Goal)
+	result, elapsed, err := s.runner.Run(ctx, req.Algorithm, req.Grid, req.Start, req.Goal, simulationOptions(req)...)
 	if err != nil {
 		s.logger.Error(ctx, "simulation failed", err,
 			log.String("algorithm", req.Algorithm),
@@ -80,7 +114,99 @@ func (s *SimulationService) RunSimulation(ctx context.Context, req RunSimulation
 		return RunSimulationResult{}, fmt.Errorf("simulation failed: %w", err)
 	}
 
+	profile, err := s.breakProfile(ctx, req, result)
+	if err != nil {
+		s.logger.Error(ctx, "simulation break profile failed", err,
+			log.String("algorithm", req.Algorithm),
+		)
+		return RunSimulationResult{}, fmt.Errorf("simulation failed: %w", err)
+	}
+
 	s.logger.Info(ctx, "simulation completed",
+		log.String("algorithm", req.Algorithm),
+		log.Int("expanded_nodes", result.ExpandedNodes),
+		log.Int("path_length", result.PathLength),
+		log.Float64("path_cost", result.PathCost),
+		log.Int64("elapsed_ms", elapsed.Milliseconds()),
+		log.Bool("found", result.Found),
+	)
+
+	return RunSimulationResult{
+		Result:       result,
+		Elapsed:      elapsed,
+		BreakProfile: profile,
+	}, nil
+}
+
+// breakProfile reruns the search of req for every wall break budget below
+// req.WallBreaks and lists the outcomes, ending with result, the outcome of the
+// full budget. It returns nil when no walls may be broken.
+func (s *SimulationService) breakProfile(ctx context.Context, req RunSimulationRequest, result *algorithm.Result) ([]algorithm.BreakBudget, error) {
+	if req.WallBreaks == 0 {
+		return nil, nil
+	}
+	profile := make([]algorithm.BreakBudget, 0, req.WallBreaks+1)
+	for k := 0; k < req.WallBreaks; k++ {
+		budget := req
+		budget.WallBreaks = k
+		r, _, err := s.runner.Run(ctx, req.Algorithm, req.Grid, req.Start, req.Goal, simulationOptions(budget)...)
+		if err != nil {
+			return nil, err
+		}
+		profile = append(profile, newBreakBudget(k, r))
+	}
+	return append(profile, newBreakBudget(req.WallBreaks, result)), nil
+}
+
+func newBreakBudget(k int, result *algorithm.Result) algorithm.BreakBudget {
+	return algorithm.BreakBudget{
+		Breaks:     k,
+		Found:      result.Found,
+		PathLength: result.PathLength,
+		PathCost:   result.PathCost,
+	}
+}
+
+// StreamSimulation runs a pathfinding simulation step by step, handing every search
+// event to emit as it happens. It applies the same validation as RunSimulation and
+// stops early when ctx is cancelled or emit fails.
+func (s *SimulationService) StreamSimulation(ctx context.Context, req RunSimulationRequest, emit simulation.EmitFunc) (RunSimulationResult, error) {
+	s.logger.Info(ctx, "simulation stream requested",
+		log.String("algorithm", req.Algorithm),
+		log.Int("grid_height", len(req.Grid)),
+	)
+
+	if err := s.validateRequest(req); err != nil {
+		s.logger.Warn(ctx, "simulation stream validation failed",
+			log.Error(err),
+			log.String("algorithm", req.Algorithm),
+		)
+		return RunSimulationResult{}, err
+	}
+
+	result, elapsed, err := s.runner.Stream(ctx, req.Algorithm, req.Grid, req.Start, req.Goal, emit, simulationOptions(req)...)
+	if err != nil {
+		if ctx.Err() != nil {
+			s.logger.Info(ctx, "simulation stream cancelled",
+				log.String("algorithm", req.Algorithm),
+			)
+		} else {
+			s.logger.Error(ctx, "simulation stream failed", err,
+				log.String("algorithm", req.Algorithm),
+			)
+		}
+		return RunSimulationResult{}, fmt.Errorf("simulation failed: %w", err)
+	}
+
+	profile, err := s.breakProfile(ctx, req, result)
+	if err != nil {
+		s.logger.Error(ctx, "simulation stream break profile failed", err,
+			log.String("algorithm", req.Algorithm),
+		)
+		return RunSimulationResult{}, fmt.Errorf("simulation failed: %w", err)
+	}
+
+	s.logger.Info(ctx, "simulation stream completed",
 		log.String("algorithm", req.Algorithm),
 		log.Int("expanded_nodes", result.ExpandedNodes),
 		log.Int("path_length", result.PathLength),
@@ -89,39 +215,348 @@ func (s *SimulationService) RunSimulation(ctx context.Context, req RunSimulation
 	)
 
 	return RunSimulationResult{
+		Result:       result,
+		Elapsed:      elapsed,
+		BreakProfile: profile,
+	}, nil
+}
+
+// World searches are bounded because the world never runs out of cells to expand.
+const (
+	defaultWorldExpansions = 200000
+	maxWorldExpansions     = 1000000
+)
+
+// SolveWorldRequest represents a request to find a path through an infinite world
+type SolveWorldRequest struct {
+	Seed int64
+	// ChunkSize is the chunk side in cells; zero selects maze.DefaultChunkSize
+	ChunkSize int
+	Start     maze.Point
+	Goal      maze.Point
+	Movement  string
+	Heuristic string
+	Weight    *float64
+	// MaxExpansions bounds the search; zero selects the default limit
+	MaxExpansions int
+}
+
+// SolveWorldResult contains the result of a world search and the chunks it had to load
+type SolveWorldResult struct {
+	Result  *algorithm.Result
+	Chunks  []maze.ChunkCoord
+	Elapsed time.Duration
+}
+
+// SolveWorld runs A* across an infinite world, generating chunks only as the search reaches them
+func (s *SimulationService) SolveWorld(ctx context.Context, req SolveWorldRequest) (SolveWorldResult, error) {
+	s.logger.Info(ctx, "world search requested",
+		log.Int("chunk_size", req.ChunkSize),
+		log.Int("start_x", req.Start.X),
+		log.Int("start_y", req.Start.Y),
+		log.Int("goal_x", req.Goal.X),
+		log.Int("goal_y", req.Goal.Y),
+	)
+
+	chunkSize := req.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = maze.DefaultChunkSize
+	}
+	limit := req.MaxExpansions
+	if limit == 0 {
+		limit = defaultWorldExpansions
+	}
+	if err := s.validateWorldRequest(req, limit); err != nil {
+		s.logger.Warn(ctx, "world search validation failed", log.Error(err))
+		return SolveWorldResult{}, err
+	}
+	world, err := maze.NewWorld(req.Seed, chunkSize)
+	if err != nil {
+		s.logger.Warn(ctx, "world search validation failed", log.Error(err))
+		return SolveWorldResult{}, err
+	}
+
+	cache := maze.NewChunkCache(world)
+	opts := simulationOptions(RunSimulationRequest{Movement: req.Movement, Heuristic: req.Heuristic, Weight: req.Weight})
+	began := time.Now()
+	result, err := algorithm.TerrainAStar(ctx, cache, req.Start, req.Goal, limit, opts...)
+	elapsed := time.Since(began)
+	if err != nil {
+		if errors.Is(err, algorithm.ErrBlocked) {
+			return SolveWorldResult{}, err
+		}
+		s.logger.Error(ctx, "world search failed", err)
+		return SolveWorldResult{}, fmt.Errorf("world search failed: %w", err)
+	}
+
+	s.logger.Info(ctx, "world search completed",
+		log.Int("expanded_nodes", result.ExpandedNodes),
+		log.Int("path_length", result.PathLength),
+		log.Int("chunks_loaded", len(cache.Loaded())),
+		log.Int64("elapsed_ms", elapsed.Milliseconds()),
+		log.Bool("found", result.Found),
+	)
+
+	return SolveWorldResult{
+		Result:  result,
+		Chunks:  cache.Loaded(),
+		Elapsed: elapsed,
+	}, nil
+}
+
+// validateWorldRequest performs service-level validation of a world search
+func (s *SimulationService) validateWorldRequest(req SolveWorldRequest, limit int) error {
+	if limit < 1 || limit > maxWorldExpansions {
+		return validationErrorf("maxExpansions must be between 1 and %d", maxWorldExpansions)
+	}
+	if _, err := algorithm.ParseMovement(req.Movement); err != nil {
+		return err
+	}
+	if _, err := algorithm.ParseHeuristic(req.Heuristic); err != nil {
+		return err
+	}
+	if req.Weight != nil && (*req.Weight < 0 || math.IsNaN(*req.Weight) || math.IsInf(*req.Weight, 0)) {
+		return algorithm.ErrInvalidWeight
+	}
+	return nil
+}
+
+// Multi-agent searches are bounded because agents that cannot all get past each
+// other keep the constraint tree growing forever.
+const (
+	defaultMultiExpansions = 1000
+	maxMultiExpansions     = 20000
+	maxAgents              = 16
+)
+
+// SolveMultiRequest represents a request to plan collision-free paths for
+// several agents sharing a grid
+type SolveMultiRequest struct {
+	Grid     maze.Grid
+	Agents   []algorithm.Agent
+	Movement string
+	// Topology is the tile shape of Grid, square by default
+	Topology string
+	// MaxExpansions bounds the constraint tree nodes expanded; zero selects
+	// the default limit
+	MaxExpansions int
+}
+
+// SolveMultiResult contains the result of a multi-agent search
+type SolveMultiResult struct {
+	Result  *algorithm.MultiResult
+	Elapsed time.Duration
+}
+
+// SolveMulti runs Conflict-Based Search for the agents of req, returning the
+// best partial solution when the expansion limit is reached
+func (s *SimulationService) SolveMulti(ctx context.Context, req SolveMultiRequest) (SolveMultiResult, error) {
+	s.logger.Info(ctx, "multi-agent search requested",
+		log.Int("grid_height", len(req.Grid)),
+		log.Int("agents", len(req.Agents)),
+	)
+
+	limit := req.MaxExpansions
+	if limit == 0 {
+		limit = defaultMultiExpansions
+	}
+	if err := s.validateMultiRequest(req, limit); err != nil {
+		s.logger.Warn(ctx, "multi-agent search validation failed", log.Error(err))
+		return SolveMultiResult{}, err
+	}
+
+	opts := simulationOptions(RunSimulationRequest{Movement: req.Movement, Topology: req.Topology})
+	began := time.Now()
+	result, err := algorithm.CBS(ctx, req.Grid, req.Agents, limit, opts...)
+	elapsed := time.Since(began)
+	if err != nil {
+		s.logger.Error(ctx, "multi-agent search failed", err,
+			log.Int("agents", len(req.Agents)),
+		)
+		return SolveMultiResult{}, fmt.Errorf("multi-agent search failed: %w", err)
+	}
+
+	s.logger.Info(ctx, "multi-agent search completed",
+		log.Int("expanded_nodes", result.ExpandedNodes),
+		log.Int("low_level_expansions", result.LowLevelExpansions),
+		log.Int("sum_of_costs", result.SumOfCosts),
+		log.Int("makespan", result.Makespan),
+		log.Int64("elapsed_ms", elapsed.Milliseconds()),
+		log.Bool("found", result.Found),
+		log.Bool("limit_reached", result.LimitReached),
+	)
+
+	return SolveMultiResult{
 		Result:  result,
 		Elapsed: elapsed,
 	}, nil
 }
 
+// validateMultiRequest performs service-level validation of a multi-agent search
+func (s *SimulationService) validateMultiRequest(req SolveMultiRequest, limit int) error {
+	if limit < 1 || limit > maxMultiExpansions {
+		return validationErrorf("maxExpansions must be between 1 and %d", maxMultiExpansions)
+	}
+	if len(req.Agents) == 0 || len(req.Agents) > maxAgents {
+		return validationErrorf("agents must number between 1 and %d", maxAgents)
+	}
+	if _, err := algorithm.ParseMovement(req.Movement); err != nil {
+		return err
+	}
+	topology, err := maze.ParseTopology(req.Topology)
+	if err != nil {
+		return err
+	}
+	if len(req.Grid) == 0 {
+		return validationErrorf("grid must be non-empty")
+	}
+	if len(req.Grid[0]) == 0 {
+		return validationErrorf("grid rows must be non-empty")
+	}
+	width := len(req.Grid[0])
+	if topology != maze.TopologyPolar {
+		for i, row := range req.Grid {
+			if len(row) != width {
+				return validationErrorf("grid has inconsistent dimensions: row %d has width %d, expected %d", i, len(row), width)
+			}
+		}
+	}
+	return nil
+}
+
+// simulationOptions translates the optional request fields into solver options
+func simulationOptions(req RunSimulationRequest) []algorithm.Option {
+	var opts []algorithm.Option
+	if req.Costs != nil {
+		opts = append(opts, algorithm.WithCosts(req.Costs))
+	}
+	if movement, err := algorithm.ParseMovement(req.Movement); err == nil {
+		opts = append(opts, algorithm.WithMovement(movement))
+	}
+	if heuristic, err := algorithm.ParseHeuristic(req.Heuristic); err == nil && heuristic != "" {
+		opts = append(opts, algorithm.WithHeuristic(heuristic))
+	}
+	if req.Weight != nil {
+		opts = append(opts, algorithm.WithWeight(*req.Weight))
+	}
+	if topology, err := maze.ParseTopology(req.Topology); err == nil {
+		opts = append(opts, algorithm.WithTopology(topology))
+	}
+	if req.Floors != nil || req.Stairs != nil {
+		opts = append(opts, algorithm.WithFloors(req.Floors, req.Stairs))
+	}
+	if req.PortalCost != nil {
+		opts = append(opts, algorithm.WithPortalCost(*req.PortalCost))
+	}
+	if req.WallBreaks > 0 {
+		opts = append(opts, algorithm.WithWallBreaks(req.WallBreaks))
+	}
+	if len(req.Obstacles) > 0 {
+		opts = append(opts, algorithm.WithObstacles(req.Obstacles))
+	}
+	return opts
+}
+
 // validateRequest performs service-level validation
 func (s *SimulationService) validateRequest(req RunSimulationRequest) error {
 	if req.Algorithm == "" {
-		return errors.New("algorithm is required")
+		return validationErrorf("algorithm is required")
+	}
+
+	// Validate algorithm name against the solvers the runner knows about
+	if !s.supportsAlgorithm(req.Algorithm) {
+		return unknownAlgorithmErrorf("algorithm must be one of: %s", strings.Join(s.algorithmKeys(), ", "))
+	}
+
+	if _, err := algorithm.ParseMovement(req.Movement); err != nil {
+		return err
+	}
+
+	if _, err := algorithm.ParseHeuristic(req.Heuristic); err != nil {
+		return err
+	}
+
+	if req.Weight != nil && (*req.Weight < 0 || math.IsNaN(*req.Weight) || math.IsInf(*req.Weight, 0)) {
+		return algorithm.ErrInvalidWeight
 	}
 
-	// Validate algorithm name
-	algoLower := strings.ToLower(req.Algorithm)
-	if algoLower != "bfs" && algoLower != "dfs" && algoLower != "astar" && algoLower != "a*" {
-		return errors.New("algorithm must be one of: bfs, dfs, astar, a*")
+	if req.PortalCost != nil && (*req.PortalCost < 0 || math.IsNaN(*req.PortalCost) || math.IsInf(*req.PortalCost, 0)) {
+		return algorithm.ErrInvalidPortalCost
+	}
+
+	if req.WallBreaks < 0 || req.WallBreaks > maxWallBreaks {
+		return validationErrorf("wall breaks must be between 0 and %d", maxWallBreaks)
+	}
+
+	if len(req.Obstacles) > maxObstacles {
+		return validationErrorf("obstacles must number at most %d", maxObstacles)
+	}
+	for i, o := range req.Obstacles {
+		if len(o.Trajectory) == 0 || len(o.Trajectory) > maxObstacleTrajectory {
+			return validationErrorf("obstacles must have 1 to %d trajectory points: obstacle %d has %d", maxObstacleTrajectory, i, len(o.Trajectory))
+		}
+	}
+
+	topology, err := maze.ParseTopology(req.Topology)
+	if err != nil {
+		return err
 	}
 
 	// Validate grid structure
 	if len(req.Grid) == 0 {
-		return errors.New("grid must be non-empty")
+		return validationErrorf("grid must be non-empty")
 	}
 	if len(req.Grid[0]) == 0 {
-		return errors.New("grid rows must be non-empty")
+		return validationErrorf("grid rows must be non-empty")
 	}
 
-	// Validate grid dimensions consistency
+	// Validate grid dimensions consistency; polar rings grow outwards, so
+	// their rows differ in length and the solvers check their shape
 	width := len(req.Grid[0])
-	for i, row := range req.Grid {
-		if len(row) != width {
-			return fmt.Errorf("grid has inconsistent dimensions: row %d has width %d, expected %d", i, len(row), width)
+	if topology != maze.TopologyPolar {
+		for i, row := range req.Grid {
+			if len(row) != width {
+				return validationErrorf("grid has inconsistent dimensions: row %d has width %d, expected %d", i, len(row), width)
+			}
+		}
+	}
+
+	// Validate cost grid shape; value checks happen in the solvers
+	if req.Costs != nil {
+		if len(req.Costs) != len(req.Grid) {
+			return validationErrorf("costs must have %d rows, got %d", len(req.Grid), len(req.Costs))
+		}
+		for i, row := range req.Costs {
+			if len(row) != len(req.Grid[i]) {
+				return validationErrorf("costs must match grid dimensions: row %d has width %d, expected %d", i, len(row), len(req.Grid[i]))
+			}
 		}
 	}
 
 	return nil
 }
 
+// ListAlgorithms returns the metadata of every algorithm that can be simulated
+func (s *SimulationService) ListAlgorithms(ctx context.Context) []algorithm.Info {
+	return s.runner.Algorithms()
+}
+
+// supportsAlgorithm reports whether name matches a solver name or alias
+func (s *SimulationService) supportsAlgorithm(name string) bool {
+	for _, key := range s.algorithmKeys() {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// algorithmKeys lists every accepted algorithm name and alias
+func (s *SimulationService) algorithmKeys() []string {
+	var keys []string
+	for _, info := range s.runner.Algorithms() {
+		keys = append(keys, info.Name)
+		keys = append(keys, info.Aliases...)
+	}
+	return keys
+}
@@ -3,7 +3,6 @@ package simulation
 import (
 	"context"
 	"errors"
-	"strings"
 	"time"
 
 	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
@@ -17,49 +16,93 @@ var (
 
 // Runner defines the interface for pathfinding simulation services
 type Runner interface {
-	Run(ctx context.Context, algorithm string, grid maze.Grid, start, goal maze.Point) (*algorithm.Result, time.Duration, error)
+	Run(ctx context.Context, algorithm string, grid maze.Grid, start, goal maze.Point, opts ...algorithm.Option) (*algorithm.Result, time.Duration, error)
+	Stream(ctx context.Context, algorithm string, grid maze.Grid, start, goal maze.Point, emit EmitFunc, opts ...algorithm.Option) (*algorithm.Result, time.Duration, error)
+	Algorithms() []algorithm.Info
 }
 
-// DefaultRunner implements Runner
+// EmitFunc receives search events as a streamed run produces them. Returning an
+// error stops the run. Because the search waits for emit to return, a slow
+// consumer naturally throttles the producer.
+type EmitFunc func(algorithm.Event) error
+
+// DefaultRunner implements Runner by looking solvers up in an algorithm registry
 type DefaultRunner struct {
-	// Future: can add logger, metrics, algorithm registry, etc.
+	registry *algorithm.Registry
 }
 
-// NewRunner creates a new default simulation runner
+// NewRunner creates a simulation runner backed by the built-in solvers
 func NewRunner() Runner {
-	return &DefaultRunner{}
+	return NewRunnerWithRegistry(algorithm.NewDefaultRegistry())
 }
 
-type solverFunc func(maze.Grid, maze.Point, maze.Point) (*algorithm.Result, error)
+// NewRunnerWithRegistry creates a simulation runner that resolves algorithms in registry
+func NewRunnerWithRegistry(registry *algorithm.Registry) Runner {
+	return &DefaultRunner{registry: registry}
+}
 
 // Run executes the requested algorithm and returns its result along with timing information.
-func (r *DefaultRunner) Run(ctx context.Context, algo string, grid maze.Grid, start, goal maze.Point) (*algorithm.Result, time.Duration, error) {
+func (r *DefaultRunner) Run(ctx context.Context, algo string, grid maze.Grid, start, goal maze.Point, opts ...algorithm.Option) (*algorithm.Result, time.Duration, error) {
 	// Check context cancellation
 	if err := ctx.Err(); err != nil {
 		return nil, 0, err
 	}
 
-	solver, err := selectSolver(algo)
-	if err != nil {
-		return nil, 0, err
+	solver, ok := r.registry.Lookup(algo)
+	if !ok {
+		return nil, 0, ErrUnknownAlgorithm
 	}
 
 	began := time.Now()
-	result, err := solver(grid, start, goal)
+	result, err := solver.Solve(grid, start, goal, opts...)
 	elapsed := time.Since(began)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	return result, elapsed, err
+	return result, elapsed, nil
 }
 
-func selectSolver(algo string) (solverFunc, error) {
-	switch strings.ToLower(algo) {
-	case "bfs":
-		return algorithm.BFS, nil
-	case "dfs":
-		return algorithm.DFS, nil
-	case "astar", "a*":
-		return algorithm.AStar, nil
-	default:
-		return nil, ErrUnknownAlgorithm
+// Stream executes the requested algorithm step by step and hands every event to emit.
+// The run stops as soon as ctx is cancelled or emit fails. The reported duration only
+// covers time spent searching, not time spent waiting on emit.
+func (r *DefaultRunner) Stream(ctx context.Context, algo string, grid maze.Grid, start, goal maze.Point, emit EmitFunc, opts ...algorithm.Option) (*algorithm.Result, time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	solver, ok := r.registry.Lookup(algo)
+	if !ok {
+		return nil, 0, ErrUnknownAlgorithm
 	}
+
+	search, err := solver.Search(grid, start, goal, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var elapsed time.Duration
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+
+		began := time.Now()
+		ev, ok := search.Step()
+		elapsed += time.Since(began)
+		if !ok {
+			break
+		}
+
+		if err := emit(ev); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return search.Result(), elapsed, nil
+}
+
+// Algorithms lists the metadata of every solver the runner can execute.
+func (r *DefaultRunner) Algorithms() []algorithm.Info {
+	return r.registry.List()
 }
@@ -2,6 +2,7 @@ package simulation
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -215,3 +216,105 @@ func TestDefaultRunner_Run_AllAlgorithms(t *testing.T) {
 	}
 }
 
+func TestDefaultRunner_Run_DijkstraWithCosts(t *testing.T) {
+	runner := NewRunner()
+	ctx := context.Background()
+	grid := createTestGrid()
+	costs := make(maze.CostGrid, len(grid))
+	for y := range costs {
+		costs[y] = []float64{1, 1, 1, 1, 1}
+	}
+	costs[0][1] = 20
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 0}
+
+	result, _, err := runner.Run(ctx, "dijkstra", grid, start, goal, algorithm.WithCosts(costs))
+	require.NoError(t, err)
+	assert.True(t, result.Found)
+	assert.Equal(t, 4.0, result.PathCost)
+}
+
+func TestDefaultRunner_Algorithms(t *testing.T) {
+	runner := NewRunner()
+
+	infos := runner.Algorithms()
+	require.NotEmpty(t, infos)
+	assert.Equal(t, "bfs", infos[0].Name)
+}
+
+func TestNewRunnerWithRegistry(t *testing.T) {
+	registry := algorithm.NewRegistry()
+	registry.MustRegister(algorithm.NewSolver(algorithm.Info{Name: "only-bfs"}, algorithm.NewBFSSearch))
+	runner := NewRunnerWithRegistry(registry)
+	ctx := context.Background()
+	grid := createTestGrid()
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 4, Y: 4}
+
+	result, _, err := runner.Run(ctx, "only-bfs", grid, start, goal)
+	require.NoError(t, err)
+	assert.True(t, result.Found)
+
+	_, _, err = runner.Run(ctx, "astar", grid, start, goal)
+	assert.ErrorIs(t, err, ErrUnknownAlgorithm)
+}
+
+func TestDefaultRunner_Stream_MatchesRun(t *testing.T) {
+	runner := NewRunner()
+	ctx := context.Background()
+	grid := createTestGrid()
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 4, Y: 4}
+
+	var pops []maze.Point
+	result, _, err := runner.Stream(ctx, "astar", grid, start, goal, func(ev algorithm.Event) error {
+		if ev.Kind == algorithm.EventPop {
+			pops = append(pops, ev.Point)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	want, _, err := runner.Run(ctx, "astar", grid, start, goal)
+	require.NoError(t, err)
+	assert.Equal(t, want, result)
+	assert.Equal(t, want.VisitedOrder, pops)
+}
+
+func TestDefaultRunner_Stream_StopsWhenEmitFails(t *testing.T) {
+	runner := NewRunner()
+	ctx := context.Background()
+	grid := createTestGrid()
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 4, Y: 4}
+	errStop := errors.New("client gone")
+
+	calls := 0
+	result, _, err := runner.Stream(ctx, "bfs", grid, start, goal, func(algorithm.Event) error {
+		calls++
+		if calls == 3 {
+			return errStop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Nil(t, result)
+	assert.Equal(t, 3, calls)
+}
+
+func TestDefaultRunner_Stream_Cancellation(t *testing.T) {
+	runner := NewRunner()
+	ctx, cancel := context.WithCancel(context.Background())
+	grid := createTestGrid()
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 4, Y: 4}
+
+	calls := 0
+	_, _, err := runner.Stream(ctx, "bfs", grid, start, goal, func(algorithm.Event) error {
+		calls++
+		cancel()
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
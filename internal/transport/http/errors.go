@@ -3,16 +3,47 @@ package httptransport
 import (
 	"errors"
 	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 
 	apierrors "github.com/JoshuaPangaribuan/pathfinder/internal/errors"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/service"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/simulation"
 )
 
+// validationErrors are the domain errors that mean the request itself was
+// invalid, reported as 400 VALIDATION_ERROR
+var validationErrors = []error{
+	maze.ErrInvalidBraid,
+	maze.ErrInvalidCaveConfig,
+	maze.ErrInvalidDungeonConfig,
+	maze.ErrInvalidChunkSize,
+	maze.ErrUnknownTopology,
+	maze.ErrInvalidPolarGrid,
+	maze.ErrInvalidFloors,
+	maze.ErrInvalidPortals,
+	algorithm.ErrUnsupportedTopology,
+	algorithm.ErrUnsupportedFloors,
+	algorithm.ErrInvalidPortalCost,
+	algorithm.ErrUnsupportedKeys,
+	algorithm.ErrUnsupportedWallBreaks,
+	algorithm.ErrInvalidWallBreaks,
+	algorithm.ErrUnsupportedObstacles,
+	algorithm.ErrInvalidObstacles,
+	algorithm.ErrObstacleCycle,
+	algorithm.ErrInvalidAgents,
+	algorithm.ErrUnsupportedReplanning,
+	algorithm.ErrInadmissibleHeuristic,
+	algorithm.ErrUnknownMovement,
+	algorithm.ErrUnknownHeuristic,
+	algorithm.ErrInvalidWeight,
+	algorithm.ErrInvalidCosts,
+}
+
 // handleError handles errors and maps them to appropriate HTTP responses
 func (h *Handler) handleError(c *gin.Context, err error) {
 	var apiErr *apierrors.APIError
@@ -22,54 +53,38 @@ func (h *Handler) handleError(c *gin.Context, err error) {
 		return
 	}
 
-	// Handle domain errors
-	if err == maze.ErrInvalidDimensions {
+	// Handle domain errors, which the service may have wrapped
+	if errors.Is(err, service.ErrPlannerSessionNotFound) {
+		apiErr := apierrors.NewNotFoundError(err.Error())
+		c.JSON(http.StatusNotFound, apiErr)
+		return
+	}
+	if errors.Is(err, maze.ErrInvalidDimensions) || errors.Is(err, maze.ErrInvalidTorusDimensions) {
 		apiErr := apierrors.NewInvalidDimensionsError(err.Error())
 		c.JSON(http.StatusBadRequest, apiErr)
 		return
 	}
-	if err == simulation.ErrUnknownAlgorithm {
+	if errors.Is(err, simulation.ErrUnknownAlgorithm) || errors.Is(err, maze.ErrUnknownAlgorithm) {
 		apiErr := apierrors.NewUnknownAlgorithmError(err.Error())
 		c.JSON(http.StatusBadRequest, apiErr)
 		return
 	}
-	if err == algorithm.ErrOutOfBounds {
+	if errors.Is(err, algorithm.ErrOutOfBounds) {
 		apiErr := apierrors.NewOutOfBoundsError(err.Error())
 		c.JSON(http.StatusBadRequest, apiErr)
 		return
 	}
-	if err == algorithm.ErrBlocked {
+	if errors.Is(err, algorithm.ErrBlocked) {
 		apiErr := apierrors.NewBlockedError(err.Error())
 		c.JSON(http.StatusBadRequest, apiErr)
 		return
 	}
-
-	// Handle wrapped errors
-	errStr := err.Error()
-	if strings.Contains(errStr, "dimensions must be") {
-		apiErr := apierrors.NewInvalidDimensionsError(errStr)
-		c.JSON(http.StatusBadRequest, apiErr)
-		return
-	}
-	if strings.Contains(errStr, "algorithm must be") {
-		apiErr := apierrors.NewUnknownAlgorithmError(errStr)
-		c.JSON(http.StatusBadRequest, apiErr)
-		return
-	}
-	if strings.Contains(errStr, "grid must be") {
-		apiErr := apierrors.NewValidationError(errStr)
-		c.JSON(http.StatusBadRequest, apiErr)
-		return
-	}
-	if strings.Contains(errStr, "out of bounds") || strings.Contains(errStr, "outside grid bounds") {
-		apiErr := apierrors.NewOutOfBoundsError(errStr)
-		c.JSON(http.StatusBadRequest, apiErr)
-		return
-	}
-	if strings.Contains(errStr, "blocked") {
-		apiErr := apierrors.NewBlockedError(errStr)
-		c.JSON(http.StatusBadRequest, apiErr)
-		return
+	for _, target := range validationErrors {
+		if errors.Is(err, target) {
+			apiErr := apierrors.NewValidationError(err.Error())
+			c.JSON(http.StatusBadRequest, apiErr)
+			return
+		}
 	}
 
 	// Default to internal error
@@ -77,6 +92,19 @@ func (h *Handler) handleError(c *gin.Context, err error) {
 	c.JSON(http.StatusInternalServerError, apiErr)
 }
 
+// bindError answers a request whose URI, query or body failed to bind.
+func (h *Handler) bindError(c *gin.Context, msg string, err error) {
+	h.logger.Warn(c.Request.Context(), msg, log.Error(err))
+	if fieldErrors, ok := err.(validator.ValidationErrors); ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation failed",
+			"details": formatValidationErrors(fieldErrors),
+		})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
 // mapErrorToStatusCode maps error codes to HTTP status codes
 func mapErrorToStatusCode(code apierrors.ErrorCode) int {
 	switch code {
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 
+	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/service"
@@ -30,35 +31,199 @@ func NewHandler(mazeService service.MazeServiceInterface, simService service.Sim
 }
 
 type generateRequest struct {
-	Width  int    `json:"width" binding:"required,min=2,max=100"`
-	Height int    `json:"height" binding:"required,min=2,max=100"`
-	Seed   *int64 `json:"seed"`
+	Width     int    `json:"width" binding:"required,min=2,max=100"`
+	Height    int    `json:"height" binding:"required,min=2,max=100"`
+	Seed      *int64 `json:"seed"`
+	Algorithm string `json:"algorithm"`
+	// Topology is "square" (default), "hex", "polar" or "torus". Polar mazes
+	// have Height rings around a centre, the innermost of which has Width
+	// cells; torus mazes wrap around their edges.
+	Topology string `json:"topology"`
+	// Levels above 1 stack that many square floors joined by stairs.
+	Levels int     `json:"levels" binding:"min=0,max=8"`
+	Braid  float64 `json:"braid" binding:"min=0,max=1"`
+	// Portals drops that many portal pairs into the maze, numbered from 2 in
+	// the grid.
+	Portals int `json:"portals" binding:"min=0,max=10"`
+	// Keys locks that many coloured doors, each with its key; see maze.MaxKeys
+	// for how they are stored in the grid.
+	Keys int `json:"keys" binding:"min=0,max=8"`
+	// Cave tunes the cave algorithm; omitted fields keep their defaults.
+	Cave *caveOptions `json:"cave"`
+	// Dungeon tunes the dungeon algorithm; omitted fields keep their defaults.
+	Dungeon *dungeonOptions `json:"dungeon"`
+	// Events asks for the carve/wall steps so clients can animate generation.
+	Events bool `json:"events"`
+}
+
+type caveOptions struct {
+	Fill          *float64 `json:"fill"`
+	Passes        *int     `json:"passes"`
+	BirthLimit    *int     `json:"birthLimit"`
+	SurvivalLimit *int     `json:"survivalLimit"`
+	Connectivity  string   `json:"connectivity"`
+}
+
+// config applies the provided options on top of the default cave settings.
+func (o *caveOptions) config() *maze.CaveConfig {
+	if o == nil {
+		return nil
+	}
+	cfg := maze.DefaultCaveConfig()
+	if o.Fill != nil {
+		cfg.FillProbability = *o.Fill
+	}
+	if o.Passes != nil {
+		cfg.SmoothingPasses = *o.Passes
+	}
+	if o.BirthLimit != nil {
+		cfg.BirthLimit = *o.BirthLimit
+	}
+	if o.SurvivalLimit != nil {
+		cfg.SurvivalLimit = *o.SurvivalLimit
+	}
+	if o.Connectivity != "" {
+		cfg.Connectivity = maze.CaveConnectivity(o.Connectivity)
+	}
+	return &cfg
+}
+
+type dungeonOptions struct {
+	MinRoomSize      *int     `json:"minRoomSize"`
+	MaxRoomSize      *int     `json:"maxRoomSize"`
+	Attempts         *int     `json:"attempts"`
+	ExtraConnections *float64 `json:"extraConnections"`
+}
+
+// config applies the provided options on top of the default dungeon settings.
+func (o *dungeonOptions) config() *maze.DungeonConfig {
+	if o == nil {
+		return nil
+	}
+	cfg := maze.DefaultDungeonConfig()
+	if o.MinRoomSize != nil {
+		cfg.MinRoomSize = *o.MinRoomSize
+	}
+	if o.MaxRoomSize != nil {
+		cfg.MaxRoomSize = *o.MaxRoomSize
+	}
+	if o.Attempts != nil {
+		cfg.PlacementAttempts = *o.Attempts
+	}
+	if o.ExtraConnections != nil {
+		cfg.ExtraConnections = *o.ExtraConnections
+	}
+	return &cfg
 }
 
 type simulateRequest struct {
-	Algorithm string     `json:"algorithm" binding:"required"`
-	Grid      maze.Grid  `json:"grid" binding:"required,min=1"`
-	Start     maze.Point `json:"start" binding:"required"`
-	Goal      maze.Point `json:"goal" binding:"required"`
+	Algorithm string        `json:"algorithm" binding:"required"`
+	Grid      maze.Grid     `json:"grid" binding:"required_without=Floors,excluded_with=Floors,omitempty,min=1"`
+	Start     maze.Point    `json:"start" binding:"required"`
+	Goal      maze.Point    `json:"goal" binding:"required"`
+	Costs     maze.CostGrid `json:"costs"`
+	Movement  string        `json:"movement"`
+	Heuristic string        `json:"heuristic"`
+	Weight    *float64      `json:"weight"`
+	// Topology says how the grid tiles connect: "square" (default), "hex",
+	// "polar" or "torus".
+	Topology string `json:"topology"`
+	// Floors replaces Grid for multi-level mazes, listing every floor from the
+	// ground up; Stairs join a square on floor z to the same square on z+1.
+	Floors []maze.Grid  `json:"floors" binding:"omitempty,min=1"`
+	Stairs []maze.Point `json:"stairs"`
+	// PortalCost is what jumping between the two squares holding the same
+	// portal id (2 and up) costs, 1 by default.
+	PortalCost *float64 `json:"portalCost"`
+	// WallBreaks is how many walls the path may break through, 0 by default.
+	WallBreaks int `json:"wallBreaks" binding:"min=0,max=10"`
+	// Obstacles move one trajectory point per time step, periodic ones starting
+	// over at the end and scripted ones stopping on their last point.
+	Obstacles []algorithm.Obstacle `json:"obstacles" binding:"omitempty,max=32"`
 }
 
 type simulateStats struct {
 	ExpandedNodes int     `json:"expandedNodes"`
 	PathLength    int     `json:"pathLength"`
+	PathCost      float64 `json:"pathCost"`
 	ElapsedMs     float64 `json:"elapsedMs"`
+	Heuristic     string  `json:"heuristic,omitempty"`
+	Weight        float64 `json:"weight,omitempty"`
+	Optimal       bool    `json:"optimal"`
+}
+
+type algorithmsResponse struct {
+	Algorithms []algorithm.Info `json:"algorithms"`
 }
 
 type simulateResponse struct {
-	Found        bool          `json:"found"`
-	Path         []maze.Point  `json:"path"`
-	VisitedOrder []maze.Point  `json:"visitedOrder"`
-	Stats        simulateStats `json:"stats"`
+	Found        bool             `json:"found"`
+	Path         []maze.Point     `json:"path"`
+	VisitedOrder []maze.Point     `json:"visitedOrder"`
+	VisitedSides []algorithm.Side `json:"visitedSides,omitempty"`
+	// Teleports lists the indices of the path points reached through a portal.
+	Teleports []int `json:"teleports,omitempty"`
+	// Pickups lists the indices of the path points where a key is picked up.
+	Pickups []int `json:"pickups,omitempty"`
+	// Breaks lists the indices of the path points that are broken walls, and
+	// BreakProfile the outcome for every wall break budget up to the one asked.
+	Breaks       []int                   `json:"breaks,omitempty"`
+	BreakProfile []algorithm.BreakBudget `json:"breakProfile,omitempty"`
+	// Times holds the time step of every path point when obstacles move, and
+	// Obstacles where each of them stands at every time step.
+	Times     []int          `json:"times,omitempty"`
+	Obstacles [][]maze.Point `json:"obstacles,omitempty"`
+	Stats     simulateStats  `json:"stats"`
+}
+
+func (r simulateRequest) toServiceRequest() service.RunSimulationRequest {
+	grid, floors := r.Grid, []maze.Grid(nil)
+	if len(r.Floors) > 0 {
+		grid, floors = r.Floors[0], r.Floors[1:]
+	}
+	return service.RunSimulationRequest{
+		Algorithm:  r.Algorithm,
+		Grid:       grid,
+		Start:      r.Start,
+		Goal:       r.Goal,
+		Costs:      r.Costs,
+		Movement:   r.Movement,
+		Heuristic:  r.Heuristic,
+		Weight:     r.Weight,
+		Topology:   r.Topology,
+		Floors:     floors,
+		Stairs:     r.Stairs,
+		PortalCost: r.PortalCost,
+		WallBreaks: r.WallBreaks,
+		Obstacles:  r.Obstacles,
+	}
+}
+
+func newSimulateStats(simResult service.RunSimulationResult) simulateStats {
+	return simulateStats{
+		ExpandedNodes: simResult.Result.ExpandedNodes,
+		PathLength:    simResult.Result.PathLength,
+		PathCost:      simResult.Result.PathCost,
+		ElapsedMs:     float64(simResult.Elapsed) / float64(time.Millisecond),
+		Heuristic:     string(simResult.Result.Heuristic),
+		Weight:        simResult.Result.Weight,
+		Optimal:       simResult.Result.Optimal,
+	}
 }
 
 // Register attaches handlers to the provided router group.
 func (h *Handler) Register(r *gin.Engine) {
 	r.POST("/maze/generate", h.GenerateMaze)
+	r.POST("/maze/stream", h.StreamMaze)
+	r.GET("/world/:seed/chunk/:cx/:cy", h.WorldChunk)
+	r.POST("/world/:seed/solve", h.WorldSolve)
 	r.POST("/simulate", h.Simulate)
+	r.POST("/simulate/stream", h.SimulateStream)
+	r.POST("/simulate/multi", h.SimulateMulti)
+	r.POST("/planner/sessions", h.CreatePlanner)
+	r.PATCH("/planner/sessions/:id", h.UpdatePlanner)
+	r.DELETE("/planner/sessions/:id", h.DeletePlanner)
+	r.GET("/algorithms", h.ListAlgorithms)
 	r.GET("/healthz", h.Health)
 }
 
@@ -68,29 +233,29 @@ func (h *Handler) GenerateMaze(c *gin.Context) {
 	
 	var req generateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn(ctx, "maze generation request validation failed",
-			log.Error(err),
-		)
-		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "validation failed",
-				"details": formatValidationErrors(validationErrors),
-			})
-			return
-		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		h.bindError(c, "maze generation request validation failed", err)
 		return
 	}
 
 	h.logger.Info(ctx, "maze generation request received",
 		log.Int("width", req.Width),
 		log.Int("height", req.Height),
+		log.String("algorithm", req.Algorithm),
 	)
 
 	result, err := h.mazeService.GenerateMaze(ctx, service.GenerateMazeRequest{
-		Width:  req.Width,
-		Height: req.Height,
-		Seed:   req.Seed,
+		Width:     req.Width,
+		Height:    req.Height,
+		Seed:      req.Seed,
+		Algorithm: req.Algorithm,
+		Topology:  req.Topology,
+		Levels:    req.Levels,
+		Braid:     req.Braid,
+		Portals:   req.Portals,
+		Keys:      req.Keys,
+		Cave:      req.Cave.config(),
+		Dungeon:   req.Dungeon.config(),
+		Events:    req.Events,
 	})
 	if err != nil {
 		h.logger.Error(ctx, "maze generation handler error", err)
@@ -112,17 +277,7 @@ func (h *Handler) Simulate(c *gin.Context) {
 	
 	var req simulateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn(ctx, "simulation request validation failed",
-			log.Error(err),
-		)
-		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "validation failed",
-				"details": formatValidationErrors(validationErrors),
-			})
-			return
-		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		h.bindError(c, "simulation request validation failed", err)
 		return
 	}
 
@@ -131,12 +286,7 @@ func (h *Handler) Simulate(c *gin.Context) {
 		log.Int("grid_height", len(req.Grid)),
 	)
 
-	simResult, err := h.simService.RunSimulation(ctx, service.RunSimulationRequest{
-		Algorithm: req.Algorithm,
-		Grid:      req.Grid,
-		Start:     req.Start,
-		Goal:      req.Goal,
-	})
+	simResult, err := h.simService.RunSimulation(ctx, req.toServiceRequest())
 	if err != nil {
 		h.logger.Error(ctx, "simulation handler error", err)
 		h.handleError(c, err)
@@ -150,17 +300,18 @@ func (h *Handler) Simulate(c *gin.Context) {
 		return
 	}
 
-	stats := simulateStats{
-		ExpandedNodes: result.ExpandedNodes,
-		PathLength:    result.PathLength,
-		ElapsedMs:     float64(simResult.Elapsed) / float64(time.Millisecond),
-	}
-
 	resp := simulateResponse{
 		Found:        result.Found,
 		Path:         result.Path,
 		VisitedOrder: result.VisitedOrder,
-		Stats:        stats,
+		VisitedSides: result.VisitedSides,
+		Teleports:    result.Teleports,
+		Pickups:      result.Pickups,
+		Breaks:       result.Breaks,
+		BreakProfile: simResult.BreakProfile,
+		Times:        result.Times,
+		Obstacles:    result.Obstacles,
+		Stats:        newSimulateStats(simResult),
 	}
 
 	status := http.StatusOK
@@ -177,6 +328,12 @@ func (h *Handler) Simulate(c *gin.Context) {
 	c.JSON(status, resp)
 }
 
+// ListAlgorithms handles GET /algorithms.
+func (h *Handler) ListAlgorithms(c *gin.Context) {
+	algorithms := h.simService.ListAlgorithms(c.Request.Context())
+	c.JSON(http.StatusOK, algorithmsResponse{Algorithms: algorithms})
+}
+
 // formatValidationErrors formats validator errors into a readable map
 func formatValidationErrors(errs validator.ValidationErrors) map[string]string {
 	errors := make(map[string]string)
@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -14,9 +14,11 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
+	apierrors "github.com/JoshuaPangaribuan/pathfinder/internal/errors"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/service"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/simulation"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/transport/http/mocks"
 )
 
@@ -67,6 +69,82 @@ func TestHandler_GenerateMaze_Success(t *testing.T) {
 	mockMazeService.AssertExpectations(t)
 }
 
+func TestHandler_GenerateMaze_Algorithm(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	seed := int64(7)
+	expectedResult := maze.GenerateResult{
+		Width:     5,
+		Height:    5,
+		Grid:      make(maze.Grid, 5),
+		Seed:      &seed,
+		Algorithm: maze.AlgorithmWilson,
+	}
+
+	mockMazeService.On("GenerateMaze", ctx, service.GenerateMazeRequest{
+		Width:     2,
+		Height:    2,
+		Seed:      &seed,
+		Algorithm: "wilson",
+	}).Return(expectedResult, nil)
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"width":     2,
+		"height":    2,
+		"seed":      seed,
+		"algorithm": "wilson",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/maze/generate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp maze.GenerateResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, maze.AlgorithmWilson, resp.Algorithm)
+	mockMazeService.AssertExpectations(t)
+}
+
+func TestHandler_GenerateMaze_UnknownAlgorithm(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	mockMazeService.On("GenerateMaze", ctx, service.GenerateMazeRequest{
+		Width:     5,
+		Height:    5,
+		Algorithm: "sidewinder",
+	}).Return(maze.GenerateResult{}, apierrors.NewUnknownAlgorithmError("algorithm must be one of: backtracker, kruskal, prim, wilson, aldous-broder"))
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"width":     5,
+		"height":    5,
+		"algorithm": "sidewinder",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/maze/generate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockMazeService.AssertExpectations(t)
+}
+
 func TestHandler_GenerateMaze_ValidationError(t *testing.T) {
 	mockMazeService := new(mocks.MockMazeService)
 	mockSimService := new(mocks.MockSimulationService)
@@ -90,6 +168,282 @@ func TestHandler_GenerateMaze_ValidationError(t *testing.T) {
 	mockMazeService.AssertNotCalled(t, "GenerateMaze")
 }
 
+func TestHandler_GenerateMaze_Braid(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	mockMazeService.On("GenerateMaze", ctx, service.GenerateMazeRequest{
+		Width:  5,
+		Height: 5,
+		Braid:  0.25,
+	}).Return(maze.GenerateResult{Width: 11, Height: 11, Grid: make(maze.Grid, 11), Braid: 0.25}, nil)
+
+	router := setupTestRouter(handler)
+
+	for _, tc := range []struct {
+		braid float64
+		want  int
+	}{
+		{0.25, http.StatusOK},
+		{1.5, http.StatusBadRequest},
+		{-0.5, http.StatusBadRequest},
+	} {
+		bodyBytes, _ := json.Marshal(map[string]any{"width": 5, "height": 5, "braid": tc.braid})
+		req := httptest.NewRequest("POST", "/maze/generate", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.want, w.Code, "braid %v", tc.braid)
+	}
+	mockMazeService.AssertNumberOfCalls(t, "GenerateMaze", 1)
+}
+
+func TestHandler_GenerateMaze_CaveOptions(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	cave := maze.DefaultCaveConfig()
+	cave.SmoothingPasses = 6
+	cave.Connectivity = maze.CaveConnectivityLargest
+
+	mockMazeService.On("GenerateMaze", ctx, service.GenerateMazeRequest{
+		Width:     10,
+		Height:    10,
+		Algorithm: "cave",
+		Cave:      &cave,
+	}).Return(maze.GenerateResult{Width: 21, Height: 21, Grid: make(maze.Grid, 21), Algorithm: maze.AlgorithmCave}, nil)
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"width":     10,
+		"height":    10,
+		"algorithm": "cave",
+		"cave": map[string]any{
+			"passes":       6,
+			"connectivity": "largest",
+		},
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/maze/generate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockMazeService.AssertExpectations(t)
+}
+
+func TestHandler_GenerateMaze_DungeonRooms(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	dungeon := maze.DefaultDungeonConfig()
+	dungeon.MaxRoomSize = 5
+	rooms := []maze.Room{
+		{Bounds: maze.Rect{X: 1, Y: 1, Width: 3, Height: 3}, Doors: []maze.Point{{X: 4, Y: 1}}},
+		{Bounds: maze.Rect{X: 7, Y: 1, Width: 3, Height: 5}, Doors: []maze.Point{{X: 6, Y: 1}}},
+	}
+
+	mockMazeService.On("GenerateMaze", ctx, service.GenerateMazeRequest{
+		Width:     5,
+		Height:    5,
+		Algorithm: "dungeon",
+		Dungeon:   &dungeon,
+	}).Return(maze.GenerateResult{Width: 11, Height: 11, Grid: make(maze.Grid, 11), Algorithm: maze.AlgorithmDungeon, Rooms: rooms}, nil)
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"width":     5,
+		"height":    5,
+		"algorithm": "dungeon",
+		"dungeon":   map[string]any{"maxRoomSize": 5},
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/maze/generate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp maze.GenerateResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, rooms, resp.Rooms)
+	mockMazeService.AssertExpectations(t)
+}
+
+func TestHandler_GenerateMaze_Events(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	events := []maze.Event{
+		{Kind: maze.EventCarve, Point: maze.Point{X: 1, Y: 1}},
+		{Kind: maze.EventWall, Point: maze.Point{X: 2, Y: 1}},
+	}
+
+	mockMazeService.On("GenerateMaze", ctx, service.GenerateMazeRequest{
+		Width:     5,
+		Height:    5,
+		Algorithm: "recursive-division",
+		Events:    true,
+	}).Return(maze.GenerateResult{Width: 11, Height: 11, Grid: make(maze.Grid, 11), Algorithm: maze.AlgorithmRecursiveDivision, Events: events}, nil)
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"width":     5,
+		"height":    5,
+		"algorithm": "recursive-division",
+		"events":    true,
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/maze/generate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"kind":"wall"`)
+	var resp maze.GenerateResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, events, resp.Events)
+	mockMazeService.AssertExpectations(t)
+}
+
+func TestHandler_GenerateMaze_HexTopology(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	seed := int64(4)
+	expected, err := maze.NewHexGenerator().Generate(ctx, 3, 3, &seed)
+	assert.NoError(t, err)
+
+	mockMazeService.On("GenerateMaze", ctx, service.GenerateMazeRequest{
+		Width:    3,
+		Height:   3,
+		Seed:     &seed,
+		Topology: "hex",
+	}).Return(expected, nil)
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"width":    3,
+		"height":   3,
+		"seed":     seed,
+		"topology": "hex",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/maze/generate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"topology":"hex"`)
+	var resp maze.GenerateResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, expected.Grid, resp.Grid)
+	mockMazeService.AssertExpectations(t)
+}
+
+func TestHandler_GenerateMaze_MultiLevel(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	seed := int64(4)
+	expected, err := maze.NewMultiLevelGenerator(2).Generate(ctx, 3, 3, &seed)
+	assert.NoError(t, err)
+
+	mockMazeService.On("GenerateMaze", ctx, service.GenerateMazeRequest{
+		Width:  3,
+		Height: 3,
+		Seed:   &seed,
+		Levels: 2,
+	}).Return(expected, nil)
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"width":  3,
+		"height": 3,
+		"seed":   seed,
+		"levels": 2,
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/maze/generate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp maze.GenerateResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, expected.Floors, resp.Floors)
+	assert.Equal(t, expected.Stairs, resp.Stairs)
+	mockMazeService.AssertExpectations(t)
+}
+
+func TestHandler_GenerateMaze_TorusUnsupportedAlgorithm(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	mockMazeService.On("GenerateMaze", ctx, service.GenerateMazeRequest{
+		Width:     5,
+		Height:    5,
+		Algorithm: "eller",
+		Topology:  "torus",
+	}).Return(maze.GenerateResult{}, apierrors.NewValidationError("torus topology is only supported by the backtracker, kruskal, prim, wilson, aldous-broder algorithms"))
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"width":     5,
+		"height":    5,
+		"algorithm": "eller",
+		"topology":  "torus",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/maze/generate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
+	mockMazeService.AssertExpectations(t)
+}
+
 func TestHandler_GenerateMaze_InvalidDimensions(t *testing.T) {
 	mockMazeService := new(mocks.MockMazeService)
 	mockSimService := new(mocks.MockSimulationService)
@@ -251,7 +605,7 @@ func TestHandler_Simulate_UnknownAlgorithm(t *testing.T) {
 		Grid:      grid,
 		Start:     maze.Point{X: 0, Y: 0},
 		Goal:      maze.Point{X: 4, Y: 4},
-	}).Return(service.RunSimulationResult{}, errors.New("algorithm must be one of: bfs, dfs, astar, a*"))
+	}).Return(service.RunSimulationResult{}, apierrors.NewUnknownAlgorithmError("algorithm must be one of: bfs, dfs, astar, a*"))
 
 	router := setupTestRouter(handler)
 
@@ -272,31 +626,40 @@ func TestHandler_Simulate_UnknownAlgorithm(t *testing.T) {
 	mockSimService.AssertExpectations(t)
 }
 
-func TestHandler_Simulate_OutOfBounds(t *testing.T) {
+func TestHandler_Simulate_Movement(t *testing.T) {
 	mockMazeService := new(mocks.MockMazeService)
 	mockSimService := new(mocks.MockSimulationService)
 	logger := log.NewNoOpLogger()
 	handler := NewHandler(mockMazeService, mockSimService, logger)
 
 	ctx := context.Background()
-	grid := createTestGrid(5, 5, nil)
-	start := maze.Point{X: -1, Y: 0}
-	goal := maze.Point{X: 0, Y: 0}
+	grid := createTestGrid(3, 3, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 2}
 
 	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
-		Algorithm: "bfs",
+		Algorithm: "astar",
 		Grid:      grid,
 		Start:     start,
 		Goal:      goal,
-	}).Return(service.RunSimulationResult{}, errors.New("start or goal out of bounds"))
+		Movement:  "8-way",
+	}).Return(service.RunSimulationResult{
+		Result: &algorithm.Result{
+			Found:      true,
+			Path:       []maze.Point{start, {X: 1, Y: 1}, goal},
+			PathLength: 2,
+			PathCost:   2.8284271247461903,
+		},
+	}, nil)
 
 	router := setupTestRouter(handler)
 
 	reqBody := map[string]any{
-		"algorithm": "bfs",
+		"algorithm": "astar",
 		"grid":      grid,
 		"start":     start,
 		"goal":      goal,
+		"movement":  "8-way",
 	}
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
@@ -305,35 +668,52 @@ func TestHandler_Simulate_OutOfBounds(t *testing.T) {
 
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp simulateResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.InDelta(t, 2.8284271247461903, resp.Stats.PathCost, 1e-9)
 	mockSimService.AssertExpectations(t)
 }
 
-func TestHandler_Simulate_Blocked(t *testing.T) {
+func TestHandler_Simulate_HeuristicAndWeight(t *testing.T) {
 	mockMazeService := new(mocks.MockMazeService)
 	mockSimService := new(mocks.MockSimulationService)
 	logger := log.NewNoOpLogger()
 	handler := NewHandler(mockMazeService, mockSimService, logger)
 
 	ctx := context.Background()
-	grid := createTestGrid(5, 5, nil)
+	grid := createTestGrid(3, 3, nil)
 	start := maze.Point{X: 0, Y: 0}
-	goal := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 2}
+	weight := 2.5
 
 	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
-		Algorithm: "bfs",
+		Algorithm: "astar",
 		Grid:      grid,
 		Start:     start,
 		Goal:      goal,
-	}).Return(service.RunSimulationResult{}, errors.New("start or goal blocked"))
+		Heuristic: "euclidean",
+		Weight:    &weight,
+	}).Return(service.RunSimulationResult{
+		Result: &algorithm.Result{
+			Found:      true,
+			Path:       []maze.Point{start, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, goal},
+			PathLength: 4,
+			PathCost:   4,
+			Heuristic:  algorithm.HeuristicEuclidean,
+			Weight:     weight,
+		},
+	}, nil)
 
 	router := setupTestRouter(handler)
 
 	reqBody := map[string]any{
-		"algorithm": "bfs",
+		"algorithm": "astar",
 		"grid":      grid,
 		"start":     start,
 		"goal":      goal,
+		"heuristic": "euclidean",
+		"weight":    weight,
 	}
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
@@ -342,30 +722,470 @@ func TestHandler_Simulate_Blocked(t *testing.T) {
 
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp simulateResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "euclidean", resp.Stats.Heuristic)
+	assert.Equal(t, weight, resp.Stats.Weight)
+	assert.False(t, resp.Stats.Optimal)
 	mockSimService.AssertExpectations(t)
 }
 
-func TestHandler_Health(t *testing.T) {
+func TestHandler_Simulate_UnknownMovement(t *testing.T) {
 	mockMazeService := new(mocks.MockMazeService)
 	mockSimService := new(mocks.MockSimulationService)
 	logger := log.NewNoOpLogger()
 	handler := NewHandler(mockMazeService, mockSimService, logger)
 
+	ctx := context.Background()
+	grid := createTestGrid(3, 3, nil)
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "bfs",
+		Grid:      grid,
+		Start:     maze.Point{X: 0, Y: 0},
+		Goal:      maze.Point{X: 2, Y: 2},
+		Movement:  "hex",
+	}).Return(service.RunSimulationResult{}, algorithm.ErrUnknownMovement)
+
 	router := setupTestRouter(handler)
 
-	req := httptest.NewRequest("GET", "/healthz", nil)
+	reqBody := map[string]any{
+		"algorithm": "bfs",
+		"grid":      grid,
+		"start":     maze.Point{X: 0, Y: 0},
+		"goal":      maze.Point{X: 2, Y: 2},
+		"movement":  "hex",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
+	mockSimService.AssertExpectations(t)
 }
 
-// Helper function to create test grid
-func createTestGrid(width, height int, walls []maze.Point) maze.Grid {
-	grid := make(maze.Grid, height)
-	for y := range grid {
+func TestHandler_Simulate_HexTopology(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := createTestGrid(3, 3, nil)
+	start := maze.Point{X: 2, Y: 0}
+	goal := maze.Point{X: 0, Y: 2}
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "astar",
+		Grid:      grid,
+		Start:     start,
+		Goal:      goal,
+		Topology:  "hex",
+	}).Return(service.RunSimulationResult{
+		Result: &algorithm.Result{
+			Found:      true,
+			Path:       []maze.Point{start, {X: 1, Y: 1}, goal},
+			PathLength: 2,
+			PathCost:   2,
+			Heuristic:  algorithm.HeuristicHex,
+			Weight:     1,
+			Optimal:    true,
+		},
+	}, nil)
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "jps",
+		Grid:      grid,
+		Start:     start,
+		Goal:      goal,
+		Topology:  "hex",
+	}).Return(service.RunSimulationResult{}, algorithm.ErrUnsupportedTopology)
+
+	router := setupTestRouter(handler)
+
+	for _, tc := range []struct {
+		algorithm string
+		status    int
+		body      string
+	}{
+		{"astar", http.StatusOK, `"heuristic":"hex"`},
+		{"jps", http.StatusBadRequest, "VALIDATION_ERROR"},
+	} {
+		reqBody := map[string]any{
+			"algorithm": tc.algorithm,
+			"grid":      grid,
+			"start":     start,
+			"goal":      goal,
+			"topology":  "hex",
+		}
+		bodyBytes, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, tc.algorithm)
+		assert.Contains(t, w.Body.String(), tc.body, tc.algorithm)
+	}
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_Simulate_PolarTopology(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	seed := int64(2)
+	generated, err := maze.NewPolarGenerator().Generate(ctx, 3, 3, &seed)
+	assert.NoError(t, err)
+	start := maze.Point{X: 0, Y: len(generated.Grid) - 1}
+	goal := maze.Point{X: 0, Y: 0}
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "bfs",
+		Grid:      generated.Grid,
+		Start:     start,
+		Goal:      goal,
+		Topology:  "polar",
+	}).Return(service.RunSimulationResult{}, maze.ErrInvalidPolarGrid)
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"algorithm": "bfs",
+		"grid":      generated.Grid,
+		"start":     start,
+		"goal":      goal,
+		"topology":  "polar",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_Simulate_MultiLevel(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	ground := createTestGrid(3, 3, nil)
+	upper := createTestGrid(3, 3, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 0, Y: 0, Z: 1}
+	stairs := []maze.Point{{X: 1, Y: 1}}
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "bfs",
+		Grid:      ground,
+		Start:     start,
+		Goal:      goal,
+		Floors:    []maze.Grid{upper},
+		Stairs:    stairs,
+	}).Return(service.RunSimulationResult{
+		Result: &algorithm.Result{
+			Found:        true,
+			Path:         []maze.Point{start, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 1, Z: 1}, {X: 1, Y: 0, Z: 1}, goal},
+			VisitedOrder: []maze.Point{start, goal},
+			PathLength:   5,
+			PathCost:     5,
+		},
+	}, nil)
+
+	router := setupTestRouter(handler)
+
+	for _, tc := range []struct {
+		name   string
+		body   map[string]any
+		status int
+		want   string
+	}{
+		{
+			name:   "floors",
+			body:   map[string]any{"algorithm": "bfs", "floors": []maze.Grid{ground, upper}, "stairs": stairs, "start": start, "goal": goal},
+			status: http.StatusOK,
+			want:   `{"x":1,"y":1,"z":1}`,
+		},
+		{
+			name:   "grid and floors",
+			body:   map[string]any{"algorithm": "bfs", "grid": ground, "floors": []maze.Grid{ground, upper}, "start": start, "goal": goal},
+			status: http.StatusBadRequest,
+			want:   "validation failed",
+		},
+	} {
+		bodyBytes, _ := json.Marshal(tc.body)
+		req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, tc.name)
+		assert.Contains(t, w.Body.String(), tc.want, tc.name)
+	}
+
+	// Ground floor points keep their z too
+	bodyBytes, _ := json.Marshal(map[string]any{"algorithm": "bfs", "floors": []maze.Grid{ground, upper}, "stairs": stairs, "start": start, "goal": goal})
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp struct {
+		Path         []map[string]int `json:"path"`
+		VisitedOrder []map[string]int `json:"visitedOrder"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	for _, p := range append(resp.Path, resp.VisitedOrder...) {
+		assert.Contains(t, p, "z")
+	}
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_Simulate_Portals(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := maze.Grid{
+		{0, 2, 1, 2, 0},
+	}
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 4, Y: 0}
+	portalCost := 0.5
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm:  "astar",
+		Grid:       grid,
+		Start:      start,
+		Goal:       goal,
+		PortalCost: &portalCost,
+	}).Return(service.RunSimulationResult{
+		Result: &algorithm.Result{
+			Found:        true,
+			Path:         []maze.Point{start, {X: 1, Y: 0}, {X: 3, Y: 0}, goal},
+			VisitedOrder: []maze.Point{start, {X: 1, Y: 0}, {X: 3, Y: 0}, goal},
+			Teleports:    []int{2},
+			PathLength:   3,
+			PathCost:     2.5,
+		},
+	}, nil)
+
+	router := setupTestRouter(handler)
+
+	body := map[string]any{"algorithm": "astar", "grid": grid, "start": start, "goal": goal, "portalCost": portalCost}
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response simulateResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []int{2}, response.Teleports)
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_Simulate_InvalidPortals(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := maze.Grid{
+		{0, 2, 0},
+	}
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 0}
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "astar",
+		Grid:      grid,
+		Start:     start,
+		Goal:      goal,
+	}).Return(service.RunSimulationResult{}, maze.ErrInvalidPortals)
+
+	router := setupTestRouter(handler)
+
+	body := map[string]any{"algorithm": "astar", "grid": grid, "start": start, "goal": goal}
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_Simulate_OutOfBounds(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := createTestGrid(5, 5, nil)
+	start := maze.Point{X: -1, Y: 0}
+	goal := maze.Point{X: 0, Y: 0}
+
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "bfs",
+		Grid:      grid,
+		Start:     start,
+		Goal:      goal,
+	}).Return(service.RunSimulationResult{}, fmt.Errorf("simulation failed: %w", algorithm.ErrOutOfBounds))
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"algorithm": "bfs",
+		"grid":      grid,
+		"start":     start,
+		"goal":      goal,
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_Simulate_Blocked(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := createTestGrid(5, 5, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 0, Y: 0}
+
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "bfs",
+		Grid:      grid,
+		Start:     start,
+		Goal:      goal,
+	}).Return(service.RunSimulationResult{}, fmt.Errorf("simulation failed: %w", algorithm.ErrBlocked))
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"algorithm": "bfs",
+		"grid":      grid,
+		"start":     start,
+		"goal":      goal,
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_RaggedGrid(t *testing.T) {
+	// The real service, so the error it reports for a grid whose rows differ
+	// in width is the one the handler maps.
+	mockMazeService := new(mocks.MockMazeService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, service.NewSimulationService(simulation.NewRunner(), logger), logger)
+	router := setupTestRouter(handler)
+
+	grid := maze.Grid{{0, 0, 0}, {0, 0}}
+	start, goal := maze.Point{X: 0, Y: 0}, maze.Point{X: 1, Y: 1}
+	cases := []struct {
+		path string
+		body map[string]any
+	}{
+		{"/simulate", map[string]any{"algorithm": "bfs", "grid": grid, "start": start, "goal": goal}},
+		{"/simulate/multi", map[string]any{"grid": grid, "agents": []algorithm.Agent{{Start: start, Goal: goal}}}},
+		{"/planner/sessions", map[string]any{"grid": grid, "start": start, "goal": goal}},
+	}
+	for _, tc := range cases {
+		bodyBytes, _ := json.Marshal(tc.body)
+		req := httptest.NewRequest("POST", tc.path, bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, tc.path)
+		assert.Contains(t, w.Body.String(), "VALIDATION_ERROR", tc.path)
+		assert.Contains(t, w.Body.String(), "inconsistent dimensions", tc.path)
+	}
+}
+
+func TestHandler_ListAlgorithms(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	infos := []algorithm.Info{
+		{Name: "bfs", Label: "Breadth-First Search", Aliases: []string{}, Optimal: true},
+		{Name: "astar", Label: "A* Search", Aliases: []string{"a*"}, Optimal: true, SupportsCosts: true},
+	}
+	mockSimService.On("ListAlgorithms", ctx).Return(infos)
+
+	router := setupTestRouter(handler)
+
+	req := httptest.NewRequest("GET", "/algorithms", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body algorithmsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, infos, body.Algorithms)
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_Health(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	router := setupTestRouter(handler)
+
+	req := httptest.NewRequest("GET", "/healthz", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// Helper function to create test grid
+func createTestGrid(width, height int, walls []maze.Point) maze.Grid {
+	grid := make(maze.Grid, height)
+	for y := range grid {
 		row := make([]int, width)
 		for x := range row {
 			row[x] = 0 // All walkable by default
@@ -380,3 +1200,321 @@ func createTestGrid(width, height int, walls []maze.Point) maze.Grid {
 	}
 	return grid
 }
+
+func TestHandler_GenerateMaze_Keys(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	seed := int64(6)
+	expected, err := maze.NewKeyGenerator(maze.NewGenerator(), 2).Generate(ctx, 4, 4, &seed)
+	assert.NoError(t, err)
+
+	mockMazeService.On("GenerateMaze", ctx, service.GenerateMazeRequest{
+		Width:  4,
+		Height: 4,
+		Seed:   &seed,
+		Keys:   2,
+	}).Return(expected, nil)
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"width":  4,
+		"height": 4,
+		"seed":   seed,
+		"keys":   2,
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/maze/generate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp maze.GenerateResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, expected.Grid, resp.Grid)
+	mockMazeService.AssertExpectations(t)
+}
+
+func TestHandler_Simulate_Keys(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := maze.Grid{
+		{0, maze.Key(1), maze.Door(1), 0},
+	}
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 3, Y: 0}
+	path := []maze.Point{start, {X: 1, Y: 0}, {X: 2, Y: 0}, goal}
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "bfs",
+		Grid:      grid,
+		Start:     start,
+		Goal:      goal,
+	}).Return(service.RunSimulationResult{
+		Result: &algorithm.Result{
+			Found:         true,
+			Path:          path,
+			VisitedOrder:  path,
+			ExpandedNodes: 4,
+			Pickups:       []int{1},
+			PathLength:    3,
+			PathCost:      3,
+		},
+	}, nil)
+
+	router := setupTestRouter(handler)
+
+	body := map[string]any{"algorithm": "bfs", "grid": grid, "start": start, "goal": goal}
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response simulateResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []int{1}, response.Pickups)
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_Simulate_UnsupportedKeys(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := maze.Grid{
+		{0, maze.Key(1), maze.Door(1), 0},
+	}
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 3, Y: 0}
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "jps",
+		Grid:      grid,
+		Start:     start,
+		Goal:      goal,
+	}).Return(service.RunSimulationResult{}, algorithm.ErrUnsupportedKeys)
+
+	router := setupTestRouter(handler)
+
+	body := map[string]any{"algorithm": "jps", "grid": grid, "start": start, "goal": goal}
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_Simulate_WallBreaks(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := maze.Grid{
+		{0, 1, 0},
+	}
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 0}
+	path := []maze.Point{start, {X: 1, Y: 0}, goal}
+	profile := []algorithm.BreakBudget{
+		{Breaks: 0},
+		{Breaks: 1, Found: true, PathLength: 2, PathCost: 2},
+	}
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm:  "bfs",
+		Grid:       grid,
+		Start:      start,
+		Goal:       goal,
+		WallBreaks: 1,
+	}).Return(service.RunSimulationResult{
+		Result: &algorithm.Result{
+			Found:         true,
+			Path:          path,
+			VisitedOrder:  path,
+			ExpandedNodes: 3,
+			Breaks:        []int{1},
+			PathLength:    2,
+			PathCost:      2,
+		},
+		BreakProfile: profile,
+	}, nil)
+
+	router := setupTestRouter(handler)
+
+	body := map[string]any{"algorithm": "bfs", "grid": grid, "start": start, "goal": goal, "wallBreaks": 1}
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response simulateResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []int{1}, response.Breaks)
+	assert.Equal(t, profile, response.BreakProfile)
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_Simulate_InvalidWallBreaks(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	router := setupTestRouter(handler)
+
+	body := map[string]any{"algorithm": "bfs", "grid": maze.Grid{{0, 1, 0}}, "start": maze.Point{}, "goal": maze.Point{X: 2}, "wallBreaks": 11}
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "validation failed")
+	mockSimService.AssertNotCalled(t, "RunSimulation")
+}
+
+func TestHandler_Simulate_UnsupportedWallBreaks(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := maze.Grid{
+		{0, 1, 0},
+	}
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 0}
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm:  "jps",
+		Grid:       grid,
+		Start:      start,
+		Goal:       goal,
+		WallBreaks: 1,
+	}).Return(service.RunSimulationResult{}, algorithm.ErrUnsupportedWallBreaks)
+
+	router := setupTestRouter(handler)
+
+	body := map[string]any{"algorithm": "jps", "grid": grid, "start": start, "goal": goal, "wallBreaks": 1}
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_Simulate_Obstacles(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := maze.Grid{
+		{0, 0, 0},
+	}
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 0}
+	obstacles := []algorithm.Obstacle{
+		{Trajectory: []maze.Point{{X: 1, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
+	}
+	path := []maze.Point{start, start, {X: 1, Y: 0}, {X: 1, Y: 0}}
+	frames := [][]maze.Point{{{X: 1, Y: 0}}, {{X: 1, Y: 0}}, {{X: 2, Y: 0}}, {{X: 2, Y: 0}}}
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "astar",
+		Grid:      grid,
+		Start:     start,
+		Goal:      goal,
+		Obstacles: obstacles,
+	}).Return(service.RunSimulationResult{
+		Result: &algorithm.Result{
+			Found:         false,
+			VisitedOrder:  path,
+			ExpandedNodes: 4,
+			Obstacles:     frames,
+		},
+	}, nil)
+
+	router := setupTestRouter(handler)
+
+	body := map[string]any{"algorithm": "astar", "grid": grid, "start": start, "goal": goal, "obstacles": obstacles}
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var response simulateResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, frames, response.Obstacles)
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_Simulate_InvalidObstacles(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := maze.Grid{
+		{0, 0, 0},
+	}
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 0}
+	obstacles := []algorithm.Obstacle{
+		{Trajectory: []maze.Point{{X: 5, Y: 0}}, Periodic: true},
+	}
+	mockSimService.On("RunSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "astar",
+		Grid:      grid,
+		Start:     start,
+		Goal:      goal,
+		Obstacles: obstacles,
+	}).Return(service.RunSimulationResult{}, algorithm.ErrInvalidObstacles)
+
+	router := setupTestRouter(handler)
+
+	body := map[string]any{"algorithm": "astar", "grid": grid, "start": start, "goal": goal, "obstacles": obstacles}
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/simulate", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
+	mockSimService.AssertExpectations(t)
+}
@@ -12,8 +12,8 @@ type MockGenerator struct {
 	mock.Mock
 }
 
-func (m *MockGenerator) Generate(ctx context.Context, width, height int, seed *int64) (maze.GenerateResult, error) {
-	args := m.Called(ctx, width, height, seed)
+func (m *MockGenerator) Generate(ctx context.Context, width, height int, seed *int64, opts ...maze.Option) (maze.GenerateResult, error) {
+	args := m.Called(ctx, width, height, seed, opts)
 	return args.Get(0).(maze.GenerateResult), args.Error(1)
 }
 
@@ -3,8 +3,10 @@ package mocks
 import (
 	"context"
 
+	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/service"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/simulation"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -18,6 +20,16 @@ func (m *MockMazeService) GenerateMaze(ctx context.Context, req service.Generate
 	return args.Get(0).(maze.GenerateResult), args.Error(1)
 }
 
+func (m *MockMazeService) StreamMaze(ctx context.Context, req service.StreamMazeRequest) (service.MazeStream, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(service.MazeStream), args.Error(1)
+}
+
+func (m *MockMazeService) WorldChunk(ctx context.Context, req service.WorldChunkRequest) (maze.Chunk, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(maze.Chunk), args.Error(1)
+}
+
 // MockSimulationService is a mock implementation of service.SimulationServiceInterface
 type MockSimulationService struct {
 	mock.Mock
@@ -28,3 +40,46 @@ func (m *MockSimulationService) RunSimulation(ctx context.Context, req service.R
 	return args.Get(0).(service.RunSimulationResult), args.Error(1)
 }
 
+// StreamSimulation replays the []algorithm.Event given as the third Return value
+// through emit before returning the configured result.
+func (m *MockSimulationService) StreamSimulation(ctx context.Context, req service.RunSimulationRequest, emit simulation.EmitFunc) (service.RunSimulationResult, error) {
+	args := m.Called(ctx, req)
+	if events, ok := args.Get(2).([]algorithm.Event); ok {
+		for _, ev := range events {
+			if err := emit(ev); err != nil {
+				return service.RunSimulationResult{}, err
+			}
+		}
+	}
+	return args.Get(0).(service.RunSimulationResult), args.Error(1)
+}
+
+func (m *MockSimulationService) ListAlgorithms(ctx context.Context) []algorithm.Info {
+	args := m.Called(ctx)
+	return args.Get(0).([]algorithm.Info)
+}
+
+func (m *MockSimulationService) SolveWorld(ctx context.Context, req service.SolveWorldRequest) (service.SolveWorldResult, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(service.SolveWorldResult), args.Error(1)
+}
+
+func (m *MockSimulationService) SolveMulti(ctx context.Context, req service.SolveMultiRequest) (service.SolveMultiResult, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(service.SolveMultiResult), args.Error(1)
+}
+
+func (m *MockSimulationService) CreatePlannerSession(ctx context.Context, req service.CreatePlannerSessionRequest) (service.PlannerSessionResult, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(service.PlannerSessionResult), args.Error(1)
+}
+
+func (m *MockSimulationService) UpdatePlannerSession(ctx context.Context, req service.UpdatePlannerSessionRequest) (service.PlannerSessionResult, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(service.PlannerSessionResult), args.Error(1)
+}
+
+func (m *MockSimulationService) DeletePlannerSession(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
 	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/simulation"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -14,11 +15,23 @@ type MockRunner struct {
 	mock.Mock
 }
 
-func (m *MockRunner) Run(ctx context.Context, algo string, grid maze.Grid, start, goal maze.Point) (*algorithm.Result, time.Duration, error) {
-	args := m.Called(ctx, algo, grid, start, goal)
+func (m *MockRunner) Run(ctx context.Context, algo string, grid maze.Grid, start, goal maze.Point, opts ...algorithm.Option) (*algorithm.Result, time.Duration, error) {
+	args := m.Called(ctx, algo, grid, start, goal, opts)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(time.Duration), args.Error(2)
 	}
 	return args.Get(0).(*algorithm.Result), args.Get(1).(time.Duration), args.Error(2)
 }
 
+func (m *MockRunner) Stream(ctx context.Context, algo string, grid maze.Grid, start, goal maze.Point, emit simulation.EmitFunc, opts ...algorithm.Option) (*algorithm.Result, time.Duration, error) {
+	args := m.Called(ctx, algo, grid, start, goal, emit, opts)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(time.Duration), args.Error(2)
+	}
+	return args.Get(0).(*algorithm.Result), args.Get(1).(time.Duration), args.Error(2)
+}
+
+func (m *MockRunner) Algorithms() []algorithm.Info {
+	args := m.Called()
+	return args.Get(0).([]algorithm.Info)
+}
@@ -0,0 +1,99 @@
+package httptransport
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/service"
+)
+
+type simulateMultiRequest struct {
+	Grid   maze.Grid         `json:"grid" binding:"required,min=1"`
+	Agents []algorithm.Agent `json:"agents" binding:"required,min=1,max=16"`
+	// Movement and Topology are those of /simulate; every step, teleport and
+	// wait takes one time step whatever its length.
+	Movement      string `json:"movement"`
+	Topology      string `json:"topology"`
+	MaxExpansions int    `json:"maxExpansions" binding:"omitempty,min=1"`
+}
+
+type simulateMultiStats struct {
+	SumOfCosts         int     `json:"sumOfCosts"`
+	Makespan           int     `json:"makespan"`
+	ExpandedNodes      int     `json:"expandedNodes"`
+	LowLevelExpansions int     `json:"lowLevelExpansions"`
+	ElapsedMs          float64 `json:"elapsedMs"`
+	LimitReached       bool    `json:"limitReached"`
+}
+
+type simulateMultiResponse struct {
+	Found     bool                 `json:"found"`
+	Paths     [][]maze.Point       `json:"paths"`
+	Conflicts []algorithm.Conflict `json:"conflicts"`
+	Stats     simulateMultiStats   `json:"stats"`
+}
+
+// SimulateMulti handles POST /simulate/multi.
+// It plans collision-free paths for several agents with Conflict-Based Search:
+// no two agents share a square or swap squares in the same time step. The
+// search is bounded by maxExpansions constraint tree nodes; running out, or
+// agents that cannot all arrive, answers 422 with the best partial solution and
+// the conflicts left in it.
+func (h *Handler) SimulateMulti(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req simulateMultiRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.bindError(c, "multi-agent request validation failed", err)
+		return
+	}
+
+	simResult, err := h.simService.SolveMulti(ctx, service.SolveMultiRequest{
+		Grid:          req.Grid,
+		Agents:        req.Agents,
+		Movement:      req.Movement,
+		Topology:      req.Topology,
+		MaxExpansions: req.MaxExpansions,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "multi-agent handler error", err)
+		h.handleError(c, err)
+		return
+	}
+
+	result := simResult.Result
+	resp := simulateMultiResponse{
+		Found:     result.Found,
+		Paths:     result.Paths,
+		Conflicts: result.Conflicts,
+		Stats: simulateMultiStats{
+			SumOfCosts:         result.SumOfCosts,
+			Makespan:           result.Makespan,
+			ExpandedNodes:      result.ExpandedNodes,
+			LowLevelExpansions: result.LowLevelExpansions,
+			ElapsedMs:          float64(simResult.Elapsed) / float64(time.Millisecond),
+			LimitReached:       result.LimitReached,
+		},
+	}
+	if resp.Conflicts == nil {
+		resp.Conflicts = []algorithm.Conflict{}
+	}
+
+	status := http.StatusOK
+	if !result.Found {
+		status = http.StatusUnprocessableEntity
+	}
+
+	h.logger.Info(ctx, "multi-agent response sent",
+		log.Int("status", status),
+		log.Int("agents", len(req.Agents)),
+		log.Bool("limit_reached", result.LimitReached),
+	)
+
+	c.JSON(status, resp)
+}
@@ -0,0 +1,146 @@
+package httptransport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/service"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/transport/http/mocks"
+)
+
+func TestHandler_SimulateMulti(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := maze.Grid{{1, 0, 1}, {0, 0, 0}}
+	agents := []algorithm.Agent{
+		{Start: maze.Point{X: 0, Y: 1}, Goal: maze.Point{X: 2, Y: 1}},
+		{Start: maze.Point{X: 2, Y: 1}, Goal: maze.Point{X: 0, Y: 1}},
+	}
+	paths := [][]maze.Point{
+		{{X: 0, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 1}},
+		{{X: 2, Y: 1}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 1}},
+	}
+	to := maze.Point{X: 2}
+
+	mockSimService.On("SolveMulti", ctx, service.SolveMultiRequest{Grid: grid, Agents: agents}).Return(service.SolveMultiResult{
+		Result:  &algorithm.MultiResult{Found: true, Paths: paths, SumOfCosts: 7, Makespan: 4, Conflicts: []algorithm.Conflict{}, ExpandedNodes: 3, LowLevelExpansions: 20},
+		Elapsed: time.Millisecond,
+	}, nil)
+	mockSimService.On("SolveMulti", ctx, service.SolveMultiRequest{Grid: grid, Agents: agents, MaxExpansions: 1}).Return(service.SolveMultiResult{
+		Result: &algorithm.MultiResult{
+			Paths:         paths,
+			Conflicts:     []algorithm.Conflict{{Kind: algorithm.ConflictEdge, Agents: [2]int{0, 1}, Point: maze.Point{X: 1}, To: &to}},
+			ExpandedNodes: 1,
+			LimitReached:  true,
+		},
+	}, nil)
+
+	router := setupTestRouter(handler)
+
+	bodyBytes, _ := json.Marshal(map[string]any{"grid": grid, "agents": agents})
+	req := httptest.NewRequest("POST", "/simulate/multi", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp simulateMultiResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Found)
+	assert.Equal(t, paths, resp.Paths)
+	assert.Equal(t, 7, resp.Stats.SumOfCosts)
+	assert.Equal(t, 4, resp.Stats.Makespan)
+	assert.Equal(t, 20, resp.Stats.LowLevelExpansions)
+	assert.Contains(t, w.Body.String(), `"conflicts":[]`)
+
+	bodyBytes, _ = json.Marshal(map[string]any{"grid": grid, "agents": agents, "maxExpansions": 1})
+	req = httptest.NewRequest("POST", "/simulate/multi", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.False(t, resp.Found)
+	assert.True(t, resp.Stats.LimitReached)
+	require.Len(t, resp.Conflicts, 1)
+	assert.Equal(t, algorithm.ConflictEdge, resp.Conflicts[0].Kind)
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_SimulateMulti_InvalidRequest(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	router := setupTestRouter(handler)
+	grid := maze.Grid{{0, 0, 0}}
+	for _, body := range []map[string]any{
+		{"grid": grid},
+		{"grid": grid, "agents": []algorithm.Agent{}},
+		{"agents": []algorithm.Agent{{Goal: maze.Point{X: 2}}}},
+		{"grid": grid, "agents": make([]algorithm.Agent, 17)},
+	} {
+		bodyBytes, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/simulate/multi", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "%v", body)
+	}
+	mockSimService.AssertNotCalled(t, "SolveMulti")
+}
+
+func TestHandler_SimulateMulti_ServiceErrors(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := maze.Grid{{0, 0, 0}}
+	cases := []struct {
+		agents []algorithm.Agent
+		err    error
+		status int
+		code   string
+	}{
+		{[]algorithm.Agent{{Goal: maze.Point{X: 2}}, {Goal: maze.Point{X: 2}}}, algorithm.ErrInvalidAgents, http.StatusBadRequest, "VALIDATION_ERROR"},
+		{[]algorithm.Agent{{Goal: maze.Point{X: 3}}}, algorithm.ErrOutOfBounds, http.StatusBadRequest, "OUT_OF_BOUNDS"},
+		// Internal failures stay internal whatever words their message uses
+		{[]algorithm.Agent{{Start: maze.Point{X: 1}, Goal: maze.Point{X: 2}}}, errors.New("agents must not share a topology cache"), http.StatusInternalServerError, "INTERNAL_ERROR"},
+	}
+	router := setupTestRouter(handler)
+	for _, tc := range cases {
+		mockSimService.On("SolveMulti", ctx, service.SolveMultiRequest{Grid: grid, Agents: tc.agents}).
+			Return(service.SolveMultiResult{}, fmt.Errorf("multi-agent search failed: %w", tc.err))
+
+		bodyBytes, _ := json.Marshal(map[string]any{"grid": grid, "agents": tc.agents})
+		req := httptest.NewRequest("POST", "/simulate/multi", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, tc.code)
+		assert.Contains(t, w.Body.String(), tc.code)
+	}
+	mockSimService.AssertExpectations(t)
+}
@@ -0,0 +1,158 @@
+package httptransport
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/service"
+)
+
+type plannerURI struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+type createPlannerRequest struct {
+	Grid  maze.Grid  `json:"grid" binding:"required,min=1"`
+	Start maze.Point `json:"start" binding:"required"`
+	Goal  maze.Point `json:"goal" binding:"required"`
+	// Movement, Heuristic and Topology are those of /simulate; polar grids
+	// cannot be replanned.
+	Movement  string `json:"movement"`
+	Heuristic string `json:"heuristic"`
+	Topology  string `json:"topology"`
+}
+
+type updatePlannerRequest struct {
+	Changes []algorithm.WallChange `json:"changes" binding:"required,min=1,max=1024"`
+}
+
+type plannerStats struct {
+	PathLength int     `json:"pathLength"`
+	PathCost   float64 `json:"pathCost"`
+	Expanded   int     `json:"expanded"`
+	Touched    int     `json:"touched"`
+	// RerunExpanded and RerunTouched are what planning the same grid from
+	// scratch takes, for comparison with the repair.
+	RerunExpanded int     `json:"rerunExpanded"`
+	RerunTouched  int     `json:"rerunTouched"`
+	ElapsedMs     float64 `json:"elapsedMs"`
+}
+
+type plannerResponse struct {
+	SessionID string       `json:"sessionId"`
+	Found     bool         `json:"found"`
+	Path      []maze.Point `json:"path"`
+	Stats     plannerStats `json:"stats"`
+}
+
+func newPlannerResponse(result service.PlannerSessionResult) plannerResponse {
+	return plannerResponse{
+		SessionID: result.SessionID,
+		Found:     result.Plan.Found,
+		Path:      result.Plan.Path,
+		Stats: plannerStats{
+			PathLength:    result.Plan.PathLength,
+			PathCost:      result.Plan.PathCost,
+			Expanded:      result.Plan.Expanded,
+			Touched:       result.Plan.Touched,
+			RerunExpanded: result.RerunExpanded,
+			RerunTouched:  result.RerunTouched,
+			ElapsedMs:     float64(result.Elapsed) / float64(time.Millisecond),
+		},
+	}
+}
+
+// CreatePlanner handles POST /planner/sessions.
+// It starts an incremental D* Lite planner between start and goal and answers
+// 201 with the session id and the first plan, found or not.
+func (h *Handler) CreatePlanner(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req createPlannerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.bindError(c, "planner request validation failed", err)
+		return
+	}
+
+	result, err := h.simService.CreatePlannerSession(ctx, service.CreatePlannerSessionRequest{
+		Grid:      req.Grid,
+		Start:     req.Start,
+		Goal:      req.Goal,
+		Movement:  req.Movement,
+		Heuristic: req.Heuristic,
+		Topology:  req.Topology,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "planner handler error", err)
+		h.handleError(c, err)
+		return
+	}
+
+	h.logger.Info(ctx, "planner session response sent",
+		log.String("session_id", result.SessionID),
+		log.Bool("found", result.Plan.Found),
+	)
+
+	c.JSON(http.StatusCreated, newPlannerResponse(result))
+}
+
+// UpdatePlanner handles PATCH /planner/sessions/:id.
+// It turns squares into walls or opens them up and repairs the session's
+// plan, reporting the squares the repair expanded and touched next to those a
+// search from scratch would have.
+func (h *Handler) UpdatePlanner(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var uri plannerURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.bindError(c, "replan request validation failed", err)
+		return
+	}
+	var req updatePlannerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.bindError(c, "replan request validation failed", err)
+		return
+	}
+
+	result, err := h.simService.UpdatePlannerSession(ctx, service.UpdatePlannerSessionRequest{
+		SessionID: uri.ID,
+		Changes:   req.Changes,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "replan handler error", err)
+		h.handleError(c, err)
+		return
+	}
+
+	h.logger.Info(ctx, "replan response sent",
+		log.String("session_id", result.SessionID),
+		log.Int("changes", len(req.Changes)),
+		log.Bool("found", result.Plan.Found),
+	)
+
+	c.JSON(http.StatusOK, newPlannerResponse(result))
+}
+
+// DeletePlanner handles DELETE /planner/sessions/:id.
+func (h *Handler) DeletePlanner(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var uri plannerURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.bindError(c, "planner delete request validation failed", err)
+		return
+	}
+
+	if err := h.simService.DeletePlannerSession(ctx, uri.ID); err != nil {
+		h.logger.Error(ctx, "planner delete handler error", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
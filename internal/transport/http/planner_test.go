@@ -0,0 +1,172 @@
+package httptransport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/service"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/transport/http/mocks"
+)
+
+func TestHandler_PlannerSession(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := maze.Grid{{0, 0, 0}, {0, 1, 0}}
+	start, goal := maze.Point{X: 0, Y: 1}, maze.Point{X: 2, Y: 1}
+	changes := []algorithm.WallChange{{Point: maze.Point{X: 1}, Wall: true}}
+
+	mockSimService.On("CreatePlannerSession", ctx, service.CreatePlannerSessionRequest{Grid: grid, Start: start, Goal: goal, Movement: "8-way"}).
+		Return(service.PlannerSessionResult{
+			SessionID:     "abc",
+			Plan:          &algorithm.Replan{Found: true, Path: []maze.Point{start, {X: 0}, {X: 1}, {X: 2}, goal}, PathLength: 4, PathCost: 4, Expanded: 5, Touched: 6},
+			RerunExpanded: 5,
+			RerunTouched:  6,
+			Elapsed:       time.Millisecond,
+		}, nil)
+	mockSimService.On("UpdatePlannerSession", ctx, service.UpdatePlannerSessionRequest{SessionID: "abc", Changes: changes}).
+		Return(service.PlannerSessionResult{
+			SessionID:     "abc",
+			Plan:          &algorithm.Replan{Path: []maze.Point{}, Expanded: 2, Touched: 4},
+			RerunExpanded: 3,
+			RerunTouched:  5,
+		}, nil)
+	mockSimService.On("DeletePlannerSession", ctx, "abc").Return(nil)
+
+	router := setupTestRouter(handler)
+
+	bodyBytes, _ := json.Marshal(map[string]any{"grid": grid, "start": start, "goal": goal, "movement": "8-way"})
+	req := httptest.NewRequest("POST", "/planner/sessions", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp plannerResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "abc", resp.SessionID)
+	assert.True(t, resp.Found)
+	assert.Len(t, resp.Path, 5)
+	assert.Equal(t, 4, resp.Stats.PathLength)
+	assert.Equal(t, 5, resp.Stats.RerunExpanded)
+	assert.Equal(t, 1.0, resp.Stats.ElapsedMs)
+
+	bodyBytes, _ = json.Marshal(map[string]any{"changes": changes})
+	req = httptest.NewRequest("PATCH", "/planner/sessions/abc", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.False(t, resp.Found)
+	assert.Equal(t, 2, resp.Stats.Expanded)
+	assert.Equal(t, 4, resp.Stats.Touched)
+	assert.Equal(t, 5, resp.Stats.RerunTouched)
+	assert.Contains(t, w.Body.String(), `"path":[]`)
+
+	req = httptest.NewRequest("DELETE", "/planner/sessions/abc", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_PlannerSession_InvalidRequest(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	router := setupTestRouter(handler)
+	for _, body := range []map[string]any{
+		{"start": maze.Point{}, "goal": maze.Point{X: 1}},
+		{"grid": maze.Grid{}, "start": maze.Point{}, "goal": maze.Point{X: 1}},
+	} {
+		bodyBytes, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/planner/sessions", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "%v", body)
+	}
+	for _, body := range []map[string]any{
+		{},
+		{"changes": []algorithm.WallChange{}},
+		{"changes": make([]algorithm.WallChange, 1025)},
+	} {
+		bodyBytes, _ := json.Marshal(body)
+		req := httptest.NewRequest("PATCH", "/planner/sessions/abc", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "%v", body)
+	}
+	mockSimService.AssertNotCalled(t, "CreatePlannerSession")
+	mockSimService.AssertNotCalled(t, "UpdatePlannerSession")
+}
+
+func TestHandler_PlannerSession_ServiceErrors(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := maze.Grid{{0, 0, 0}}
+	changes := []algorithm.WallChange{{Point: maze.Point{X: 2}, Wall: true}}
+	mockSimService.On("CreatePlannerSession", ctx, service.CreatePlannerSessionRequest{Grid: grid, Goal: maze.Point{X: 2}, Topology: "polar"}).
+		Return(service.PlannerSessionResult{}, fmt.Errorf("planner session failed: %w", algorithm.ErrUnsupportedTopology))
+	keyed := maze.Grid{{0, maze.Key(1), 0}}
+	mockSimService.On("CreatePlannerSession", ctx, service.CreatePlannerSessionRequest{Grid: keyed, Goal: maze.Point{X: 2}}).
+		Return(service.PlannerSessionResult{}, fmt.Errorf("planner session failed: %w", algorithm.ErrUnsupportedReplanning))
+	mockSimService.On("CreatePlannerSession", ctx, service.CreatePlannerSessionRequest{Grid: grid, Goal: maze.Point{X: 2}, Movement: "8-way", Heuristic: "manhattan"}).
+		Return(service.PlannerSessionResult{}, fmt.Errorf("planner session failed: %w", algorithm.ErrInadmissibleHeuristic))
+	mockSimService.On("UpdatePlannerSession", ctx, service.UpdatePlannerSessionRequest{SessionID: "gone", Changes: changes}).
+		Return(service.PlannerSessionResult{}, service.ErrPlannerSessionNotFound)
+	mockSimService.On("UpdatePlannerSession", ctx, service.UpdatePlannerSessionRequest{SessionID: "abc", Changes: changes}).
+		Return(service.PlannerSessionResult{}, fmt.Errorf("replan failed: %w", algorithm.ErrBlocked))
+	mockSimService.On("DeletePlannerSession", ctx, "gone").Return(service.ErrPlannerSessionNotFound)
+
+	router := setupTestRouter(handler)
+	cases := []struct {
+		method, path string
+		body         any
+		status       int
+		code         string
+	}{
+		{"POST", "/planner/sessions", map[string]any{"grid": grid, "start": maze.Point{}, "goal": maze.Point{X: 2}, "topology": "polar"}, http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"POST", "/planner/sessions", map[string]any{"grid": keyed, "start": maze.Point{}, "goal": maze.Point{X: 2}}, http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"POST", "/planner/sessions", map[string]any{"grid": grid, "start": maze.Point{}, "goal": maze.Point{X: 2}, "movement": "8-way", "heuristic": "manhattan"}, http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"PATCH", "/planner/sessions/gone", map[string]any{"changes": changes}, http.StatusNotFound, "NOT_FOUND"},
+		{"PATCH", "/planner/sessions/abc", map[string]any{"changes": changes}, http.StatusBadRequest, "BLOCKED"},
+		{"DELETE", "/planner/sessions/gone", nil, http.StatusNotFound, "NOT_FOUND"},
+	}
+	for _, tc := range cases {
+		bodyBytes, _ := json.Marshal(tc.body)
+		req := httptest.NewRequest(tc.method, tc.path, bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, "%s %s", tc.method, tc.path)
+		assert.Contains(t, w.Body.String(), tc.code, "%s %s", tc.method, tc.path)
+	}
+	mockSimService.AssertExpectations(t)
+}
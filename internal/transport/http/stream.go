@@ -0,0 +1,229 @@
+package httptransport
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
+	apierrors "github.com/JoshuaPangaribuan/pathfinder/internal/errors"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/service"
+)
+
+// streamBatchSize caps how many search events are packed into one SSE message.
+const streamBatchSize = 64
+
+// SSE event names used by POST /simulate/stream.
+const (
+	sseEventSteps = "steps"
+	sseEventDone  = "done"
+	sseEventError = "error"
+)
+
+type streamDone struct {
+	Found     bool         `json:"found"`
+	Path      []maze.Point `json:"path"`
+	Teleports []int        `json:"teleports,omitempty"`
+	Pickups   []int        `json:"pickups,omitempty"`
+	Breaks    []int        `json:"breaks,omitempty"`
+	// BreakProfile is the outcome for every wall break budget up to the one
+	// asked.
+	BreakProfile []algorithm.BreakBudget `json:"breakProfile,omitempty"`
+	// Times and Obstacles time the path against moving obstacles.
+	Times     []int          `json:"times,omitempty"`
+	Obstacles [][]maze.Point `json:"obstacles,omitempty"`
+	Stats     simulateStats  `json:"stats"`
+}
+
+// sseWriter batches search events into Server-Sent Events messages. Headers are
+// only sent with the first message, so failures that happen before any event is
+// produced can still be answered with a regular JSON error.
+type sseWriter struct {
+	c       *gin.Context
+	batch   []algorithm.Event
+	started bool
+}
+
+func (w *sseWriter) emit(ev algorithm.Event) error {
+	w.batch = append(w.batch, ev)
+	if len(w.batch) < streamBatchSize {
+		return nil
+	}
+	return w.flushBatch()
+}
+
+func (w *sseWriter) flushBatch() error {
+	if len(w.batch) == 0 {
+		return nil
+	}
+	err := w.send(sseEventSteps, w.batch)
+	w.batch = w.batch[:0]
+	return err
+}
+
+// send writes one SSE message and flushes it to the client. Writes block while
+// the client is not reading, which pauses the search that feeds the writer.
+func (w *sseWriter) send(event string, data any) error {
+	if !w.started {
+		header := w.c.Writer.Header()
+		header.Set("Content-Type", "text/event-stream")
+		header.Set("Cache-Control", "no-cache")
+		header.Set("Connection", "keep-alive")
+		header.Set("X-Accel-Buffering", "no")
+		w.c.Status(http.StatusOK)
+		w.started = true
+	}
+
+	w.c.SSEvent(event, data)
+	if err := w.c.Request.Context().Err(); err != nil {
+		return err
+	}
+	w.c.Writer.Flush()
+	return nil
+}
+
+// SimulateStream handles POST /simulate/stream.
+// It accepts the same body as POST /simulate and streams search events as
+// "steps" messages, each holding up to streamBatchSize events, followed by a
+// single "done" message carrying the path and the usual stats. Errors raised
+// after streaming has begun are reported as an "error" message.
+func (h *Handler) SimulateStream(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req simulateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.bindError(c, "simulation stream request validation failed", err)
+		return
+	}
+
+	h.logger.Info(ctx, "simulation stream request received",
+		log.String("algorithm", req.Algorithm),
+		log.Int("grid_height", len(req.Grid)),
+	)
+
+	w := &sseWriter{c: c}
+	simResult, err := h.simService.StreamSimulation(ctx, req.toServiceRequest(), w.emit)
+	if err == nil {
+		err = w.flushBatch()
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			// The client went away; there is nobody left to answer.
+			return
+		}
+		h.logger.Error(ctx, "simulation stream handler error", err)
+		if !w.started {
+			h.handleError(c, err)
+			return
+		}
+		_ = w.send(sseEventError, apierrors.NewInternalError(err.Error()))
+		return
+	}
+
+	if simResult.Result == nil {
+		err := errors.New("simulation returned nil result")
+		h.logger.Error(ctx, "simulation stream returned no result", err)
+		if !w.started {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "simulation returned no result"})
+			return
+		}
+		_ = w.send(sseEventError, apierrors.NewInternalError("simulation returned no result"))
+		return
+	}
+
+	done := streamDone{
+		Found:        simResult.Result.Found,
+		Path:         simResult.Result.Path,
+		Teleports:    simResult.Result.Teleports,
+		Pickups:      simResult.Result.Pickups,
+		Breaks:       simResult.Result.Breaks,
+		BreakProfile: simResult.BreakProfile,
+		Times:        simResult.Result.Times,
+		Obstacles:    simResult.Result.Obstacles,
+		Stats:        newSimulateStats(simResult),
+	}
+	if err := w.send(sseEventDone, done); err != nil {
+		h.logger.Warn(ctx, "simulation stream closed before completion",
+			log.Error(fmt.Errorf("sending done event: %w", err)),
+		)
+		return
+	}
+
+	h.logger.Info(ctx, "simulation stream response sent",
+		log.String("algorithm", req.Algorithm),
+		log.Bool("found", simResult.Result.Found),
+	)
+}
+
+type streamMazeRequest struct {
+	Width  int    `json:"width" binding:"required,min=2,max=1000"`
+	Height int    `json:"height" binding:"required,min=2,max=1000000"`
+	Seed   *int64 `json:"seed"`
+}
+
+// flushWriter pushes every write straight to the client, so each block of rows
+// buffered by maze.WriteRows goes out as its own chunk.
+type flushWriter struct {
+	w gin.ResponseWriter
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.w.Flush()
+	return n, err
+}
+
+// StreamMaze handles POST /maze/stream.
+// It generates a maze with Eller's algorithm and sends it as a chunked
+// text/plain body while it is being built: one line of '0' (passage) and '1'
+// (wall) characters per grid row. The grid size, the seed and the algorithm are
+// sent up front in X-Maze-Width, X-Maze-Height, X-Maze-Seed and
+// X-Maze-Algorithm headers. A body with fewer lines than X-Maze-Height means
+// generation was cut short.
+func (h *Handler) StreamMaze(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req streamMazeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.bindError(c, "maze stream request validation failed", err)
+		return
+	}
+
+	stream, err := h.mazeService.StreamMaze(ctx, service.StreamMazeRequest{
+		Width:  req.Width,
+		Height: req.Height,
+		Seed:   req.Seed,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "maze stream handler error", err)
+		h.handleError(c, err)
+		return
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/plain; charset=utf-8")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+	header.Set("X-Maze-Width", strconv.Itoa(stream.Width))
+	header.Set("X-Maze-Height", strconv.Itoa(stream.Height))
+	header.Set("X-Maze-Seed", strconv.FormatInt(stream.Seed, 10))
+	header.Set("X-Maze-Algorithm", string(stream.Algorithm))
+	c.Status(http.StatusOK)
+
+	if err := maze.WriteRows(flushWriter{w: c.Writer}, stream.Rows); err != nil {
+		if ctx.Err() == nil {
+			h.logger.Error(ctx, "maze stream aborted", err)
+		}
+		return
+	}
+
+	h.logger.Info(ctx, "maze stream response sent",
+		log.Int("width", stream.Width),
+		log.Int("height", stream.Height),
+	)
+}
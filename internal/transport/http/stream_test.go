@@ -0,0 +1,221 @@
+package httptransport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
+	apierrors "github.com/JoshuaPangaribuan/pathfinder/internal/errors"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/service"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/transport/http/mocks"
+)
+
+type sseMessage struct {
+	event string
+	data  string
+}
+
+// parseSSE splits a recorded event stream into its messages
+func parseSSE(t *testing.T, body string) []sseMessage {
+	t.Helper()
+	var messages []sseMessage
+	var current sseMessage
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			current.event = strings.TrimPrefix(line, "event:")
+		case strings.HasPrefix(line, "data:"):
+			current.data = strings.TrimPrefix(line, "data:")
+		case line == "":
+			if current.event != "" {
+				messages = append(messages, current)
+			}
+			current = sseMessage{}
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return messages
+}
+
+func TestHandler_SimulateStream_Success(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := createTestGrid(3, 1, nil)
+	start := maze.Point{X: 0, Y: 0}
+	goal := maze.Point{X: 2, Y: 0}
+
+	events := make([]algorithm.Event, streamBatchSize+3)
+	for i := range events {
+		events[i] = algorithm.Event{Kind: algorithm.EventPop, Point: maze.Point{X: i % 3, Y: 0}}
+	}
+	expectedResult := &algorithm.Result{
+		Found:         true,
+		Path:          []maze.Point{start, {X: 1, Y: 0}, goal},
+		ExpandedNodes: 3,
+		PathLength:    2,
+		PathCost:      2,
+	}
+
+	mockSimService.On("StreamSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "bfs",
+		Grid:      grid,
+		Start:     start,
+		Goal:      goal,
+	}).Return(service.RunSimulationResult{
+		Result:  expectedResult,
+		Elapsed: time.Millisecond * 4,
+	}, nil, events)
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"algorithm": "bfs",
+		"grid":      grid,
+		"start":     start,
+		"goal":      goal,
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/simulate/stream", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+
+	messages := parseSSE(t, w.Body.String())
+	require.Len(t, messages, 3)
+	assert.Equal(t, sseEventSteps, messages[0].event)
+	assert.Equal(t, sseEventSteps, messages[1].event)
+	assert.Equal(t, sseEventDone, messages[2].event)
+
+	var firstBatch, secondBatch []algorithm.Event
+	require.NoError(t, json.Unmarshal([]byte(messages[0].data), &firstBatch))
+	require.NoError(t, json.Unmarshal([]byte(messages[1].data), &secondBatch))
+	assert.Len(t, firstBatch, streamBatchSize)
+	assert.Len(t, secondBatch, 3)
+
+	var done streamDone
+	require.NoError(t, json.Unmarshal([]byte(messages[2].data), &done))
+	assert.True(t, done.Found)
+	assert.Equal(t, expectedResult.Path, done.Path)
+	assert.Equal(t, 2, done.Stats.PathLength)
+	assert.Equal(t, 3, done.Stats.ExpandedNodes)
+	assert.Equal(t, 4.0, done.Stats.ElapsedMs)
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_SimulateStream_ErrorBeforeFirstEvent(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	grid := createTestGrid(3, 3, nil)
+	mockSimService.On("StreamSimulation", ctx, service.RunSimulationRequest{
+		Algorithm: "invalid",
+		Grid:      grid,
+		Start:     maze.Point{X: 0, Y: 0},
+		Goal:      maze.Point{X: 2, Y: 2},
+	}).Return(service.RunSimulationResult{}, apierrors.NewUnknownAlgorithmError("algorithm must be one of: bfs, dfs"), nil)
+
+	router := setupTestRouter(handler)
+
+	reqBody := map[string]any{
+		"algorithm": "invalid",
+		"grid":      grid,
+		"start":     maze.Point{X: 0, Y: 0},
+		"goal":      maze.Point{X: 2, Y: 2},
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/simulate/stream", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	mockSimService.AssertExpectations(t)
+}
+
+func TestHandler_StreamMaze_Success(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	seed := int64(21)
+	mockMazeService.On("StreamMaze", ctx, service.StreamMazeRequest{Width: 4, Height: 3000, Seed: &seed}).Return(service.MazeStream{
+		Width:     9,
+		Height:    6001,
+		Seed:      seed,
+		Algorithm: maze.AlgorithmEller,
+		Rows:      maze.EllerRows(ctx, 4, 3000, &seed),
+	}, nil)
+
+	router := setupTestRouter(handler)
+
+	bodyBytes, _ := json.Marshal(map[string]any{"width": 4, "height": 3000, "seed": seed})
+	req := httptest.NewRequest("POST", "/maze/stream", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "9", w.Header().Get("X-Maze-Width"))
+	assert.Equal(t, "6001", w.Header().Get("X-Maze-Height"))
+	assert.Equal(t, "21", w.Header().Get("X-Maze-Seed"))
+	assert.Equal(t, "eller", w.Header().Get("X-Maze-Algorithm"))
+	assert.True(t, w.Flushed)
+
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	require.Len(t, lines, 6001)
+	assert.Equal(t, "111111111", lines[0])
+	assert.Equal(t, "111111111", lines[len(lines)-1])
+	mockMazeService.AssertExpectations(t)
+}
+
+func TestHandler_StreamMaze_ValidationError(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	router := setupTestRouter(handler)
+
+	bodyBytes, _ := json.Marshal(map[string]any{"width": 100, "height": 2000000})
+	req := httptest.NewRequest("POST", "/maze/stream", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "validation failed")
+	mockMazeService.AssertNotCalled(t, "StreamMaze")
+}
@@ -0,0 +1,136 @@
+package httptransport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/service"
+)
+
+type worldURI struct {
+	Seed int64 `uri:"seed"`
+}
+
+type worldChunkURI struct {
+	Seed int64 `uri:"seed"`
+	X    int   `uri:"cx"`
+	Y    int   `uri:"cy"`
+}
+
+type worldChunkQuery struct {
+	// Size is the chunk side in cells; omitted selects maze.DefaultChunkSize.
+	Size int `form:"size" binding:"omitempty,min=2,max=64"`
+}
+
+type worldSolveRequest struct {
+	ChunkSize     int        `json:"chunkSize" binding:"omitempty,min=2,max=64"`
+	Start         maze.Point `json:"start" binding:"required"`
+	Goal          maze.Point `json:"goal" binding:"required"`
+	Movement      string     `json:"movement"`
+	Heuristic     string     `json:"heuristic"`
+	Weight        *float64   `json:"weight"`
+	MaxExpansions int        `json:"maxExpansions" binding:"omitempty,min=1"`
+}
+
+type worldSolveResponse struct {
+	Found        bool              `json:"found"`
+	Path         []maze.Point      `json:"path"`
+	VisitedOrder []maze.Point      `json:"visitedOrder"`
+	Chunks       []maze.ChunkCoord `json:"chunks"`
+	Stats        simulateStats     `json:"stats"`
+}
+
+// WorldChunk handles GET /world/:seed/chunk/:cx/:cy.
+// It returns one chunk of the infinite world with the given seed. The optional
+// size query parameter sets the chunk side in cells and must match across the
+// chunks of one world.
+func (h *Handler) WorldChunk(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var uri worldChunkURI
+	var query worldChunkQuery
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.bindError(c, "world chunk request validation failed", err)
+		return
+	}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.bindError(c, "world chunk request validation failed", err)
+		return
+	}
+
+	chunk, err := h.mazeService.WorldChunk(ctx, service.WorldChunkRequest{
+		Seed:      uri.Seed,
+		ChunkSize: query.Size,
+		X:         uri.X,
+		Y:         uri.Y,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "world chunk handler error", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, chunk)
+}
+
+// WorldSolve handles POST /world/:seed/solve.
+// It runs A* between two world points, generating chunks only as the search
+// reaches them, and lists those chunks in load order. The search is bounded by
+// maxExpansions; running out answers 422 like an unreachable goal in /simulate.
+func (h *Handler) WorldSolve(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var uri worldURI
+	var req worldSolveRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.bindError(c, "world search request validation failed", err)
+		return
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.bindError(c, "world search request validation failed", err)
+		return
+	}
+
+	simResult, err := h.simService.SolveWorld(ctx, service.SolveWorldRequest{
+		Seed:          uri.Seed,
+		ChunkSize:     req.ChunkSize,
+		Start:         req.Start,
+		Goal:          req.Goal,
+		Movement:      req.Movement,
+		Heuristic:     req.Heuristic,
+		Weight:        req.Weight,
+		MaxExpansions: req.MaxExpansions,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "world search handler error", err)
+		h.handleError(c, err)
+		return
+	}
+
+	result := simResult.Result
+	resp := worldSolveResponse{
+		Found:        result.Found,
+		Path:         result.Path,
+		VisitedOrder: result.VisitedOrder,
+		Chunks:       simResult.Chunks,
+		Stats:        newSimulateStats(service.RunSimulationResult{Result: result, Elapsed: simResult.Elapsed}),
+	}
+	if resp.Path == nil {
+		resp.Path = []maze.Point{}
+	}
+
+	status := http.StatusOK
+	if !result.Found {
+		status = http.StatusUnprocessableEntity
+	}
+
+	h.logger.Info(ctx, "world search response sent",
+		log.Int("status", status),
+		log.Int("chunks_loaded", len(simResult.Chunks)),
+	)
+
+	c.JSON(status, resp)
+}
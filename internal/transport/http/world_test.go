@@ -0,0 +1,118 @@
+package httptransport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JoshuaPangaribuan/pathfinder/internal/algorithm"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/lib/log"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/maze"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/service"
+	"github.com/JoshuaPangaribuan/pathfinder/internal/transport/http/mocks"
+)
+
+func TestHandler_WorldChunk_Success(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	world, err := maze.NewWorld(42, 4)
+	require.NoError(t, err)
+	chunk, err := world.Chunk(ctx, -2, 3)
+	require.NoError(t, err)
+
+	mockMazeService.On("WorldChunk", ctx, service.WorldChunkRequest{Seed: 42, ChunkSize: 4, X: -2, Y: 3}).Return(chunk, nil)
+
+	router := setupTestRouter(handler)
+	req := httptest.NewRequest("GET", "/world/42/chunk/-2/3?size=4", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp maze.Chunk
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, chunk, resp)
+	assert.Contains(t, w.Body.String(), `"coord":{"cx":-2,"cy":3}`)
+	mockMazeService.AssertExpectations(t)
+}
+
+func TestHandler_WorldChunk_InvalidParameters(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	router := setupTestRouter(handler)
+	for _, url := range []string{
+		"/world/abc/chunk/0/0",
+		"/world/1/chunk/x/0",
+		"/world/1/chunk/0/0?size=1",
+		"/world/1/chunk/0/0?size=65",
+	} {
+		req := httptest.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
+	mockMazeService.AssertNotCalled(t, "WorldChunk")
+}
+
+func TestHandler_WorldSolve(t *testing.T) {
+	mockMazeService := new(mocks.MockMazeService)
+	mockSimService := new(mocks.MockSimulationService)
+	logger := log.NewNoOpLogger()
+	handler := NewHandler(mockMazeService, mockSimService, logger)
+
+	ctx := context.Background()
+	start := maze.Point{X: 1, Y: 1}
+	goal := maze.Point{X: 1, Y: 3}
+	path := []maze.Point{start, {X: 1, Y: 2}, goal}
+	chunks := []maze.ChunkCoord{{X: 0, Y: 0}}
+
+	mockSimService.On("SolveWorld", ctx, service.SolveWorldRequest{Seed: 7, Start: start, Goal: goal}).Return(service.SolveWorldResult{
+		Result:  &algorithm.Result{Found: true, Path: path, VisitedOrder: path, ExpandedNodes: 3, PathLength: 2, PathCost: 2, Optimal: true},
+		Chunks:  chunks,
+		Elapsed: time.Millisecond,
+	}, nil)
+	mockSimService.On("SolveWorld", ctx, service.SolveWorldRequest{Seed: 7, Start: start, Goal: goal, MaxExpansions: 1}).Return(service.SolveWorldResult{
+		Result: &algorithm.Result{VisitedOrder: []maze.Point{start}, ExpandedNodes: 1},
+		Chunks: chunks,
+	}, nil)
+
+	router := setupTestRouter(handler)
+
+	bodyBytes, _ := json.Marshal(map[string]any{"start": start, "goal": goal})
+	req := httptest.NewRequest("POST", "/world/7/solve", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp worldSolveResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Found)
+	assert.Equal(t, path, resp.Path)
+	assert.Equal(t, chunks, resp.Chunks)
+	assert.Equal(t, 2, resp.Stats.PathLength)
+
+	bodyBytes, _ = json.Marshal(map[string]any{"start": start, "goal": goal, "maxExpansions": 1})
+	req = httptest.NewRequest("POST", "/world/7/solve", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), `"path":[]`)
+	mockSimService.AssertExpectations(t)
+}
@@ -1,25 +1,43 @@
-import { useEffect, useState } from "react";
+import { useEffect, useMemo, useState } from "react";
 
-import { ControlsPanel, GridCanvas, StatsPanel, ErrorBoundary, ToastContainer } from "@/components";
-import { useSimulationAnimation } from "@/hooks";
+import { ControlsPanel, GridCanvas, StatsPanel, ErrorBoundary, ToastContainer, WorldView } from "@/components";
+import { usePlannerSession, useSimulationAnimation } from "@/hooks";
 import { ToastProvider } from "@/hooks/useToast";
 import { useAppStore } from "@/store/useAppStore";
-import type { Point } from "@/types";
+import { canEditWalls, type Point } from "@/types";
 
-type SelectionMode = "start" | "goal";
+type SelectionMode = "start" | "goal" | "wall";
+type View = "maze" | "world";
 
 const App = () => {
   const [selectionMode, setSelectionMode] = useState<SelectionMode>("start");
+  const [view, setView] = useState<View>("maze");
 
   const maze = useAppStore((state) => state.maze);
+  const floors = useAppStore((state) => state.floors);
+  const stairs = useAppStore((state) => state.stairs);
+  const floor = useAppStore((state) => state.floor);
   const visitedOrder = useAppStore((state) => state.visitedOrder);
+  const visitedSides = useAppStore((state) => state.visitedSides);
   const path = useAppStore((state) => state.path);
+  const teleports = useAppStore((state) => state.teleports);
+  const pickups = useAppStore((state) => state.pickups);
+  const breaks = useAppStore((state) => state.breaks);
+  const obstacleFrames = useAppStore((state) => state.obstacleFrames);
+  const timeStep = useAppStore((state) => state.timeStep);
+  const setTimeStep = useAppStore((state) => state.setTimeStep);
+  const agentPaths = useAppStore((state) => state.agentPaths);
+  const plannerStats = useAppStore((state) => state.plannerStats);
   const start = useAppStore((state) => state.start);
   const goal = useAppStore((state) => state.goal);
+  const seed = useAppStore((state) => state.seed);
+  const topology = useAppStore((state) => state.topology);
   const setStart = useAppStore((state) => state.setStart);
   const setGoal = useAppStore((state) => state.setGoal);
+  const setFloor = useAppStore((state) => state.setFloor);
 
   const { visitedCount, showPath, isAnimating, skip } = useSimulationAnimation();
+  const { toggleWall, error: plannerError } = usePlannerSession();
 
   useEffect(() => {
     if (!start) {
@@ -29,14 +47,37 @@ const App = () => {
     }
   }, [start, goal]);
 
-  const handleSelectCell = (point: Point) => {
+  // A new maze may be one whose walls cannot be replanned around.
+  useEffect(() => {
+    if (!maze || floors.length > 1 || !canEditWalls(maze, topology)) {
+      setSelectionMode((mode) => (mode === "wall" ? "start" : mode));
+    }
+  }, [maze, floors, topology]);
+
+  const multiLevel = floors.length > 1;
+  const timed = obstacleFrames.length > 0;
+  const walker = timed && showPath ? path[Math.min(timeStep, path.length - 1)] ?? null : null;
+  // Agents planned together wait on their goals once they arrive, so the
+  // slider runs up to the last arrival.
+  const makespan = Math.max(0, ...agentPaths.map((agentPath) => agentPath.length - 1));
+  const steps = agentPaths.length > 0 ? makespan + 1 : obstacleFrames.length;
+  const agentWalkers = useMemo(
+    () => agentPaths.map((agentPath) => agentPath[Math.min(timeStep, agentPath.length - 1)] ?? null),
+    [agentPaths, timeStep],
+  );
+
+  const handleSelectCell = (cell: Point) => {
+    if (selectionMode === "wall") {
+      void toggleWall(cell);
+      return;
+    }
     if (selectionMode === "start") {
-      setStart(point);
+      setStart(cell);
       if (!goal) {
         setSelectionMode("goal");
       }
     } else {
-      setGoal(point);
+      setGoal(cell);
     }
   };
 
@@ -59,15 +100,24 @@ const App = () => {
                   Generate perfect mazes and compare BFS, DFS, and A* exploration.
                 </p>
               </div>
-              {isAnimating && (
+              <div className="flex items-center gap-2">
                 <button
                   type="button"
-                  onClick={skip}
+                  onClick={() => setView(view === "maze" ? "world" : "maze")}
                   className="rounded-md border border-slate-700 bg-slate-900 px-4 py-2 text-xs font-medium text-slate-200 transition hover:border-slate-500"
                 >
-                  Skip Animation
+                  {view === "maze" ? "Explore Infinite World" : "Back to Maze"}
                 </button>
-              )}
+                {isAnimating && (
+                  <button
+                    type="button"
+                    onClick={skip}
+                    className="rounded-md border border-slate-700 bg-slate-900 px-4 py-2 text-xs font-medium text-slate-200 transition hover:border-slate-500"
+                  >
+                    Skip Animation
+                  </button>
+                )}
+              </div>
             </div>
           </header>
 
@@ -89,6 +139,7 @@ const App = () => {
                   <h3 className="mb-3 text-sm font-medium text-slate-200">Instructions</h3>
                   <ul className="list-disc space-y-1 pl-5 text-sm text-slate-400">
                     <li>Select the start and goal cells by clicking the maze.</li>
+                    <li>Switch to Edit Walls to toggle walls and watch the path repair itself.</li>
                     <li>Use the control panel to generate new mazes and run algorithms.</li>
                     <li>Adjust animation speed to inspect frontier expansion in detail.</li>
                   </ul>
@@ -97,16 +148,71 @@ const App = () => {
 
               {/* Maze Panel - 70% width on desktop, 70% height on mobile */}
               <div className="order-1 h-[70%] flex min-h-0 items-center justify-center md:order-2 md:h-full md:p-4">
-                <GridCanvas
-                  grid={maze}
-                  visitedOrder={visitedOrder}
-                  visitedCount={visitedCount}
-                  path={path}
-                  showPath={showPath}
-                  start={start}
-                  goal={goal}
-                  onSelectCell={handleSelectCell}
-                />
+                {view === "world" ? (
+                  <WorldView seed={seed ?? 1} />
+                ) : (
+                  <div className="flex h-full w-full flex-col gap-2">
+                    {multiLevel && (
+                      <div className="flex gap-1" role="tablist">
+                        {floors.map((_, z) => (
+                          <button
+                            key={z}
+                            type="button"
+                            role="tab"
+                            aria-selected={z === floor}
+                            onClick={() => setFloor(z)}
+                            className={`rounded-md border px-3 py-1 text-xs font-medium transition ${
+                              z === floor
+                                ? "border-sky-500 bg-sky-500/20 text-sky-200"
+                                : "border-slate-700 bg-slate-900 text-slate-300 hover:border-slate-500"
+                            }`}
+                          >
+                            Floor {z + 1}
+                          </button>
+                        ))}
+                      </div>
+                    )}
+                    {steps > 0 && (
+                      <label className="flex items-center gap-3 text-xs text-slate-300">
+                        Time step {timeStep} / {steps - 1}
+                        <input
+                          type="range"
+                          min={0}
+                          max={steps - 1}
+                          value={timeStep}
+                          onChange={(event) => setTimeStep(Number(event.target.value))}
+                          className="flex-1 accent-rose-500"
+                        />
+                      </label>
+                    )}
+                    {selectionMode === "wall" && plannerError && (
+                      <p className="text-xs text-rose-300">{plannerError}</p>
+                    )}
+                    <div className="min-h-0 flex-1">
+                      <GridCanvas
+                        grid={floors[floor] ?? maze}
+                        topology={topology}
+                        visitedOrder={visitedOrder}
+                        visitedSides={visitedSides}
+                        visitedCount={visitedCount}
+                        path={path}
+                        teleports={teleports}
+                        pickups={pickups}
+                        breaks={breaks}
+                        showPath={showPath || plannerStats !== null}
+                        start={start}
+                        goal={goal}
+                        floor={floor}
+                        stairs={stairs}
+                        obstacles={obstacleFrames[timeStep]}
+                        walker={walker}
+                        agentPaths={agentPaths}
+                        agentWalkers={agentWalkers}
+                        onSelectCell={handleSelectCell}
+                      />
+                    </div>
+                  </div>
+                )}
               </div>
             </div>
           </main>
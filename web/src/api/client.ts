@@ -1,11 +1,18 @@
 import axios from "axios";
 
 import type {
-  Algorithm,
+  AlgorithmInfo,
+  AlgorithmsResponse,
+  CreatePlannerRequest,
   GenerateMazeRequest,
   MazeResponse,
+  PlannerResponse,
+  SimulateMultiRequest,
+  SimulateMultiResponse,
   SimulateRequest,
   SimulateResponse,
+  WallChange,
+  WorldChunk,
 } from "@/types";
 
 export const apiClient = axios.create({
@@ -56,7 +63,77 @@ export const simulate = async (
   return data;
 };
 
-export const isAlgorithm = (value: string): value is Algorithm => {
-  return value === "bfs" || value === "dfs" || value === "astar";
+// simulateMulti resolves with the best partial plan as well when the agents
+// cannot all be planned without collisions, which the server answers with 422.
+export const simulateMulti = async (
+  payload: SimulateMultiRequest,
+  options?: { signal?: AbortSignal }
+): Promise<SimulateMultiResponse> => {
+  const { data } = await apiClient.post<SimulateMultiResponse>(
+    "/simulate/multi",
+    payload,
+    {
+      signal: options?.signal,
+      validateStatus: (status) => status === 200 || status === 422,
+    }
+  );
+  return data;
+};
+
+export const createPlannerSession = async (
+  payload: CreatePlannerRequest,
+  options?: { signal?: AbortSignal }
+): Promise<PlannerResponse> => {
+  const { data } = await apiClient.post<PlannerResponse>("/planner/sessions", payload, {
+    signal: options?.signal,
+  });
+  return data;
+};
+
+export const updatePlannerSession = async (
+  sessionId: string,
+  changes: WallChange[],
+  options?: { signal?: AbortSignal }
+): Promise<PlannerResponse> => {
+  const { data } = await apiClient.patch<PlannerResponse>(
+    `/planner/sessions/${encodeURIComponent(sessionId)}`,
+    { changes },
+    { signal: options?.signal }
+  );
+  return data;
+};
+
+export const deletePlannerSession = async (sessionId: string): Promise<void> => {
+  await apiClient.delete(`/planner/sessions/${encodeURIComponent(sessionId)}`);
+};
+
+export const fetchWorldChunk = async (
+  seed: number,
+  cx: number,
+  cy: number,
+  size?: number,
+  options?: { signal?: AbortSignal }
+): Promise<WorldChunk> => {
+  const { data } = await apiClient.get<WorldChunk>(`/world/${seed}/chunk/${cx}/${cy}`, {
+    params: size ? { size } : undefined,
+    signal: options?.signal,
+  });
+  return data;
+};
+
+export const listAlgorithms = async (
+  options?: { signal?: AbortSignal }
+): Promise<AlgorithmInfo[]> => {
+  const { data } = await apiClient.get<AlgorithmsResponse>("/algorithms", {
+    signal: options?.signal,
+  });
+  return data.algorithms;
+};
+
+export const isAlgorithm = (value: string, algorithms: AlgorithmInfo[]): boolean => {
+  const key = value.toLowerCase();
+  return algorithms.some(
+    (info) => info.name === key || info.aliases.some((alias) => alias.toLowerCase() === key)
+  );
 };
 
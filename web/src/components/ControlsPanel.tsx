@@ -3,9 +3,11 @@ import { useCallback, useMemo, useState, useEffect } from "react";
 import { MazeGenerator, AlgorithmSelector, CellSelector } from "@/components/controls";
 import { useMazeService, useSimulationService } from "@/hooks";
 import { useAppStore } from "@/store/useAppStore";
-import type { GenerateMazeRequest } from "@/types";
+import { WRAP_ALGORITHMS, type GenerateMazeRequest, type MazeAlgorithm, type Topology } from "@/types";
+import { agents as planAgents } from "@/utils/agents";
+import { patrols as placePatrols } from "@/utils/patrols";
 
-type SelectionMode = "start" | "goal";
+type SelectionMode = "start" | "goal" | "wall";
 
 interface ControlsPanelProps {
   selectionMode: SelectionMode;
@@ -47,17 +49,29 @@ export const ControlsPanel = ({
   onRunComplete,
 }: ControlsPanelProps) => {
   const maze = useAppStore((state) => state.maze);
+  const floors = useAppStore((state) => state.floors);
+  const stairs = useAppStore((state) => state.stairs);
   const start = useAppStore((state) => state.start);
   const goal = useAppStore((state) => state.goal);
+  const topology = useAppStore((state) => state.topology);
   const algorithm = useAppStore((state) => state.algorithm);
+  const wallBreaks = useAppStore((state) => state.wallBreaks);
+  const patrols = useAppStore((state) => state.patrols);
+  const agents = useAppStore((state) => state.agents);
   const resetSimulation = useAppStore((state) => state.resetSimulation);
 
   const { generateMaze, isGenerating, error: mazeError } = useMazeService();
-  const { runSimulation, isRunning, error: simError } = useSimulationService();
+  const { runSimulation, runMulti, isRunning, error: simError } = useSimulationService();
 
   const [width, setWidth] = useState<number>(defaultDimensions.width);
   const [height, setHeight] = useState<number>(defaultDimensions.height);
   const [seed, setSeed] = useState<string>("");
+  const [mazeAlgorithm, setMazeAlgorithm] = useState<MazeAlgorithm>("backtracker");
+  const [mazeTopology, setMazeTopology] = useState<Topology>("square");
+  const [braid, setBraid] = useState<number>(0);
+  const [levels, setLevels] = useState<number>(1);
+  const [portals, setPortals] = useState<number>(0);
+  const [keys, setKeys] = useState<number>(0);
   const [maxDimensions, setMaxDimensions] = useState(getMaxDimensions);
   const [error, setError] = useState<string | null>(null);
   const [successMessage, setSuccessMessage] = useState<string | null>(null);
@@ -76,7 +90,7 @@ export const ControlsPanel = ({
     if (!maze || !start || !goal) {
       return false;
     }
-    if (start.x === goal.x && start.y === goal.y) {
+    if (start.x === goal.x && start.y === goal.y && start.z === goal.z) {
       return false;
     }
     return true;
@@ -89,10 +103,29 @@ export const ControlsPanel = ({
     setError(null);
     setSuccessMessage(null);
 
+    // Hex, polar and multi-level mazes only come from the backtracker and cannot
+    // be braided; multi-level mazes are always square. Torus mazes need a
+    // generator that carves across the seams.
+    const multiLevel = levels > 1;
+    const wrap = mazeTopology === "torus" && !multiLevel;
+    const backtrackerOnly = (mazeTopology !== "square" && !wrap) || multiLevel;
     const payload: GenerateMazeRequest = {
       width: Math.max(2, Math.floor(width)),
       height: Math.max(2, Math.floor(height)),
+      algorithm: backtrackerOnly || (wrap && !WRAP_ALGORITHMS.includes(mazeAlgorithm)) ? "backtracker" : mazeAlgorithm,
+      topology: multiLevel ? "square" : mazeTopology,
+      braid: backtrackerOnly ? 0 : braid,
     };
+    if (multiLevel) {
+      payload.levels = levels;
+    } else {
+      if (portals > 0) {
+        payload.portals = portals;
+      }
+      if (keys > 0 && (mazeTopology === "square" || mazeTopology === "torus")) {
+        payload.keys = keys;
+      }
+    }
     if (seed.trim() !== "") {
       const parsedSeed = Number(seed);
       if (!Number.isFinite(parsedSeed)) {
@@ -109,14 +142,14 @@ export const ControlsPanel = ({
       // Error already handled in service hook
       setError(mazeError);
     }
-  }, [height, isGenerating, seed, width, generateMaze, mazeError]);
+  }, [height, isGenerating, seed, mazeAlgorithm, mazeTopology, braid, levels, portals, keys, width, generateMaze, mazeError]);
 
   const handleRun = useCallback(async () => {
     if (!maze || !start || !goal) {
       setError("Please select start and goal cells");
       return;
     }
-    if (start.x === goal.x && start.y === goal.y) {
+    if (start.x === goal.x && start.y === goal.y && start.z === goal.z) {
       setError("Start and goal must be different cells");
       return;
     }
@@ -129,12 +162,39 @@ export const ControlsPanel = ({
     setSuccessMessage(null);
     onRunStart?.();
 
+    // Several agents on a single floor are planned together by CBS, which
+    // ignores the algorithm, wall breaks and moving obstacles.
+    if (agents > 1 && floors.length <= 1) {
+      try {
+        const result = await runMulti({ grid: maze, agents: planAgents(maze, agents, start, goal), topology });
+        if (result) {
+          setSuccessMessage(
+            result.found
+              ? "Agents planned without collisions"
+              : "No collision-free plan found; showing the best partial plan",
+          );
+          onRunComplete?.(result.found);
+        }
+      } catch {
+        // Error already handled in service hook
+        setError(simError);
+        onRunComplete?.(false);
+      }
+      return;
+    }
+
+    // Patrols only walk square single-floor mazes.
+    const obstacles = patrols > 0 && floors.length <= 1 && topology === "square" ? placePatrols(maze, patrols, start) : [];
+
     try {
       await runSimulation({
         algorithm,
-        grid: maze,
+        ...(floors.length > 1 ? { floors, stairs } : { grid: maze }),
         start,
         goal,
+        topology,
+        ...(wallBreaks > 0 ? { wallBreaks } : {}),
+        ...(obstacles.length > 0 ? { obstacles } : {}),
       });
       // Access the result from store after simulation completes
       const result = useAppStore.getState().resultsByAlgorithm[algorithm];
@@ -149,7 +209,7 @@ export const ControlsPanel = ({
       setError(simError);
       onRunComplete?.(false);
     }
-  }, [algorithm, goal, isRunning, maze, onRunComplete, onRunStart, resetSimulation, runSimulation, simError, start]);
+  }, [agents, algorithm, floors, goal, isRunning, maze, onRunComplete, onRunStart, resetSimulation, runMulti, runSimulation, patrols, simError, stairs, start, topology, wallBreaks]);
 
   return (
     <section className="flex flex-col gap-4">
@@ -169,11 +229,23 @@ export const ControlsPanel = ({
               width={width}
               height={height}
               seed={seed}
+              algorithm={mazeAlgorithm}
+              topology={mazeTopology}
+              braid={braid}
+              levels={levels}
+              portals={portals}
+              keys={keys}
               maxWidth={maxDimensions.width}
               maxHeight={maxDimensions.height}
               onWidthChange={setWidth}
               onHeightChange={setHeight}
               onSeedChange={setSeed}
+              onAlgorithmChange={setMazeAlgorithm}
+              onTopologyChange={setMazeTopology}
+              onBraidChange={setBraid}
+              onLevelsChange={setLevels}
+              onPortalsChange={setPortals}
+              onKeysChange={setKeys}
               onGenerate={handleGenerate}
               isGenerating={isGenerating}
             />
@@ -232,11 +304,23 @@ export const ControlsPanel = ({
             width={width}
             height={height}
             seed={seed}
+            algorithm={mazeAlgorithm}
+            topology={mazeTopology}
+            braid={braid}
+            levels={levels}
+            portals={portals}
+            keys={keys}
             maxWidth={maxDimensions.width}
             maxHeight={maxDimensions.height}
             onWidthChange={setWidth}
             onHeightChange={setHeight}
             onSeedChange={setSeed}
+            onAlgorithmChange={setMazeAlgorithm}
+            onTopologyChange={setMazeTopology}
+            onBraidChange={setBraid}
+            onLevelsChange={setLevels}
+            onPortalsChange={setPortals}
+            onKeysChange={setKeys}
             onGenerate={handleGenerate}
             isGenerating={isGenerating}
           />
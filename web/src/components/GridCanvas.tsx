@@ -2,27 +2,64 @@ import { useEffect, useMemo, useRef, useState } from "react";
 
 import { useCanvasEventHandlers } from "@/hooks/useCanvasEventHandlers";
 import { useCanvasRenderer } from "@/hooks/useCanvasRenderer";
-import type { Grid, Point } from "@/types";
+import type { Grid, Point, SearchSide, Topology } from "@/types";
 
 interface GridCanvasProps {
   grid: Grid | null;
+  topology?: Topology;
   visitedOrder: Point[];
+  visitedSides?: SearchSide[];
   visitedCount: number;
   path: Point[];
+  // teleports are the indices of the path points reached through a portal.
+  teleports?: number[];
+  // pickups are the indices of the path points where a key is picked up.
+  pickups?: number[];
+  // breaks are the indices of the path points that are broken walls.
+  breaks?: number[];
   showPath: boolean;
   start: Point | null;
   goal: Point | null;
+  // floor and stairs are set for multi-level mazes; grid is then the floor shown.
+  floor?: number;
+  stairs?: Point[];
+  // obstacles and walker show the moving obstacles and the path at one time step.
+  obstacles?: Point[];
+  walker?: Point | null;
+  // agentPaths and agentWalkers show agents planned together and where each
+  // stands at one time step.
+  agentPaths?: Point[][];
+  agentWalkers?: (Point | null)[];
   onSelectCell?: (point: Point) => void;
 }
 
+const NO_STAIRS: Point[] = [];
+const NO_TELEPORTS: number[] = [];
+const NO_PICKUPS: number[] = [];
+const NO_BREAKS: number[] = [];
+const NO_OBSTACLES: Point[] = [];
+const NO_AGENT_PATHS: Point[][] = [];
+const NO_AGENT_WALKERS: (Point | null)[] = [];
+
 export const GridCanvas = ({
   grid,
+  topology = "square",
   visitedOrder,
+  visitedSides,
   visitedCount,
   path,
+  teleports = NO_TELEPORTS,
+  pickups = NO_PICKUPS,
+  breaks = NO_BREAKS,
   showPath,
   start,
   goal,
+  floor = 0,
+  stairs = NO_STAIRS,
+  obstacles = NO_OBSTACLES,
+  walker = null,
+  agentPaths = NO_AGENT_PATHS,
+  agentWalkers = NO_AGENT_WALKERS,
   onSelectCell,
 }: GridCanvasProps) => {
   const containerRef = useRef<HTMLDivElement | null>(null);
@@ -69,18 +106,30 @@ export const GridCanvas = ({
 
   const { canvasRef, contextRef, cellGeometryRef, dprRef, prevVisitedCountRef, drawVisitedRange, drawPathOverlay, drawMarkers, drawHoverEffect, redrawAll } = useCanvasRenderer({
     grid,
+    topology,
     dimensions,
     visitedOrder,
+    visitedSides,
     visitedCount,
     path,
+    teleports,
+    pickups,
+    breaks,
     showPath,
     start,
     goal,
     hoveredCell,
+    floor,
+    stairs,
+    obstacles,
+    walker,
+    agentPaths,
+    agentWalkers,
   });
 
   const { handleCanvasClick, handleCanvasMouseMove, handleCanvasMouseLeave } = useCanvasEventHandlers({
     grid,
+    floor,
     onSelectCell,
     setHoveredCell,
     cellGeometryRef,
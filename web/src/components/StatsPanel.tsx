@@ -3,27 +3,99 @@ import { useMemo } from "react";
 import { useAppStore, type StoredSimulation } from "@/store/useAppStore";
 import type { Algorithm } from "@/types";
 
-const LABELS = {
-  bfs: "Breadth-First Search",
-  dfs: "Depth-First Search",
-  astar: "A* Search",
-} as const;
-
 const formatNumber = (value: number) => new Intl.NumberFormat().format(value);
 const formatMs = (value: number) => `${value.toFixed(2)} ms`;
 
 export const StatsPanel = () => {
   const results = useAppStore((state) => state.resultsByAlgorithm);
+  const algorithms = useAppStore((state) => state.algorithms);
+  const multiStats = useAppStore((state) => state.multiStats);
+  const agentPaths = useAppStore((state) => state.agentPaths);
+  const agentConflicts = useAppStore((state) => state.agentConflicts);
+  const plannerStats = useAppStore((state) => state.plannerStats);
+  const path = useAppStore((state) => state.path);
+
+  const labels = useMemo(
+    () => Object.fromEntries(algorithms.map((info) => [info.name, info.label])),
+    [algorithms]
+  );
 
   const entries = useMemo(() => {
-    const order: Algorithm[] = ["bfs", "dfs", "astar"];
+    const order: Algorithm[] = algorithms.map((info) => info.name);
     return order
       .map((algorithm) => {
         const payload = results[algorithm];
         return payload ? ([algorithm, payload] as [Algorithm, StoredSimulation]) : null;
       })
       .filter((entry): entry is [Algorithm, StoredSimulation] => entry !== null);
-  }, [results]);
+  }, [algorithms, results]);
+
+  if (plannerStats) {
+    const rows: [string, string][] = [
+      ["Path Length", formatNumber(plannerStats.pathLength)],
+      ["Path Cost", plannerStats.pathCost.toFixed(2)],
+      ["Expanded", `${formatNumber(plannerStats.expanded)} / ${formatNumber(plannerStats.rerunExpanded)}`],
+      ["Touched", `${formatNumber(plannerStats.touched)} / ${formatNumber(plannerStats.rerunTouched)}`],
+      ["Elapsed", formatMs(plannerStats.elapsedMs)],
+    ];
+    return (
+      <section className="rounded-xl border border-slate-800 bg-slate-950/70 p-6">
+        <h2 className="mb-1 text-lg font-semibold text-slate-100">Incremental Replan</h2>
+        <p className="mb-4 text-xs text-slate-400">Squares the D* Lite repair needed against a full rerun.</p>
+        <dl className="grid grid-cols-2 gap-2 text-sm text-slate-200">
+          {rows.map(([label, value]) => (
+            <div key={label} className="contents">
+              <dt className="text-slate-400">{label}</dt>
+              <dd className="text-right">{value}</dd>
+            </div>
+          ))}
+        </dl>
+        {path.length === 0 && (
+          <p className="mt-4 text-sm font-semibold text-rose-300">The walls cut the goal off from the start.</p>
+        )}
+      </section>
+    );
+  }
+
+  if (multiStats) {
+    const rows: [string, string][] = [
+      ["Agents", formatNumber(agentPaths.length)],
+      ["Sum of Costs", formatNumber(multiStats.sumOfCosts)],
+      ["Makespan", formatNumber(multiStats.makespan)],
+      ["Constraint Tree Nodes", formatNumber(multiStats.expandedNodes)],
+      ["Low-Level Expansions", formatNumber(multiStats.lowLevelExpansions)],
+      ["Elapsed", formatMs(multiStats.elapsedMs)],
+    ];
+    return (
+      <section className="rounded-xl border border-slate-800 bg-slate-950/70 p-6">
+        <h2 className="mb-4 text-lg font-semibold text-slate-100">Multi-Agent Plan</h2>
+        <dl className="grid grid-cols-2 gap-2 text-sm text-slate-200">
+          {rows.map(([label, value]) => (
+            <div key={label} className="contents">
+              <dt className="text-slate-400">{label}</dt>
+              <dd className="text-right">{value}</dd>
+            </div>
+          ))}
+        </dl>
+        {agentConflicts.length > 0 && (
+          <div className="mt-4">
+            <h3 className="mb-2 text-sm font-semibold text-rose-300">
+              {multiStats.limitReached ? "Best partial plan at the expansion limit" : "No collision-free plan"}
+            </h3>
+            <ul className="space-y-1 text-xs text-slate-300">
+              {agentConflicts.map((conflict) => (
+                <li key={`${conflict.agents[0]}-${conflict.agents[1]}`}>
+                  Agents {conflict.agents[0] + 1} and {conflict.agents[1] + 1}{" "}
+                  {conflict.kind === "vertex" ? "meet at" : "swap at"} ({conflict.point.x}, {conflict.point.y}), step{" "}
+                  {conflict.time}
+                </li>
+              ))}
+            </ul>
+          </div>
+        )}
+      </section>
+    );
+  }
 
   if (!entries.length) {
     return (
@@ -59,7 +131,7 @@ export const StatsPanel = () => {
 
                 return (
                   <tr key={algorithm}>
-                    <td className="px-4 py-3 font-medium text-slate-100">{LABELS[algorithm]}</td>
+                    <td className="px-4 py-3 font-medium text-slate-100">{labels[algorithm] ?? algorithm}</td>
                     <td className="px-4 py-3 text-right">{formatNumber(stats.pathLength)}</td>
                     <td className="px-4 py-3 text-right">{formatNumber(stats.expandedNodes)}</td>
                     <td className="px-4 py-3 text-right">{formatMs(stats.elapsedMs)}</td>
@@ -81,6 +153,29 @@ export const StatsPanel = () => {
           </table>
         </div>
       </div>
+      {entries.some(([, payload]) => payload.result.breakProfile?.length) && (
+        <div className="mt-6">
+          <h3 className="mb-2 text-sm font-semibold text-slate-100">Path Length by Wall Breaks</h3>
+          <ul className="space-y-2 text-xs text-slate-300">
+            {entries.map(([algorithm, payload]) => {
+              const profile = payload.result.breakProfile;
+              if (!profile?.length) {
+                return null;
+              }
+              return (
+                <li key={algorithm} className="flex flex-wrap items-center gap-2">
+                  <span className="font-medium text-slate-100">{labels[algorithm] ?? algorithm}</span>
+                  {profile.map((budget) => (
+                    <span key={budget.breaks} className="rounded-md bg-slate-900/70 px-2 py-1">
+                      {budget.breaks}: {budget.found ? formatNumber(budget.pathLength) : "no path"}
+                    </span>
+                  ))}
+                </li>
+              );
+            })}
+          </ul>
+        </div>
+      )}
     </section>
   );
 };
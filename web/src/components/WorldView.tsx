@@ -0,0 +1,145 @@
+import { useCallback, useEffect, useRef, useState } from "react";
+import type { PointerEvent as ReactPointerEvent } from "react";
+
+import { fetchWorldChunk } from "@/api";
+import type { Grid, Pixel } from "@/types";
+
+const COLORS = {
+  wall: "#0f172a",
+  space: "#1e293b",
+  loading: "#111827",
+} as const;
+
+// Side of one grid square on screen, in CSS pixels.
+const SQUARE_SIZE = 8;
+// Chunk side in cells; a chunk grid is twice as many squares per side.
+const CHUNK_SIZE = 16;
+const CHUNK_SQUARES = CHUNK_SIZE * 2;
+
+interface WorldViewProps {
+  seed: number;
+}
+
+const chunkKey = (cx: number, cy: number) => `${cx},${cy}`;
+
+// WorldView shows the infinite world of a seed. Dragging pans the view, and the
+// chunks that scroll into sight are fetched on demand and cached.
+export const WorldView = ({ seed }: WorldViewProps) => {
+  const containerRef = useRef<HTMLDivElement | null>(null);
+  const canvasRef = useRef<HTMLCanvasElement | null>(null);
+  const chunksRef = useRef(new Map<string, Grid>());
+  const pendingRef = useRef(new Set<string>());
+  const dragRef = useRef<Pixel | null>(null);
+  // World pixel shown at the top-left corner of the canvas.
+  const [offset, setOffset] = useState<Pixel>({ x: 0, y: 0 });
+  const [loadedCount, setLoadedCount] = useState(0);
+
+  useEffect(() => {
+    chunksRef.current.clear();
+    pendingRef.current.clear();
+    setLoadedCount(0);
+  }, [seed]);
+
+  const draw = useCallback(() => {
+    const canvas = canvasRef.current;
+    const container = containerRef.current;
+    const context = canvas?.getContext("2d");
+    if (!canvas || !container || !context) {
+      return;
+    }
+
+    const width = container.clientWidth;
+    const height = container.clientHeight;
+    if (canvas.width !== width || canvas.height !== height) {
+      canvas.width = width;
+      canvas.height = height;
+    }
+
+    const chunkPixels = CHUNK_SQUARES * SQUARE_SIZE;
+    const firstX = Math.floor(offset.x / chunkPixels);
+    const firstY = Math.floor(offset.y / chunkPixels);
+    const lastX = Math.floor((offset.x + width) / chunkPixels);
+    const lastY = Math.floor((offset.y + height) / chunkPixels);
+
+    for (let cy = firstY; cy <= lastY; cy++) {
+      for (let cx = firstX; cx <= lastX; cx++) {
+        const left = cx * chunkPixels - offset.x;
+        const top = cy * chunkPixels - offset.y;
+        const grid = chunksRef.current.get(chunkKey(cx, cy));
+        if (!grid) {
+          context.fillStyle = COLORS.loading;
+          context.fillRect(left, top, chunkPixels, chunkPixels);
+          continue;
+        }
+        grid.forEach((row, y) => {
+          row.forEach((value, x) => {
+            context.fillStyle = value === 1 ? COLORS.wall : COLORS.space;
+            context.fillRect(left + x * SQUARE_SIZE, top + y * SQUARE_SIZE, SQUARE_SIZE, SQUARE_SIZE);
+          });
+        });
+      }
+    }
+
+    for (let cy = firstY; cy <= lastY; cy++) {
+      for (let cx = firstX; cx <= lastX; cx++) {
+        const key = chunkKey(cx, cy);
+        if (chunksRef.current.has(key) || pendingRef.current.has(key)) {
+          continue;
+        }
+        pendingRef.current.add(key);
+        fetchWorldChunk(seed, cx, cy, CHUNK_SIZE)
+          .then((chunk) => {
+            chunksRef.current.set(key, chunk.grid);
+            setLoadedCount(chunksRef.current.size);
+          })
+          .finally(() => pendingRef.current.delete(key));
+      }
+    }
+  }, [offset, seed]);
+
+  useEffect(() => {
+    draw();
+  }, [draw, loadedCount]);
+
+  useEffect(() => {
+    window.addEventListener("resize", draw);
+    return () => window.removeEventListener("resize", draw);
+  }, [draw]);
+
+  const handlePointerDown = (event: ReactPointerEvent<HTMLCanvasElement>) => {
+    event.currentTarget.setPointerCapture(event.pointerId);
+    dragRef.current = { x: event.clientX, y: event.clientY };
+  };
+
+  const handlePointerMove = (event: ReactPointerEvent<HTMLCanvasElement>) => {
+    const last = dragRef.current;
+    if (!last) {
+      return;
+    }
+    dragRef.current = { x: event.clientX, y: event.clientY };
+    setOffset((current) => ({
+      x: current.x - (event.clientX - last.x),
+      y: current.y - (event.clientY - last.y),
+    }));
+  };
+
+  const handlePointerUp = () => {
+    dragRef.current = null;
+  };
+
+  return (
+    <div ref={containerRef} className="relative h-full w-full overflow-hidden rounded-xl border border-slate-800">
+      <canvas
+        ref={canvasRef}
+        className="cursor-grab active:cursor-grabbing"
+        onPointerDown={handlePointerDown}
+        onPointerMove={handlePointerMove}
+        onPointerUp={handlePointerUp}
+        onPointerCancel={handlePointerUp}
+      />
+      <span className="pointer-events-none absolute left-3 top-3 rounded bg-slate-950/80 px-2 py-1 text-xs text-slate-300">
+        Seed {seed} · {loadedCount} chunks loaded · drag to pan
+      </span>
+    </div>
+  );
+};
@@ -1,12 +1,7 @@
-import { type ChangeEvent } from "react";
+import { useEffect, type ChangeEvent } from "react";
+import { listAlgorithms } from "@/api";
 import { useAppStore } from "@/store/useAppStore";
-import type { Algorithm } from "@/types";
-
-const algorithmLabel: Record<Algorithm, string> = {
-  bfs: "Breadth-First Search",
-  dfs: "Depth-First Search",
-  astar: "A* Search",
-};
+import { MAX_AGENTS, MAX_PATROLS, MAX_WALL_BREAKS, hasKeys, type Algorithm } from "@/types";
 
 interface AlgorithmSelectorProps {
   className?: string;
@@ -14,9 +9,33 @@ interface AlgorithmSelectorProps {
 
 export const AlgorithmSelector = ({ className = "" }: AlgorithmSelectorProps) => {
   const algorithm = useAppStore((state) => state.algorithm);
+  const algorithms = useAppStore((state) => state.algorithms);
+  const topology = useAppStore((state) => state.topology);
+  const multiLevel = useAppStore((state) => state.floors.length > 1);
+  const locked = useAppStore((state) => (state.maze ? hasKeys(state.maze) : false));
+  const setAlgorithms = useAppStore((state) => state.setAlgorithms);
   const animationSpeed = useAppStore((state) => state.animationSpeed);
   const setAlgorithm = useAppStore((state) => state.setAlgorithm);
   const setAnimationSpeed = useAppStore((state) => state.setAnimationSpeed);
+  const wallBreaks = useAppStore((state) => state.wallBreaks);
+  const setWallBreaks = useAppStore((state) => state.setWallBreaks);
+  const patrols = useAppStore((state) => state.patrols);
+  const setPatrols = useAppStore((state) => state.setPatrols);
+  const agents = useAppStore((state) => state.agents);
+  const setAgents = useAppStore((state) => state.setAgents);
+
+  useEffect(() => {
+    if (algorithms.length) {
+      return;
+    }
+    const controller = new AbortController();
+    listAlgorithms({ signal: controller.signal })
+      .then(setAlgorithms)
+      .catch(() => {
+        // Leave the list empty; the selector stays on the default algorithm
+      });
+    return () => controller.abort();
+  }, [algorithms.length, setAlgorithms]);
 
   const handleAlgorithmChange = (event: ChangeEvent<HTMLSelectElement>) => {
     const value = event.target.value as Algorithm;
@@ -28,6 +47,21 @@ export const AlgorithmSelector = ({ className = "" }: AlgorithmSelectorProps) =>
     setAnimationSpeed(value);
   };
 
+  const handleWallBreaksChange = (event: ChangeEvent<HTMLInputElement>) => {
+    const value = Number(event.target.value);
+    setWallBreaks(Math.min(MAX_WALL_BREAKS, Number.isFinite(value) ? value : 0));
+  };
+
+  const handlePatrolsChange = (event: ChangeEvent<HTMLInputElement>) => {
+    const value = Number(event.target.value);
+    setPatrols(Math.min(MAX_PATROLS, Number.isFinite(value) ? value : 0));
+  };
+
+  const handleAgentsChange = (event: ChangeEvent<HTMLInputElement>) => {
+    const value = Number(event.target.value);
+    setAgents(Math.min(MAX_AGENTS, Number.isFinite(value) ? value : 1));
+  };
+
   return (
     <div className={`space-y-4 ${className}`}>
       <h3 className="text-lg font-semibold text-slate-100">Algorithm Settings</h3>
@@ -38,13 +72,48 @@ export const AlgorithmSelector = ({ className = "" }: AlgorithmSelectorProps) =>
           onChange={handleAlgorithmChange}
           className="rounded-md border border-slate-700 bg-slate-900 px-3 py-2 text-sm text-slate-100 focus:border-sky-500 focus:outline-none focus:ring focus:ring-sky-500/20"
         >
-          {Object.entries(algorithmLabel).map(([key, label]) => (
-            <option key={key} value={key}>
-              {label}
+          {algorithms.map((info) => (
+            <option key={info.name} value={info.name} disabled={!info.topologies.includes(topology) || (multiLevel && !info.multiLevel) || (locked && !info.keys) || (wallBreaks > 0 && !info.wallBreaks) || (patrols > 0 && !info.movingObstacles)}>
+              {info.label}
             </option>
           ))}
         </select>
       </label>
+      <label className="flex flex-col gap-2 text-sm text-slate-300">
+        Wall breaks
+        <input
+          type="number"
+          min={0}
+          max={MAX_WALL_BREAKS}
+          value={wallBreaks}
+          onChange={handleWallBreaksChange}
+          className="rounded-md border border-slate-700 bg-slate-900 px-3 py-2 text-sm text-slate-100 focus:border-sky-500 focus:outline-none focus:ring focus:ring-sky-500/20"
+        />
+      </label>
+      <label className="flex flex-col gap-2 text-sm text-slate-300">
+        Moving obstacles
+        <input
+          type="number"
+          min={0}
+          max={MAX_PATROLS}
+          value={patrols}
+          disabled={multiLevel || topology !== "square"}
+          onChange={handlePatrolsChange}
+          className="rounded-md border border-slate-700 bg-slate-900 px-3 py-2 text-sm text-slate-100 focus:border-sky-500 focus:outline-none focus:ring focus:ring-sky-500/20 disabled:cursor-not-allowed disabled:text-slate-500"
+        />
+      </label>
+      <label className="flex flex-col gap-2 text-sm text-slate-300">
+        Agents
+        <input
+          type="number"
+          min={1}
+          max={MAX_AGENTS}
+          value={agents}
+          disabled={multiLevel || locked}
+          onChange={handleAgentsChange}
+          className="rounded-md border border-slate-700 bg-slate-900 px-3 py-2 text-sm text-slate-100 focus:border-sky-500 focus:outline-none focus:ring focus:ring-sky-500/20 disabled:cursor-not-allowed disabled:text-slate-500"
+        />
+      </label>
       <div className="flex flex-col gap-2">
         <span className="text-sm text-slate-300">Animation Speed ({animationSpeed} ms)</span>
         <input
@@ -1,6 +1,7 @@
 import { useAppStore } from "@/store/useAppStore";
+import { canEditWalls } from "@/types";
 
-type SelectionMode = "start" | "goal";
+type SelectionMode = "start" | "goal" | "wall";
 
 interface CellSelectorProps {
   selectionMode: SelectionMode;
@@ -15,6 +16,11 @@ export const CellSelector = ({
 }: CellSelectorProps) => {
   const start = useAppStore((state) => state.start);
   const goal = useAppStore((state) => state.goal);
+  const maze = useAppStore((state) => state.maze);
+  const floors = useAppStore((state) => state.floors);
+  const topology = useAppStore((state) => state.topology);
+  // Walls are replanned around on single-floor mazes without portals or keys.
+  const wallsEditable = maze !== null && floors.length <= 1 && canEditWalls(maze, topology);
 
   const handleSelectionModeToggle = (mode: SelectionMode) => () => {
     onSelectionModeChange(mode);
@@ -48,6 +54,23 @@ export const CellSelector = ({
             Set Goal
           </button>
         </div>
+        <button
+          type="button"
+          onClick={handleSelectionModeToggle("wall")}
+          disabled={!wallsEditable || !start || !goal}
+          className={`rounded-md border px-3 py-2 text-sm font-medium disabled:cursor-not-allowed disabled:opacity-50 ${
+            selectionMode === "wall"
+              ? "border-amber-500 bg-amber-500/10 text-amber-300"
+              : "border-slate-700 bg-slate-900 text-slate-300 hover:border-slate-500"
+          }`}
+        >
+          Edit Walls
+        </button>
+        {selectionMode === "wall" && (
+          <p className="text-center text-xs text-slate-400">
+            Click squares to toggle walls; the path is repaired incrementally with D* Lite.
+          </p>
+        )}
         <div className="text-center text-sm text-slate-400">
           Start: {start ? `(${start.x}, ${start.y})` : "--"} | Goal: {goal ? `(${goal.x}, ${goal.y})` : "--"}
         </div>
@@ -1,51 +1,150 @@
+import type { ChangeEvent } from "react";
+
 import { DimensionInput, SeedInput } from "@/components/forms";
+import { MAX_KEYS, MAX_PORTALS, MAZE_ALGORITHMS, WRAP_ALGORITHMS, type MazeAlgorithm, type Topology } from "@/types";
 
 interface MazeGeneratorProps {
   width: number;
   height: number;
   seed: string;
+  algorithm: MazeAlgorithm;
+  topology: Topology;
+  braid: number;
+  levels: number;
+  portals: number;
+  keys: number;
   maxWidth: number;
   maxHeight: number;
   onWidthChange: (width: number) => void;
   onHeightChange: (height: number) => void;
   onSeedChange: (seed: string) => void;
+  onAlgorithmChange: (algorithm: MazeAlgorithm) => void;
+  onTopologyChange: (topology: Topology) => void;
+  onBraidChange: (braid: number) => void;
+  onLevelsChange: (levels: number) => void;
+  onPortalsChange: (portals: number) => void;
+  onKeysChange: (keys: number) => void;
   onGenerate: () => void;
   isGenerating: boolean;
 }
 
 const MIN_DIMENSION = 5;
+const MAX_LEVELS = 8;
 
 export const MazeGenerator = ({
   width,
   height,
   seed,
+  algorithm,
+  topology,
+  braid,
+  levels,
+  portals,
+  keys,
   maxWidth,
   maxHeight,
   onWidthChange,
   onHeightChange,
   onSeedChange,
+  onAlgorithmChange,
+  onTopologyChange,
+  onBraidChange,
+  onLevelsChange,
+  onPortalsChange,
+  onKeysChange,
   onGenerate,
   isGenerating,
 }: MazeGeneratorProps) => {
+  // Hex, polar and multi-level mazes are always built by the backtracker and
+  // cannot be braided; multi-level mazes are always square. Torus mazes come
+  // from the generators that can carve across the seams.
+  const multiLevel = levels > 1;
+  const wrap = topology === "torus" && !multiLevel;
+  const backtrackerOnly = (topology !== "square" && !wrap) || multiLevel;
+  const polar = topology === "polar" && !multiLevel;
+  // Keys are placed on single-floor square and torus mazes only.
+  const lockable = !multiLevel && (topology === "square" || topology === "torus");
+  const selectedAlgorithm = backtrackerOnly || (wrap && !WRAP_ALGORITHMS.includes(algorithm)) ? "backtracker" : algorithm;
+
   return (
     <div className="space-y-4">
       <h3 className="text-lg font-semibold text-slate-100">Maze Generation</h3>
       <div className="grid grid-cols-2 gap-4">
         <DimensionInput
-          label="Width (cells)"
+          label={polar ? "Inner ring (cells)" : "Width (cells)"}
           value={width}
           onChange={onWidthChange}
           min={MIN_DIMENSION}
           max={maxWidth}
         />
         <DimensionInput
-          label="Height (cells)"
+          label={polar ? "Rings" : "Height (cells)"}
           value={height}
           onChange={onHeightChange}
           min={MIN_DIMENSION}
           max={maxHeight}
         />
       </div>
+      <div className="grid grid-cols-2 gap-4">
+        <DimensionInput label="Floors" value={levels} onChange={onLevelsChange} min={1} max={MAX_LEVELS} />
+        {/* Portals are placed on single-floor mazes only. */}
+        <DimensionInput
+          label="Portal pairs"
+          value={multiLevel ? 0 : portals}
+          onChange={onPortalsChange}
+          min={0}
+          max={multiLevel ? 0 : MAX_PORTALS}
+        />
+        <DimensionInput
+          label="Locked doors"
+          value={lockable ? keys : 0}
+          onChange={onKeysChange}
+          min={0}
+          max={lockable ? MAX_KEYS : 0}
+        />
+      </div>
+      <label className="flex flex-col gap-2 text-sm text-slate-300">
+        Tiles
+        <select
+          value={multiLevel ? "square" : topology}
+          disabled={multiLevel}
+          onChange={(event: ChangeEvent<HTMLSelectElement>) => onTopologyChange(event.target.value as Topology)}
+          className="rounded-md border border-slate-700 bg-slate-900 px-3 py-2 text-sm text-slate-100 focus:border-sky-500 focus:outline-none focus:ring focus:ring-sky-500/20 disabled:opacity-50"
+        >
+          <option value="square">Square</option>
+          <option value="hex">Hexagonal</option>
+          <option value="polar">Circular</option>
+          <option value="torus">Wrap-around (torus)</option>
+        </select>
+      </label>
+      <label className="flex flex-col gap-2 text-sm text-slate-300">
+        Generator
+        <select
+          value={selectedAlgorithm}
+          disabled={backtrackerOnly}
+          onChange={(event: ChangeEvent<HTMLSelectElement>) => onAlgorithmChange(event.target.value as MazeAlgorithm)}
+          className="rounded-md border border-slate-700 bg-slate-900 px-3 py-2 text-sm text-slate-100 focus:border-sky-500 focus:outline-none focus:ring focus:ring-sky-500/20 disabled:opacity-50"
+        >
+          {MAZE_ALGORITHMS.map((info) => (
+            <option key={info.name} value={info.name} disabled={wrap && !WRAP_ALGORITHMS.includes(info.name)}>
+              {info.label}
+            </option>
+          ))}
+        </select>
+      </label>
+      <div className="flex flex-col gap-2">
+        <span className="text-sm text-slate-300">Loops ({Math.round((backtrackerOnly ? 0 : braid) * 100)}% of dead ends removed)</span>
+        <input
+          type="range"
+          min={0}
+          max={1}
+          step={0.05}
+          value={backtrackerOnly ? 0 : braid}
+          disabled={backtrackerOnly}
+          onChange={(event: ChangeEvent<HTMLInputElement>) => onBraidChange(Number(event.target.value))}
+          className="accent-sky-500"
+        />
+      </div>
       <SeedInput value={seed} onChange={onSeedChange} />
       <button
         type="button"
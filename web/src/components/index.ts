@@ -3,4 +3,4 @@ export { ControlsPanel } from "./ControlsPanel";
 export { StatsPanel } from "./StatsPanel";
 export { ErrorBoundary } from "./ErrorBoundary";
 export { ToastContainer } from "./ToastContainer";
-
+export { WorldView } from "./WorldView";
@@ -1,4 +1,4 @@
 export { useSimulationAnimation } from "./useSimulationAnimation";
 export { useMazeService } from "./useMazeService";
 export { useSimulationService } from "./useSimulationService";
-
+export { usePlannerSession } from "./usePlannerSession";
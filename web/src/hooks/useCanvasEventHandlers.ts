@@ -1,18 +1,24 @@
 import { useCallback, type MouseEvent } from "react";
 
-import type { Grid, Point } from "@/types";
+import type { CellGeometry } from "@/hooks/useCanvasRenderer";
+import { isOpenTile, type Grid, type Point } from "@/types";
+import { hexAt } from "@/utils/hexLayout";
+import { polarAt } from "@/utils/polarLayout";
 
 interface UseCanvasEventHandlersProps {
   grid: Grid | null;
+  // floor is the floor grid is on, given to every cell picked.
+  floor: number;
   onSelectCell?: (point: Point) => void;
   setHoveredCell: (cell: Point | null) => void;
-  cellGeometryRef: React.RefObject<{ width: number; height: number; containerWidth: number; containerHeight: number }>;
+  cellGeometryRef: React.RefObject<CellGeometry>;
   dprRef: React.RefObject<number>;
   canvasRef: React.RefObject<HTMLCanvasElement | null>;
 }
 
 export const useCanvasEventHandlers = ({
   grid,
+  floor,
   onSelectCell,
   setHoveredCell,
   cellGeometryRef,
@@ -29,7 +35,7 @@ export const useCanvasEventHandlers = ({
     }
 
     const rect = canvas.getBoundingClientRect();
-    const { width: cellWidth, height: cellHeight, containerWidth, containerHeight } = cellGeometryRef.current!;
+    const { width: cellWidth, height: cellHeight, containerWidth, containerHeight, hex, polar } = cellGeometryRef.current!;
     const scaleX = canvas.width / (rect.width || 1);
     const scaleY = canvas.height / (rect.height || 1);
     const dpr = dprRef.current!;
@@ -41,50 +47,32 @@ export const useCanvasEventHandlers = ({
       return null;
     }
 
-    const x = Math.floor(offsetX / cellWidth);
-    const y = Math.floor(offsetY / cellHeight);
+    if (polar) {
+      return polarAt(polar, grid, offsetX, offsetY);
+    }
+
+    const { x, y } = hex
+      ? hexAt(hex, offsetX, offsetY)
+      : { x: Math.floor(offsetX / cellWidth), y: Math.floor(offsetY / cellHeight) };
 
-    if (y < 0 || y >= grid.length || x < 0 || x >= grid[0].length) {
+    if (y < 0 || y >= grid.length || x < 0 || x >= grid[y].length) {
       return null;
     }
 
-    return { x, y };
-  }, [grid, canvasRef, cellGeometryRef, dprRef]);
+    return { x, y, z: floor };
+  }, [grid, floor, canvasRef, cellGeometryRef, dprRef]);
 
   const handleCanvasClick = useCallback((event: MouseEvent<HTMLCanvasElement>) => {
     if (!grid || !onSelectCell) {
       return;
     }
-    const canvas = canvasRef.current;
-    if (!canvas) {
-      return;
-    }
-
-    const rect = canvas.getBoundingClientRect();
-    const { width: cellWidth, height: cellHeight, containerWidth, containerHeight } = cellGeometryRef.current!;
-    const scaleX = canvas.width / (rect.width || 1);
-    const scaleY = canvas.height / (rect.height || 1);
-    const dpr = dprRef.current!;
-
-    const offsetX = (event.clientX - rect.left) * (scaleX / dpr);
-    const offsetY = (event.clientY - rect.top) * (scaleY / dpr);
-
-    if (offsetX < 0 || offsetY < 0 || offsetX >= containerWidth || offsetY >= containerHeight) {
-      return;
-    }
-
-    const x = Math.floor(offsetX / cellWidth);
-    const y = Math.floor(offsetY / cellHeight);
-
-    if (y < 0 || y >= grid.length || x < 0 || x >= grid[0].length) {
-      return;
-    }
-    if (grid[y][x] !== 0) {
+    const cell = getCellFromMouseEvent(event);
+    if (!cell || !isOpenTile(grid[cell.y][cell.x])) {
       return;
     }
 
-    onSelectCell({ x, y });
-  }, [grid, onSelectCell, canvasRef, cellGeometryRef, dprRef]);
+    onSelectCell(cell);
+  }, [grid, onSelectCell, getCellFromMouseEvent]);
 
   const handleCanvasMouseMove = useCallback((event: MouseEvent<HTMLCanvasElement>) => {
     const cell = getCellFromMouseEvent(event);
@@ -1,16 +1,34 @@
 import { useCallback, useRef } from "react";
 
-import type { Grid, Point } from "@/types";
+import { FIRST_PORTAL, doorColorOf, isOpenTile, keyColorOf, type Grid, type Pixel, type Point, type SearchSide, type Topology } from "@/types";
+import { hexCenter, hexLayout, traceHex, type HexLayout } from "@/utils/hexLayout";
+import { polarCenter, polarLayout, tracePolar, type PolarLayout } from "@/utils/polarLayout";
 
 const COLORS = {
   wall: "#0f172a",
   space: "#1e293b",
   visited: "#38bdf8",
+  visitedBackward: "#c084fc",
   path: "#fbbf24",
   start: "#22c55e",
   goal: "#ef4444",
+  stairs: "#e2e8f0",
+  teleport: "#f472b6",
+  pickup: "#f8fafc",
+  broken: "#fb923c",
+  obstacle: "#e11d48",
+  walker: "#facc15",
 } as const;
 
+// portalColor gives the two ends of each portal a colour of their own.
+const portalColor = (id: number) => `hsl(${((id - FIRST_PORTAL) * 137) % 360}, 85%, 65%)`;
+
+// agentColor gives each agent planned together a colour of its own.
+const agentColor = (agent: number) => `hsl(${(agent * 137 + 200) % 360}, 80%, 60%)`;
+
+// keyColor gives each key and the door it opens a colour of their own.
+const keyColor = (color: number) => `hsl(${((color - 1) * 83 + 40) % 360}, 90%, 55%)`;
+
 const hexToRgba = (hex: string, alpha: number) => {
   const sanitized = hex.replace("#", "");
   const bigint = parseInt(sanitized, 16);
@@ -29,40 +47,100 @@ interface Dimensions {
   containerHeight: number;
 }
 
+export interface CellGeometry {
+  width: number;
+  height: number;
+  containerWidth: number;
+  containerHeight: number;
+  // Set when the grid is made of hexagons instead of squares.
+  hex: HexLayout | null;
+  // Set when the grid is made of rings around a centre.
+  polar: PolarLayout | null;
+}
+
 interface UseCanvasRendererProps {
   grid: Grid | null;
+  topology: Topology;
   dimensions: Dimensions;
   visitedOrder: Point[];
+  visitedSides?: SearchSide[];
   visitedCount: number;
   path: Point[];
+  // teleports are the indices of the path points reached through a portal,
+  // drawn as dashed jumps from the point before.
+  teleports: number[];
+  // pickups are the indices of the path points where a key is picked up,
+  // ringed on the path.
+  pickups: number[];
+  // breaks are the indices of the path points that are broken walls, crossed
+  // out on the path.
+  breaks: number[];
   showPath: boolean;
   start: Point | null;
   goal: Point | null;
   hoveredCell: Point | null;
+  // floor is the floor of a multi-level maze on screen; points on other floors
+  // are skipped and stairs up from or down to it are marked.
+  floor: number;
+  stairs: Point[];
+  // obstacles are where the moving obstacles stand at the time step on screen,
+  // and walker where the path is then.
+  obstacles: Point[];
+  walker: Point | null;
+  // agentPaths are the paths of agents planned together, drawn as lines, and
+  // agentWalkers where each agent stands at the time step on screen, null for
+  // an agent without a path.
+  agentPaths: Point[][];
+  agentWalkers: (Point | null)[];
 }
 
 export const useCanvasRenderer = ({
   grid,
+  topology,
   dimensions,
   visitedOrder,
+  visitedSides,
   visitedCount,
   path,
+  teleports,
+  pickups,
+  breaks,
   showPath,
   start,
   goal,
   hoveredCell,
+  floor,
+  stairs,
+  obstacles,
+  walker,
+  agentPaths,
+  agentWalkers,
 }: UseCanvasRendererProps) => {
   const canvasRef = useRef<HTMLCanvasElement | null>(null);
   const contextRef = useRef<CanvasRenderingContext2D | null>(null);
-  const cellGeometryRef = useRef({
+  const cellGeometryRef = useRef<CellGeometry>({
     width: 1,
     height: 1,
     containerWidth: 1,
     containerHeight: 1,
+    hex: null,
+    polar: null,
   });
   const dprRef = useRef<number>(1);
   const prevVisitedCountRef = useRef<number>(0);
 
+  const onFloor = useCallback((point: Point) => point.z === floor, [floor]);
+
+  const cellCenter = useCallback((point: Point): Pixel => {
+    const { width: cellWidth, height: cellHeight, hex, polar } = cellGeometryRef.current;
+    if (polar && grid) {
+      return polarCenter(polar, grid, point);
+    }
+    return hex
+      ? hexCenter(hex, point)
+      : { x: point.x * cellWidth + cellWidth / 2, y: point.y * cellHeight + cellHeight / 2 };
+  }, [grid]);
+
   const resetCanvas = useCallback(() => {
     if (!grid) {
       return;
@@ -75,11 +153,15 @@ export const useCanvasRenderer = ({
     const { width, height, cellWidth, cellHeight, containerWidth, containerHeight } = dimensions;
     const dpr = window.devicePixelRatio || 1;
     dprRef.current = dpr;
+    const hex = topology === "hex" ? hexLayout(width, height, containerWidth, containerHeight) : null;
+    const polar = topology === "polar" ? polarLayout(grid, containerWidth, containerHeight) : null;
     cellGeometryRef.current = {
       width: cellWidth,
       height: cellHeight,
       containerWidth,
       containerHeight,
+      hex,
+      polar,
     };
 
     canvas.width = containerWidth * dpr;
@@ -105,9 +187,35 @@ export const useCanvasRenderer = ({
     context.fillRect(0, 0, containerWidth, containerHeight);
 
     context.fillStyle = COLORS.space;
+    if (polar) {
+      context.beginPath();
+      grid.forEach((row, y) =>
+        row.forEach((tile, x) => {
+          if (isOpenTile(tile)) {
+            tracePolar(context, polar, grid, { x, y });
+          }
+        }),
+      );
+      context.fill();
+      context.restore();
+      return;
+    }
+    if (hex) {
+      context.beginPath();
+      for (let y = 0; y < height; y += 1) {
+        for (let x = 0; x < width; x += 1) {
+          if (isOpenTile(grid[y][x])) {
+            traceHex(context, hex, { x, y });
+          }
+        }
+      }
+      context.fill();
+      context.restore();
+      return;
+    }
     for (let y = 0; y < height; y += 1) {
       for (let x = 0; x < width; x += 1) {
-        if (grid[y][x] === 0) {
+        if (isOpenTile(grid[y][x])) {
           context.fillRect(
             x * cellWidth,
             y * cellHeight,
@@ -118,7 +226,7 @@ export const useCanvasRenderer = ({
       }
     }
     context.restore();
-  }, [dimensions, grid]);
+  }, [dimensions, grid, topology]);
 
   const drawVisitedRange = useCallback((from: number, to: number) => {
     const context = contextRef.current;
@@ -126,18 +234,31 @@ export const useCanvasRenderer = ({
       return;
     }
     const total = visitedOrder.length || 1;
-    const { width: cellWidth, height: cellHeight } = cellGeometryRef.current;
+    const { width: cellWidth, height: cellHeight, hex, polar } = cellGeometryRef.current;
     const dpr = dprRef.current;
 
     context.save();
     context.setTransform(dpr, 0, 0, dpr, 0, 0);
     for (let index = from; index < to; index += 1) {
       const point = visitedOrder[index];
-      if (!point) {
+      if (!point || !onFloor(point)) {
         continue;
       }
       const intensity = 0.15 + (index / total) * 0.65;
-      context.fillStyle = hexToRgba(COLORS.visited, Math.min(0.85, intensity));
+      const color = visitedSides?.[index] === "backward" ? COLORS.visitedBackward : COLORS.visited;
+      context.fillStyle = hexToRgba(color, Math.min(0.85, intensity));
+      if (polar && grid) {
+        context.beginPath();
+        tracePolar(context, polar, grid, point);
+        context.fill();
+        continue;
+      }
+      if (hex) {
+        context.beginPath();
+        traceHex(context, hex, point);
+        context.fill();
+        continue;
+      }
       context.fillRect(
         point.x * cellWidth,
         point.y * cellHeight,
@@ -146,14 +267,15 @@ export const useCanvasRenderer = ({
       );
     }
     context.restore();
-  }, [visitedOrder]);
+  }, [grid, onFloor, visitedOrder, visitedSides]);
 
   const drawPathOverlay = useCallback(() => {
     const context = contextRef.current;
-    if (!context || !showPath || path.length === 0) {
+    const points = path.filter(onFloor);
+    if (!context || !showPath || points.length === 0) {
       return;
     }
-    const { width: cellWidth, height: cellHeight } = cellGeometryRef.current;
+    const { width: cellWidth, height: cellHeight, hex, polar } = cellGeometryRef.current;
     const dpr = dprRef.current;
     const insetX = Math.max(1, cellWidth * 0.25);
     const insetY = Math.max(1, cellHeight * 0.25);
@@ -163,58 +285,198 @@ export const useCanvasRenderer = ({
     context.save();
     context.setTransform(dpr, 0, 0, dpr, 0, 0);
     context.fillStyle = hexToRgba(COLORS.path, 0.9);
-    path.forEach((point) => {
-      context.fillRect(
-        point.x * cellWidth + insetX,
-        point.y * cellHeight + insetY,
-        sizeX,
-        sizeY,
-      );
+    if (polar && grid) {
+      context.beginPath();
+      points.forEach((point) => tracePolar(context, polar, grid, point, 0.5));
+      context.fill();
+    } else if (hex) {
+      context.beginPath();
+      points.forEach((point) => traceHex(context, hex, point, 0.5));
+      context.fill();
+    } else {
+      points.forEach((point) => {
+        context.fillRect(
+          point.x * cellWidth + insetX,
+          point.y * cellHeight + insetY,
+          sizeX,
+          sizeY,
+        );
+      });
+    }
+
+    // Teleports are dashed lines between the portal ends, when both are on this floor.
+    context.strokeStyle = COLORS.teleport;
+    context.lineWidth = Math.max(1, Math.min(cellWidth, cellHeight) * 0.15);
+    context.setLineDash([4, 4]);
+    teleports.forEach((index) => {
+      const from = path[index - 1];
+      const to = path[index];
+      if (!from || !to || !onFloor(from) || !onFloor(to)) {
+        return;
+      }
+      const a = cellCenter(from);
+      const b = cellCenter(to);
+      context.beginPath();
+      context.moveTo(a.x, a.y);
+      context.lineTo(b.x, b.y);
+      context.stroke();
+    });
+
+    // Key pickups are rings around the path points where a key is collected.
+    context.strokeStyle = COLORS.pickup;
+    context.setLineDash([]);
+    pickups.forEach((index) => {
+      const point = path[index];
+      if (!point || !onFloor(point)) {
+        return;
+      }
+      const { x, y } = cellCenter(point);
+      context.beginPath();
+      context.arc(x, y, Math.max(2, Math.min(cellWidth, cellHeight) * 0.45), 0, Math.PI * 2);
+      context.stroke();
+    });
+
+    // Broken walls are crossed out where the path runs through them.
+    context.strokeStyle = COLORS.broken;
+    breaks.forEach((index) => {
+      const point = path[index];
+      if (!point || !onFloor(point)) {
+        return;
+      }
+      const { x, y } = cellCenter(point);
+      const reach = Math.max(2, Math.min(cellWidth, cellHeight) * 0.35);
+      context.beginPath();
+      context.moveTo(x - reach, y - reach);
+      context.lineTo(x + reach, y + reach);
+      context.moveTo(x + reach, y - reach);
+      context.lineTo(x - reach, y + reach);
+      context.stroke();
     });
     context.restore();
-  }, [path, showPath]);
+  }, [breaks, cellCenter, grid, onFloor, path, pickups, showPath, teleports]);
 
   const drawMarkers = useCallback(() => {
     const context = contextRef.current;
     if (!context) {
       return;
     }
-    const { width: cellWidth, height: cellHeight } = cellGeometryRef.current;
+    const { width: cellWidth, height: cellHeight, hex, polar } = cellGeometryRef.current;
     const dpr = dprRef.current;
-    const radius = Math.min(cellWidth, cellHeight) * 0.35;
+    const radius = polar
+      ? polar.ringWidth * 0.3
+      : hex
+        ? hex.size * 0.6
+        : Math.min(cellWidth, cellHeight) * 0.35;
 
     context.save();
     context.setTransform(dpr, 0, 0, dpr, 0, 0);
     context.lineWidth = Math.max(1, Math.min(cellWidth, cellHeight) * 0.1);
 
-    if (start) {
+    // Portals are rings, both ends of a portal in the same colour. Doors are
+    // squares and keys diamonds in the colour they share.
+    grid?.forEach((row, y) =>
+      row.forEach((tile, x) => {
+        const { x: cx, y: cy } = cellCenter({ x, y, z: floor });
+        const door = doorColorOf(tile);
+        if (door !== null) {
+          context.fillStyle = keyColor(door);
+          context.fillRect(cx - radius, cy - radius, radius * 2, radius * 2);
+          return;
+        }
+        const key = keyColorOf(tile);
+        if (key !== null) {
+          context.fillStyle = keyColor(key);
+          context.beginPath();
+          context.moveTo(cx, cy - radius);
+          context.lineTo(cx + radius, cy);
+          context.lineTo(cx, cy + radius);
+          context.lineTo(cx - radius, cy);
+          context.closePath();
+          context.fill();
+          return;
+        }
+        if (tile < FIRST_PORTAL) {
+          return;
+        }
+        context.strokeStyle = portalColor(tile);
+        context.beginPath();
+        context.arc(cx, cy, radius, 0, Math.PI * 2);
+        context.stroke();
+      }),
+    );
+
+    // Stairs are triangles pointing towards the floor they lead to.
+    context.fillStyle = COLORS.stairs;
+    stairs.forEach((stair) => {
+      const up = stair.z === floor;
+      if (!up && stair.z + 1 !== floor) {
+        return;
+      }
+      const { x, y } = cellCenter(stair);
+      const tip = up ? -radius : radius;
+      context.beginPath();
+      context.moveTo(x, y + tip);
+      context.lineTo(x + radius, y - tip);
+      context.lineTo(x - radius, y - tip);
+      context.closePath();
+      context.fill();
+    });
+
+    if (start && onFloor(start)) {
+      const { x, y } = cellCenter(start);
       context.fillStyle = COLORS.start;
       context.beginPath();
-      context.arc(
-        start.x * cellWidth + cellWidth / 2,
-        start.y * cellHeight + cellHeight / 2,
-        radius,
-        0,
-        Math.PI * 2,
-      );
+      context.arc(x, y, radius, 0, Math.PI * 2);
       context.fill();
     }
 
-    if (goal) {
+    if (goal && onFloor(goal)) {
+      const { x, y } = cellCenter(goal);
       context.fillStyle = COLORS.goal;
       context.beginPath();
-      context.arc(
-        goal.x * cellWidth + cellWidth / 2,
-        goal.y * cellHeight + cellHeight / 2,
-        radius,
-        0,
-        Math.PI * 2,
-      );
+      context.arc(x, y, radius, 0, Math.PI * 2);
       context.fill();
     }
 
+    // Moving obstacles are squares and the walker a ring at the time step on screen.
+    context.fillStyle = COLORS.obstacle;
+    obstacles.forEach((obstacle) => {
+      if (!onFloor(obstacle)) {
+        return;
+      }
+      const { x, y } = cellCenter(obstacle);
+      context.fillRect(x - radius, y - radius, radius * 2, radius * 2);
+    });
+    if (walker && onFloor(walker)) {
+      const { x, y } = cellCenter(walker);
+      context.strokeStyle = COLORS.walker;
+      context.beginPath();
+      context.arc(x, y, radius, 0, Math.PI * 2);
+      context.stroke();
+    }
+
+    // Agents planned together are lines along their paths, each ending in a
+    // disc where the agent stands at the time step on screen.
+    agentPaths.forEach((agentPath, agent) => {
+      const points = agentPath.filter(onFloor).map(cellCenter);
+      context.strokeStyle = agentColor(agent);
+      context.beginPath();
+      points.forEach(({ x, y }, index) => (index === 0 ? context.moveTo(x, y) : context.lineTo(x, y)));
+      context.stroke();
+    });
+    agentWalkers.forEach((point, agent) => {
+      if (!point || !onFloor(point)) {
+        return;
+      }
+      const { x, y } = cellCenter(point);
+      context.fillStyle = agentColor(agent);
+      context.beginPath();
+      context.arc(x, y, radius * 0.8, 0, Math.PI * 2);
+      context.fill();
+    });
+
     context.restore();
-  }, [goal, start]);
+  }, [agentPaths, agentWalkers, cellCenter, floor, goal, grid, obstacles, onFloor, stairs, start, walker]);
 
   const drawHoverEffect = useCallback(() => {
     const context = contextRef.current;
@@ -223,16 +485,34 @@ export const useCanvasRenderer = ({
     }
 
     // Only show hover effect on valid cells (open spaces)
-    if (grid[hoveredCell.y][hoveredCell.x] !== 0) {
+    if (!isOpenTile(grid[hoveredCell.y][hoveredCell.x])) {
       return;
     }
 
-    const { width: cellWidth, height: cellHeight } = cellGeometryRef.current;
+    const { width: cellWidth, height: cellHeight, hex, polar } = cellGeometryRef.current;
     const dpr = dprRef.current;
 
     context.save();
     context.setTransform(dpr, 0, 0, dpr, 0, 0);
 
+    if (polar) {
+      context.fillStyle = hexToRgba("#ffffff", 0.2);
+      context.beginPath();
+      tracePolar(context, polar, grid, hoveredCell);
+      context.fill();
+      context.restore();
+      return;
+    }
+
+    if (hex) {
+      context.fillStyle = hexToRgba("#ffffff", 0.2);
+      context.beginPath();
+      traceHex(context, hex, hoveredCell);
+      context.fill();
+      context.restore();
+      return;
+    }
+
     // Draw shadow/box effect
     const insetX = Math.max(1, cellWidth * 0.1);
     const insetY = Math.max(1, cellHeight * 0.1);
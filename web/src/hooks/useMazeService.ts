@@ -1,7 +1,51 @@
 import { useCallback, useState } from "react";
 import { generateMaze as generateMazeAPI } from "@/api";
 import { useAppStore } from "@/store/useAppStore";
-import type { GenerateMazeRequest } from "@/types";
+import { doorTile, keyTile, type GenerateMazeRequest, type GenerationEvent, type Grid, type MazeResponse, type Topology } from "@/types";
+
+// GENERATION_FRAMES is roughly how many frames the generation replay takes.
+const GENERATION_FRAMES = 90;
+
+const nextFrame = () => new Promise<void>((resolve) => requestAnimationFrame(() => resolve()));
+
+// eventTile is the tile a generation event leaves behind.
+const eventTile = (event: GenerationEvent): number => {
+  switch (event.kind) {
+    case "portal":
+      return event.portal ?? 0;
+    case "door":
+      return doorTile(event.color ?? 1);
+    case "key":
+      return keyTile(event.color ?? 1);
+    case "carve":
+      return 0;
+    default:
+      return 1;
+  }
+};
+
+// replayGeneration draws the maze being built from its generation events.
+const replayGeneration = async (maze: MazeResponse, draw: (floors: Grid[], topology: Topology) => void) => {
+  const events = maze.events ?? [];
+  if (events.length === 0) {
+    return;
+  }
+
+  // Start from the shape of the finished floors, whose rows differ in length on
+  // polar mazes. Stairs only join squares their floors carve anyway.
+  const floors: Grid[] = (maze.floors ?? [maze.grid]).map((grid) => grid.map((row) => row.map(() => 1)));
+  const perFrame = Math.ceil(events.length / GENERATION_FRAMES);
+  for (let i = 0; i < events.length; i += perFrame) {
+    for (const event of events.slice(i, i + perFrame)) {
+      if (event.kind === "stairs") {
+        continue;
+      }
+      floors[event.point.z][event.point.y][event.point.x] = eventTile(event);
+    }
+    draw(floors.map((grid) => grid.map((row) => [...row])), maze.topology);
+    await nextFrame();
+  }
+};
 
 export interface UseMazeServiceReturn {
   generateMaze: (payload: GenerateMazeRequest) => Promise<void>;
@@ -11,6 +55,7 @@ export interface UseMazeServiceReturn {
 
 export const useMazeService = (): UseMazeServiceReturn => {
   const setMaze = useAppStore((state) => state.setMaze);
+  const setMazeGrid = useAppStore((state) => state.setMazeGrid);
   const [isGenerating, setIsGenerating] = useState(false);
   const [error, setError] = useState<string | null>(null);
 
@@ -19,7 +64,8 @@ export const useMazeService = (): UseMazeServiceReturn => {
     setError(null);
 
     try {
-      const response = await generateMazeAPI(payload);
+      const response = await generateMazeAPI({ ...payload, events: true });
+      await replayGeneration(response, setMazeGrid);
       setMaze(response);
     } catch (err) {
       const message = err instanceof Error ? err.message : "Failed to generate maze";
@@ -28,7 +74,7 @@ export const useMazeService = (): UseMazeServiceReturn => {
     } finally {
       setIsGenerating(false);
     }
-  }, [setMaze]);
+  }, [setMaze, setMazeGrid]);
 
   return { generateMaze, isGenerating, error };
 };
@@ -0,0 +1,112 @@
+import { useCallback, useEffect, useRef, useState } from "react";
+
+import { createPlannerSession, deletePlannerSession, updatePlannerSession } from "@/api";
+import { useAppStore } from "@/store/useAppStore";
+import type { Grid, Point, PlannerResponse } from "@/types";
+
+export interface UsePlannerSessionReturn {
+  toggleWall: (cell: Point) => Promise<void>;
+  isReplanning: boolean;
+  error: string | null;
+}
+
+const samePoint = (a: Point, b: Point) => a.x === b.x && a.y === b.y;
+
+// usePlannerSession edits walls and keeps the path between start and goal up
+// to date through an incremental planner session on the server. The session is
+// created on the first edit, sent every edit after that as a wall change, and
+// dropped once the maze, start or goal change.
+export const usePlannerSession = (): UsePlannerSessionReturn => {
+  const start = useAppStore((state) => state.start);
+  const goal = useAppStore((state) => state.goal);
+  const topology = useAppStore((state) => state.topology);
+  const setWall = useAppStore((state) => state.setWall);
+  const setPlannerResult = useAppStore((state) => state.setPlannerResult);
+  const [isReplanning, setIsReplanning] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+
+  const sessionRef = useRef<string | null>(null);
+  // gridRef is the grid the session plans on; any other grid in the store is a
+  // new maze.
+  const gridRef = useRef<Grid | null>(null);
+  // generationRef counts dropped sessions, so that one still being created
+  // when its maze, start or goal change is dropped as soon as it arrives.
+  const generationRef = useRef(0);
+  // Edits are sent one at a time so their plans arrive in order.
+  const queueRef = useRef<Promise<void>>(Promise.resolve());
+
+  const drop = useCallback(() => {
+    const sessionId = sessionRef.current;
+    sessionRef.current = null;
+    gridRef.current = null;
+    generationRef.current += 1;
+    if (sessionId) {
+      deletePlannerSession(sessionId).catch(() => {
+        // The session expires on its own.
+      });
+    }
+  }, []);
+
+  useEffect(() => drop, [start, goal, topology, drop]);
+
+  const edit = useCallback(
+    async (cell: Point) => {
+      const { maze, start, goal, topology } = useAppStore.getState();
+      if (!maze || !start || !goal || maze[cell.y]?.[cell.x] === undefined) {
+        return;
+      }
+      if (samePoint(cell, start) || samePoint(cell, goal)) {
+        return;
+      }
+      if (gridRef.current !== maze) {
+        drop();
+      }
+
+      const wall = maze[cell.y][cell.x] !== 1;
+      setWall(cell, wall);
+      const grid = useAppStore.getState().maze ?? maze;
+      gridRef.current = grid;
+      const generation = generationRef.current;
+
+      setIsReplanning(true);
+      setError(null);
+      try {
+        let result: PlannerResponse | null = null;
+        if (sessionRef.current) {
+          try {
+            result = await updatePlannerSession(sessionRef.current, [{ point: cell, wall }]);
+          } catch {
+            // The session may have expired; start over from the grid as it stands.
+            sessionRef.current = null;
+          }
+        }
+        if (!result) {
+          result = await createPlannerSession({ grid, start, goal, topology });
+          if (generation !== generationRef.current) {
+            deletePlannerSession(result.sessionId).catch(() => {
+              // The session expires on its own.
+            });
+            return;
+          }
+          sessionRef.current = result.sessionId;
+        }
+        setPlannerResult(result);
+      } catch (err) {
+        setError(err instanceof Error ? err.message : "Failed to replan");
+      } finally {
+        setIsReplanning(false);
+      }
+    },
+    [drop, setPlannerResult, setWall]
+  );
+
+  const toggleWall = useCallback(
+    (cell: Point) => {
+      queueRef.current = queueRef.current.then(() => edit(cell));
+      return queueRef.current;
+    },
+    [edit]
+  );
+
+  return { toggleWall, isReplanning, error };
+};
@@ -1,10 +1,11 @@
 import { useCallback, useState, useRef } from "react";
-import { simulate as simulateAPI } from "@/api";
+import { simulate as simulateAPI, simulateMulti as simulateMultiAPI } from "@/api";
 import { useAppStore } from "@/store/useAppStore";
-import type { SimulateRequest } from "@/types";
+import type { SimulateMultiRequest, SimulateMultiResponse, SimulateRequest } from "@/types";
 
 export interface UseSimulationServiceReturn {
   runSimulation: (request: SimulateRequest) => Promise<void>;
+  runMulti: (request: SimulateMultiRequest) => Promise<SimulateMultiResponse | null>;
   isRunning: boolean;
   error: string | null;
   cancel: () => void;
@@ -12,6 +13,7 @@ export interface UseSimulationServiceReturn {
 
 export const useSimulationService = (): UseSimulationServiceReturn => {
   const setSimulationResult = useAppStore((state) => state.setSimulationResult);
+  const setMultiResult = useAppStore((state) => state.setMultiResult);
   const algorithm = useAppStore((state) => state.algorithm);
   const [isRunning, setIsRunning] = useState(false);
   const [error, setError] = useState<string | null>(null);
@@ -55,6 +57,36 @@ export const useSimulationService = (): UseSimulationServiceReturn => {
     [algorithm, setSimulationResult, cancel]
   );
 
-  return { runSimulation, isRunning, error, cancel };
+  const runMulti = useCallback(
+    async (request: SimulateMultiRequest) => {
+      cancel();
+
+      setIsRunning(true);
+      setError(null);
+
+      abortControllerRef.current = new AbortController();
+
+      try {
+        const response = await simulateMultiAPI(request, {
+          signal: abortControllerRef.current.signal,
+        });
+        setMultiResult(response);
+        return response;
+      } catch (err) {
+        if (err instanceof Error && err.name === "AbortError") {
+          return null;
+        }
+        const message = err instanceof Error ? err.message : "Failed to plan agents";
+        setError(message);
+        throw err;
+      } finally {
+        setIsRunning(false);
+        abortControllerRef.current = null;
+      }
+    },
+    [setMultiResult, cancel]
+  );
+
+  return { runSimulation, runMulti, isRunning, error, cancel };
 };
 
@@ -1,12 +1,23 @@
 import { create } from "zustand";
 
-import type {
-  Algorithm,
-  MazeResponse,
-  Point,
-  SimulationStats,
-  SimulateResponse,
-  Grid,
+import {
+  hasKeys,
+  isOpenTile,
+  type Algorithm,
+  type AlgorithmInfo,
+  type Conflict,
+  type MazeResponse,
+  type MultiStats,
+  type PlannerResponse,
+  type PlannerStats,
+  type Point,
+  type Room,
+  type SearchSide,
+  type SimulationStats,
+  type SimulateMultiResponse,
+  type SimulateResponse,
+  type Grid,
+  type Topology,
 } from "@/types";
 
 export interface StoredSimulation {
@@ -16,42 +27,137 @@ export interface StoredSimulation {
 
 interface AppState {
   maze: Grid | null;
+  // floors holds every floor of the maze, ground floor first; floor is the one on screen.
+  floors: Grid[];
+  stairs: Point[];
+  floor: number;
   mazeWidth: number;
   mazeHeight: number;
   seed?: number;
+  topology: Topology;
+  rooms: Room[];
   start: Point | null;
   goal: Point | null;
   algorithm: Algorithm;
+  algorithms: AlgorithmInfo[];
   visitedOrder: Point[];
+  visitedSides: SearchSide[];
   path: Point[];
+  // teleports are the indices of the path points reached through a portal.
+  teleports: number[];
+  // pickups are the indices of the path points where a key is picked up.
+  pickups: number[];
+  // breaks are the indices of the path points that are broken walls.
+  breaks: number[];
+  // wallBreaks is how many walls the next run may break through.
+  wallBreaks: number;
+  // patrols is how many moving obstacles the next run must dodge.
+  patrols: number;
+  // obstacleFrames holds where every obstacle stands at each time step of the
+  // last run, and timeStep the step on screen.
+  obstacleFrames: Point[][];
+  timeStep: number;
+  // agents is how many agents the next run plans together; with more than one
+  // the run fills agentPaths, the conflicts left and multiStats instead of path.
+  agents: number;
+  agentPaths: Point[][];
+  agentConflicts: Conflict[];
+  multiStats: MultiStats | null;
+  // plannerStats holds the work of the last replan after a wall edit, whose
+  // path is then shown at once in path.
+  plannerStats: PlannerStats | null;
   stats: SimulationStats | null;
   isAnimating: boolean;
   animationSpeed: number;
   resultsByAlgorithm: Partial<Record<Algorithm, StoredSimulation>>;
 
   setMaze: (maze: MazeResponse) => void;
+  setMazeGrid: (floors: Grid[], topology: Topology) => void;
+  setFloor: (floor: number) => void;
   setStart: (point: Point | null) => void;
   setGoal: (point: Point | null) => void;
   setAlgorithm: (algorithm: Algorithm) => void;
+  setAlgorithms: (algorithms: AlgorithmInfo[]) => void;
   setSimulationResult: (algorithm: Algorithm, result: SimulateResponse) => void;
   setIsAnimating: (value: boolean) => void;
   setAnimationSpeed: (ms: number) => void;
+  setWallBreaks: (breaks: number) => void;
+  setPatrols: (patrols: number) => void;
+  setTimeStep: (step: number) => void;
+  setAgents: (agents: number) => void;
+  setMultiResult: (result: SimulateMultiResponse) => void;
+  setWall: (point: Point, wall: boolean) => void;
+  setPlannerResult: (result: PlannerResponse) => void;
   resetSimulation: () => void;
 }
 
 const DEFAULT_ALGORITHM: Algorithm = "astar";
+
+const roomCenter = (room: Room): Point => ({
+  x: room.bounds.x + Math.floor(room.bounds.width / 2),
+  y: room.bounds.y + Math.floor(room.bounds.height / 2),
+  z: 0,
+});
 const DEFAULT_ANIMATION_SPEED = 35;
 
+// openTiles lists the open tiles of grid in reading order.
+const openTiles = (grid: Grid): Point[] =>
+  grid.flatMap((row, y) => row.flatMap((tile, x) => (isOpenTile(tile) ? [{ x, y, z: 0 }] : [])));
+
+// initialEndpoints places start and goal where the maze suggests: in different
+// dungeon rooms, on the outer ring and at the centre of a polar maze, in
+// opposite corners of the bottom and top floors of a multi-level maze, or on
+// the first and last open tiles of a maze with keys, whose keys can all be
+// collected from the first one.
+const initialEndpoints = (maze: MazeResponse): { start: Point | null; goal: Point | null } => {
+  if (maze.rooms && maze.rooms.length > 1) {
+    return { start: roomCenter(maze.rooms[0]), goal: roomCenter(maze.rooms[maze.rooms.length - 1]) };
+  }
+  if (maze.topology === "polar") {
+    return { start: { x: 0, y: maze.grid.length - 1, z: 0 }, goal: { x: 0, y: 0, z: 0 } };
+  }
+  if (maze.floors && maze.floors.length > 1) {
+    return {
+      start: { x: 1, y: 1, z: 0 },
+      goal: { x: maze.width - 2, y: maze.height - 2, z: maze.floors.length - 1 },
+    };
+  }
+  if (hasKeys(maze.grid)) {
+    const open = openTiles(maze.grid);
+    return { start: open[0] ?? null, goal: open[open.length - 1] ?? null };
+  }
+  return { start: null, goal: null };
+};
+
 export const useAppStore = create<AppState>((set) => ({
   maze: null,
+  floors: [],
+  stairs: [],
+  floor: 0,
   mazeWidth: 0,
   mazeHeight: 0,
   seed: undefined,
+  topology: "square",
+  rooms: [],
   start: null,
   goal: null,
   algorithm: DEFAULT_ALGORITHM,
+  algorithms: [],
   visitedOrder: [],
+  visitedSides: [],
   path: [],
+  teleports: [],
+  pickups: [],
+  breaks: [],
+  wallBreaks: 0,
+  patrols: 0,
+  obstacleFrames: [],
+  timeStep: 0,
+  agents: 1,
+  agentPaths: [],
+  agentConflicts: [],
+  multiStats: null,
+  plannerStats: null,
   stats: null,
   isAnimating: false,
   animationSpeed: DEFAULT_ANIMATION_SPEED,
@@ -60,27 +166,91 @@ export const useAppStore = create<AppState>((set) => ({
   setMaze: (maze) =>
     set(() => ({
       maze: maze.grid,
+      floors: maze.floors ?? [maze.grid],
+      stairs: maze.stairs ?? [],
+      floor: 0,
       mazeWidth: maze.width,
       mazeHeight: maze.height,
       seed: maze.seed,
+      topology: maze.topology ?? "square",
+      rooms: maze.rooms ?? [],
+      ...initialEndpoints(maze),
+      visitedOrder: [],
+      visitedSides: [],
+      path: [],
+      teleports: [],
+      pickups: [],
+      breaks: [],
+      obstacleFrames: [],
+      timeStep: 0,
+      agentPaths: [],
+      agentConflicts: [],
+      multiStats: null,
+      plannerStats: null,
+      stats: null,
+      resultsByAlgorithm: {},
+    })),
+
+  // setMazeGrid shows floors that are still being built, clearing everything tied
+  // to the previous maze.
+  setMazeGrid: (floors, topology) =>
+    set((state) => ({
+      maze: floors[0],
+      floors,
+      stairs: [],
+      floor: Math.min(state.floor, floors.length - 1),
+      mazeWidth: floors[0][0]?.length ?? 0,
+      mazeHeight: floors[0].length,
+      topology,
+      rooms: [],
       start: null,
       goal: null,
       visitedOrder: [],
+      visitedSides: [],
       path: [],
+      teleports: [],
+      pickups: [],
+      breaks: [],
+      obstacleFrames: [],
+      timeStep: 0,
+      agentPaths: [],
+      agentConflicts: [],
+      multiStats: null,
+      plannerStats: null,
       stats: null,
       resultsByAlgorithm: {},
     })),
 
+  setFloor: (floor) => set({ floor }),
+
   setStart: (point) => set({ start: point }),
 
   setGoal: (point) => set({ goal: point }),
 
   setAlgorithm: (algorithm) => set({ algorithm }),
 
+  setAlgorithms: (algorithms) =>
+    set((state) => ({
+      algorithms,
+      algorithm: algorithms.some((info) => info.name === state.algorithm)
+        ? state.algorithm
+        : algorithms[0]?.name ?? state.algorithm,
+    })),
+
   setSimulationResult: (algorithm, result) =>
     set((state) => ({
       visitedOrder: result.visitedOrder,
+      visitedSides: result.visitedSides ?? [],
       path: result.path,
+      teleports: result.teleports ?? [],
+      pickups: result.pickups ?? [],
+      breaks: result.breaks ?? [],
+      obstacleFrames: result.obstacles ?? [],
+      timeStep: 0,
+      agentPaths: [],
+      agentConflicts: [],
+      multiStats: null,
+      plannerStats: null,
       stats: result.stats,
       resultsByAlgorithm: {
         ...state.resultsByAlgorithm,
@@ -95,10 +265,66 @@ export const useAppStore = create<AppState>((set) => ({
 
   setAnimationSpeed: (ms) => set({ animationSpeed: Math.max(0, ms) }),
 
+  setWallBreaks: (breaks) => set({ wallBreaks: Math.max(0, Math.floor(breaks)) }),
+
+  setPatrols: (patrols) => set({ patrols: Math.max(0, Math.floor(patrols)) }),
+
+  setTimeStep: (step) => set({ timeStep: step }),
+
+  setAgents: (agents) => set({ agents: Math.max(1, Math.floor(agents)) }),
+
+  setMultiResult: (result) =>
+    set({
+      agentPaths: result.paths,
+      agentConflicts: result.conflicts,
+      multiStats: result.stats,
+      plannerStats: null,
+      timeStep: 0,
+    }),
+
+  // setWall edits the ground floor, the only one a planner session replans.
+  setWall: (point, wall) =>
+    set((state) => {
+      if (!state.maze) {
+        return {};
+      }
+      const maze = state.maze.map((row, y) =>
+        y === point.y ? row.map((tile, x) => (x === point.x ? (wall ? 1 : 0) : tile)) : row,
+      );
+      return { maze, floors: [maze, ...state.floors.slice(1)] };
+    }),
+
+  setPlannerResult: (result) =>
+    set({
+      visitedOrder: [],
+      visitedSides: [],
+      path: result.path,
+      teleports: [],
+      pickups: [],
+      breaks: [],
+      obstacleFrames: [],
+      timeStep: 0,
+      agentPaths: [],
+      agentConflicts: [],
+      multiStats: null,
+      plannerStats: result.stats,
+      stats: null,
+    }),
+
   resetSimulation: () =>
     set((state) => ({
       visitedOrder: [],
+      visitedSides: [],
       path: [],
+      teleports: [],
+      pickups: [],
+      breaks: [],
+      obstacleFrames: [],
+      timeStep: 0,
+      agentPaths: [],
+      agentConflicts: [],
+      multiStats: null,
+      plannerStats: null,
       stats: null,
       resultsByAlgorithm: state.resultsByAlgorithm,
     })),
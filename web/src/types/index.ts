@@ -1,16 +1,184 @@
+// z is the floor of a point, 0 on the ground floor and on single-floor mazes.
+// The server always sends it.
 export type Point = {
   x: number;
   y: number;
+  z: number;
+};
+
+// Pixel is a position on a canvas.
+export type Pixel = {
+  x: number;
+  y: number;
 };
 
 export type Grid = number[][];
 
-export type Algorithm = "bfs" | "dfs" | "astar";
+// Topology is the tile shape of a grid. Hex grids use axial coordinates: x is the
+// q axis, y the r axis, and each row sits half a tile right of the one above.
+// Polar grids are rings around a centre tile at (0, 0): ring r keeps the walls on
+// its inner edge in row 2r - 1 and its cells, each followed clockwise by a wall,
+// in row 2r, so rows differ in length. Torus grids are square grids whose edges
+// wrap around; generated ones have no outer border, with the walls across the
+// seams in row 0 and column 0.
+export type Topology = "square" | "hex" | "polar" | "torus";
+
+// Algorithm is the name of a solver as listed by GET /algorithms.
+export type Algorithm = string;
+
+export interface AlgorithmInfo {
+  name: Algorithm;
+  label: string;
+  aliases: string[];
+  optimal: boolean;
+  // Whether the solver weighs steps by per-cell costs rather than counting them.
+  supportsCosts: boolean;
+  topologies: Topology[];
+  multiLevel: boolean;
+  // Whether the solver tracks keys, and so can solve grids with doors.
+  keys: boolean;
+  // Whether the solver can break through walls when asked to.
+  wallBreaks: boolean;
+  // Whether the solver can dodge moving obstacles, waiting in place if need be.
+  movingObstacles: boolean;
+}
+
+export interface AlgorithmsResponse {
+  algorithms: AlgorithmInfo[];
+}
+
+export type CostGrid = number[][];
+
+export type Movement = "4-way" | "8-way" | "8-way-corner-cutting";
+
+export type Heuristic = "manhattan" | "euclidean" | "chebyshev" | "octile" | "hex" | "radial" | "zero";
+
+export type MazeAlgorithm =
+  | "backtracker"
+  | "kruskal"
+  | "prim"
+  | "wilson"
+  | "aldous-broder"
+  | "recursive-division"
+  | "eller"
+  | "cave"
+  | "dungeon";
+
+export const MAZE_ALGORITHMS: { name: MazeAlgorithm; label: string }[] = [
+  { name: "backtracker", label: "Recursive Backtracker" },
+  { name: "kruskal", label: "Randomized Kruskal" },
+  { name: "prim", label: "Randomized Prim" },
+  { name: "wilson", label: "Wilson (uniform)" },
+  { name: "aldous-broder", label: "Aldous-Broder (uniform)" },
+  { name: "recursive-division", label: "Recursive Division" },
+  { name: "eller", label: "Eller (row by row)" },
+  { name: "cave", label: "Cave (cellular automaton)" },
+  { name: "dungeon", label: "Dungeon (rooms and corridors)" },
+];
+
+// WRAP_ALGORITHMS are the generators that can build torus mazes.
+export const WRAP_ALGORITHMS: MazeAlgorithm[] = ["backtracker", "kruskal", "prim", "wilson", "aldous-broder"];
+
+// Grid tiles are 0 for open squares and 1 for walls; values from FIRST_PORTAL
+// up mark portals, each value on exactly two squares that teleport to each other.
+export const FIRST_PORTAL = 2;
+export const MAX_PORTALS = 10;
+
+// A simulation may break through up to MAX_WALL_BREAKS walls.
+export const MAX_WALL_BREAKS = 10;
+
+// Obstacle moves to the next point of its trajectory at every time step. A
+// periodic obstacle starts over once it reaches the end; a scripted one stays
+// on its last point.
+export interface Obstacle {
+  trajectory: Point[];
+  periodic: boolean;
+}
+
+// The controls add up to MAX_PATROLS patrolling obstacles to a simulation.
+export const MAX_PATROLS = 8;
+
+// Keys and doors are negative tiles: the key of colour c (1 to MAX_KEY_COLORS)
+// is -c and the door it opens is -100 - c. Doors only open once their key is held.
+export const MAX_KEY_COLORS = 16;
+export const MAX_KEYS = 8;
+export const keyTile = (color: number): number => -color;
+export const doorTile = (color: number): number => -100 - color;
+export const keyColorOf = (tile: number): number | null => (-tile >= 1 && -tile <= MAX_KEY_COLORS ? -tile : null);
+export const doorColorOf = (tile: number): number | null =>
+  -tile - 100 >= 1 && -tile - 100 <= MAX_KEY_COLORS ? -tile - 100 : null;
+export const hasKeys = (grid: number[][]): boolean =>
+  grid.some((row) => row.some((tile) => keyColorOf(tile) !== null || doorColorOf(tile) !== null));
+
+// isOpenTile reports whether a tile can be walked on, which portals and keys can.
+export const isOpenTile = (tile: number): boolean => tile === 0 || tile >= FIRST_PORTAL || keyColorOf(tile) !== null;
+
+export type CaveConnectivity = "join" | "largest";
+
+// CaveOptions tunes the cave generator; omitted fields keep the server defaults.
+export interface CaveOptions {
+  fill?: number;
+  passes?: number;
+  birthLimit?: number;
+  survivalLimit?: number;
+  connectivity?: CaveConnectivity;
+}
+
+// DungeonOptions tunes the dungeon generator; omitted fields keep the server defaults.
+export interface DungeonOptions {
+  minRoomSize?: number;
+  maxRoomSize?: number;
+  attempts?: number;
+  extraConnections?: number;
+}
+
+export interface Rect {
+  x: number;
+  y: number;
+  width: number;
+  height: number;
+}
+
+// Room is a dungeon room: its open area and the wall squares corridors pass through.
+export interface Room {
+  bounds: Rect;
+  doors: Point[];
+}
+
+export type GenerationEventKind = "carve" | "wall" | "stairs" | "portal" | "door" | "key";
+
+// GenerationEvent is one step of maze generation. Replaying the events in order on
+// an all-wall grid of the maze's size reproduces the finished grid.
+export interface GenerationEvent {
+  kind: GenerationEventKind;
+  point: Point;
+  // The portal id a "portal" event places.
+  portal?: number;
+  // The key colour a "door" or "key" event places.
+  color?: number;
+}
 
 export interface GenerateMazeRequest {
   width: number;
   height: number;
   seed?: number;
+  algorithm?: MazeAlgorithm;
+  // Hex and polar mazes are built with the backtracker only and cannot be
+  // braided; torus mazes need one of WRAP_ALGORITHMS.
+  topology?: Topology;
+  // Fraction of dead ends to remove (0 = perfect maze, 1 = no dead ends).
+  braid?: number;
+  cave?: CaveOptions;
+  dungeon?: DungeonOptions;
+  // Number of floors joined by stairs; more than one needs the backtracker on square tiles without braid.
+  levels?: number;
+  // Number of portal pairs to drop into the maze; not supported with several levels.
+  portals?: number;
+  // Number of coloured doors to lock, each with a key reachable from the first
+  // open square; square and torus mazes only, not with several levels.
+  keys?: number;
+  // Ask for the generation events so the build can be animated.
+  events?: boolean;
 }
 
 export interface MazeResponse {
@@ -18,25 +186,206 @@ export interface MazeResponse {
   height: number;
   grid: Grid;
   seed?: number;
+  algorithm?: MazeAlgorithm;
+  topology: Topology;
+  braid?: number;
+  rooms?: Room[];
+  // Every floor of a multi-level maze, ground floor first; grid repeats floors[0].
+  floors?: Grid[];
+  // Stairs of a multi-level maze, each joining floor z to floor z + 1.
+  stairs?: Point[];
+  events?: GenerationEvent[];
+}
+
+export interface ChunkCoord {
+  cx: number;
+  cy: number;
+}
+
+// WorldChunk is one tile of an infinite world served by GET /world/{seed}/chunk/{cx}/{cy}.
+// grid[0][0] sits at the world coordinate origin; chunks tile without overlap.
+export interface WorldChunk {
+  coord: ChunkCoord;
+  origin: Point;
+  size: number;
+  seed: number;
+  grid: Grid;
 }
 
 export interface SimulateRequest {
   algorithm: Algorithm;
-  grid: Grid;
+  // Send grid for a single floor, or floors and stairs for a multi-level maze.
+  grid?: Grid;
+  floors?: Grid[];
+  stairs?: Point[];
   start: Point;
   goal: Point;
+  costs?: CostGrid;
+  movement?: Movement;
+  heuristic?: Heuristic;
+  weight?: number;
+  topology?: Topology;
+  // Cost of jumping between the two ends of a portal, 1 by default.
+  portalCost?: number;
+  // Number of walls the path may break through, 0 by default.
+  wallBreaks?: number;
+  // Obstacles moving over the grid, which the path must dodge.
+  obstacles?: Obstacle[];
 }
 
+// BreakBudget is the outcome of the same search allowed to break up to breaks walls.
+export interface BreakBudget {
+  breaks: number;
+  found: boolean;
+  pathLength: number;
+  pathCost: number;
+}
+
+// SearchSide tells which half of a bidirectional search expanded a node.
+export type SearchSide = "forward" | "backward";
+
 export interface SimulateResponse {
   found: boolean;
   path: Point[];
   visitedOrder: Point[];
+  // Set by bidirectional solvers, one entry per visitedOrder entry.
+  visitedSides?: SearchSide[];
+  // Indices of the path points reached by jumping through a portal.
+  teleports?: number[];
+  // Indices of the path points where a key is picked up.
+  pickups?: number[];
+  // Indices of the path points that are broken walls.
+  breaks?: number[];
+  // Outcome for every wall break budget from 0 up to the one asked.
+  breakProfile?: BreakBudget[];
+  // With moving obstacles, the time step of every path point and where each
+  // obstacle stands at every time step.
+  times?: number[];
+  obstacles?: Point[][];
   stats: SimulationStats;
 }
 
+// Agent is one of several walkers planned together by POST /simulate/multi.
+export interface Agent {
+  start: Point;
+  goal: Point;
+}
+
+// The controls plan up to MAX_AGENTS agents at once.
+export const MAX_AGENTS = 8;
+
+export interface SimulateMultiRequest {
+  grid: Grid;
+  agents: Agent[];
+  movement?: Movement;
+  topology?: Topology;
+  // Constraint tree nodes to expand before settling for a partial plan.
+  maxExpansions?: number;
+}
+
+// Conflict is the first collision left between two agents of a partial plan:
+// both on point at time, or swapping point and to when leaving at time.
+export interface Conflict {
+  kind: "vertex" | "edge";
+  agents: [number, number];
+  time: number;
+  point: Point;
+  to?: Point;
+}
+
+export interface MultiStats {
+  sumOfCosts: number;
+  makespan: number;
+  expandedNodes: number;
+  lowLevelExpansions: number;
+  elapsedMs: number;
+  limitReached: boolean;
+}
+
+export interface SimulateMultiResponse {
+  found: boolean;
+  // One path per agent, one point per time step; an agent waits on its goal
+  // once it has arrived.
+  paths: Point[][];
+  conflicts: Conflict[];
+  stats: MultiStats;
+}
+
+// WallChange turns the square at point into a wall or opens it up.
+export interface WallChange {
+  point: Point;
+  wall: boolean;
+}
+
+// CreatePlannerRequest starts an incremental planner session with
+// POST /planner/sessions; walls are then edited with PATCH
+// /planner/sessions/:id and the plan repaired instead of rerun.
+export interface CreatePlannerRequest {
+  grid: Grid;
+  start: Point;
+  goal: Point;
+  movement?: Movement;
+  heuristic?: Heuristic;
+  topology?: Topology;
+}
+
+// PlannerStats sets the squares the last replan expanded and touched against
+// those planning the same grid from scratch takes.
+export interface PlannerStats {
+  pathLength: number;
+  pathCost: number;
+  expanded: number;
+  touched: number;
+  rerunExpanded: number;
+  rerunTouched: number;
+  elapsedMs: number;
+}
+
+export interface PlannerResponse {
+  sessionId: string;
+  found: boolean;
+  path: Point[];
+  stats: PlannerStats;
+}
+
+// canEditWalls reports whether a planner session can replan grid as its walls
+// change: a single floor of plain walls and open squares, on any tile shape
+// but polar.
+export const canEditWalls = (grid: Grid, topology: Topology): boolean =>
+  topology !== "polar" && grid.every((row) => row.every((tile) => tile === 0 || tile === 1));
+
 export interface SimulationStats {
   expandedNodes: number;
   pathLength: number;
+  pathCost: number;
   elapsedMs: number;
+  heuristic?: Heuristic;
+  weight?: number;
+  optimal: boolean;
 }
 
+export type SearchEventKind = "push" | "relax" | "pop" | "goalReached";
+
+// SearchEvent is one entry of a "steps" message sent by POST /simulate/stream.
+export interface SearchEvent {
+  kind: SearchEventKind;
+  point: Point;
+  parent?: Point;
+  g: number;
+  h: number;
+  f: number;
+  side?: SearchSide;
+}
+
+// SimulateStreamDone is the payload of the final "done" message of a stream.
+export interface SimulateStreamDone {
+  found: boolean;
+  path: Point[];
+  teleports?: number[];
+  pickups?: number[];
+  breaks?: number[];
+  breakProfile?: BreakBudget[];
+  times?: number[];
+  obstacles?: Point[][];
+  stats: SimulationStats;
+}
@@ -0,0 +1,22 @@
+import { isOpenTile, type Agent, type Grid, type Point } from "@/types";
+
+const samePoint = (a: Point, b: Point) => a.x === b.x && a.y === b.y;
+
+// agents plans count agents on grid: the first walks from start to goal, and
+// each other one from an open tile near the start of reading order to one near
+// its end, spread evenly so that no two share a start or a goal.
+export const agents = (grid: Grid, count: number, start: Point, goal: Point): Agent[] => {
+  const open = grid.flatMap((row, y) =>
+    row.flatMap((tile, x) => {
+      const point = { x, y, z: 0 };
+      return isOpenTile(tile) && !samePoint(point, start) && !samePoint(point, goal) ? [point] : [];
+    }),
+  );
+  const planned: Agent[] = [{ start, goal }];
+  const half = Math.floor(open.length / 2);
+  for (let i = 1; i < Math.min(count, half + 1); i++) {
+    const offset = Math.floor(((i - 1) * half) / Math.max(1, count - 1));
+    planned.push({ start: open[offset], goal: open[open.length - 1 - offset] });
+  }
+  return planned;
+};
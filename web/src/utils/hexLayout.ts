@@ -0,0 +1,72 @@
+import type { Pixel, Point } from "@/types";
+
+const SQRT3 = Math.sqrt(3);
+
+// HexLayout places the tiles of a hex grid on a canvas. Tiles are pointy-top
+// hexagons with circumradius size; tile (x, y) of the grid is the axial
+// coordinate (q, r) = (x, y), so each row sits half a tile right of the row above.
+export interface HexLayout {
+  size: number;
+  originX: number;
+  originY: number;
+}
+
+// hexLayout fits a grid of width x height tiles into the container, centred.
+export const hexLayout = (
+  width: number,
+  height: number,
+  containerWidth: number,
+  containerHeight: number,
+): HexLayout => {
+  const spanX = SQRT3 * (width + (height - 1) / 2);
+  const spanY = 1.5 * (height - 1) + 2;
+  const size = Math.max(0.5, Math.min(containerWidth / spanX, containerHeight / spanY));
+  return {
+    size,
+    originX: (containerWidth - spanX * size) / 2 + (SQRT3 / 2) * size,
+    originY: (containerHeight - spanY * size) / 2 + size,
+  };
+};
+
+// hexCenter returns the canvas position of the centre of tile p.
+export const hexCenter = (layout: HexLayout, p: Point): Pixel => ({
+  x: layout.originX + layout.size * SQRT3 * (p.x + p.y / 2),
+  y: layout.originY + layout.size * 1.5 * p.y,
+});
+
+// traceHex adds the outline of tile p, shrunk by scale around its centre, to the
+// current path of context.
+export const traceHex = (context: CanvasRenderingContext2D, layout: HexLayout, p: Point, scale = 1) => {
+  const center = hexCenter(layout, p);
+  const radius = layout.size * scale;
+  context.moveTo(center.x, center.y - radius);
+  for (let corner = 1; corner < 6; corner += 1) {
+    const angle = (Math.PI / 3) * corner - Math.PI / 2;
+    context.lineTo(center.x + radius * Math.cos(angle), center.y + radius * Math.sin(angle));
+  }
+  context.closePath();
+};
+
+// hexAt returns the tile under the canvas position (x, y), which may lie
+// outside the grid. Hex mazes have a single floor.
+export const hexAt = (layout: HexLayout, x: number, y: number): Point => {
+  const px = (x - layout.originX) / layout.size;
+  const py = (y - layout.originY) / layout.size;
+  const q = (SQRT3 / 3) * px - py / 3;
+  const r = (2 / 3) * py;
+
+  // Round in cube coordinates, fixing the component with the largest error.
+  const s = -q - r;
+  let rq = Math.round(q);
+  let rr = Math.round(r);
+  const rs = Math.round(s);
+  const dq = Math.abs(rq - q);
+  const dr = Math.abs(rr - r);
+  const ds = Math.abs(rs - s);
+  if (dq > dr && dq > ds) {
+    rq = -rr - rs;
+  } else if (dr > ds) {
+    rr = -rq - rs;
+  }
+  return { x: rq, y: rr, z: 0 };
+};
@@ -0,0 +1,34 @@
+import { isOpenTile, type Grid, type Obstacle, type Point } from "@/types";
+
+// PATROL_REACH is how many squares a patrol walks away from where it starts.
+const PATROL_REACH = 4;
+
+const samePoint = (a: Point, b: Point | null) => b !== null && a.x === b.x && a.y === b.y;
+
+// patrols places count obstacles spread evenly over the open squares of a square
+// grid, read in reading order, skipping start. Each walks up to PATROL_REACH
+// squares along a straight run of open squares, right if it can and down
+// otherwise, and back again, over and over.
+export const patrols = (grid: Grid, count: number, start: Point | null): Obstacle[] => {
+  const open = grid.flatMap((row, y) =>
+    row.flatMap((tile, x) => (isOpenTile(tile) && !samePoint({ x, y, z: 0 }, start) ? [{ x, y, z: 0 }] : [])),
+  );
+  const walkable = (p: Point) => isOpenTile(grid[p.y]?.[p.x] ?? 1);
+
+  const obstacles: Obstacle[] = [];
+  for (let i = 0; i < Math.min(count, open.length); i++) {
+    const from = open[Math.floor(((i + 0.5) * open.length) / count)];
+    const dir = walkable({ x: from.x + 1, y: from.y, z: 0 }) ? { x: 1, y: 0 } : { x: 0, y: 1 };
+    const out: Point[] = [from];
+    for (let step = 1; step <= PATROL_REACH; step++) {
+      const next = { x: from.x + dir.x * step, y: from.y + dir.y * step, z: 0 };
+      if (!walkable(next)) {
+        break;
+      }
+      out.push(next);
+    }
+    // Walking back skips both ends so the obstacle never stands still.
+    obstacles.push({ trajectory: [...out, ...out.slice(1, -1).reverse()], periodic: true });
+  }
+  return obstacles;
+};
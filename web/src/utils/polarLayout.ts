@@ -0,0 +1,132 @@
+import type { Grid, Pixel, Point } from "@/types";
+
+const TAU = Math.PI * 2;
+
+// Share of a ring's depth taken by the walls on its inner edge.
+const WALL_DEPTH = 0.25;
+
+// PolarLayout places the tiles of a polar grid on a canvas as concentric rings
+// around (centerX, centerY), each ringWidth deep. Row 0 is the centre disc;
+// ring r takes rows 2r - 1 (the walls on its inner edge) and 2r (its cells,
+// each followed clockwise by the wall to the next one). Angles run clockwise
+// from 12 o'clock.
+export interface PolarLayout {
+  centerX: number;
+  centerY: number;
+  ringWidth: number;
+}
+
+interface Sector {
+  inner: number;
+  outer: number;
+  from: number;
+  to: number;
+}
+
+// polarLayout fits a grid into the container, centred.
+export const polarLayout = (grid: Grid, containerWidth: number, containerHeight: number): PolarLayout => {
+  const rings = (grid.length - 1) / 2;
+  return {
+    centerX: containerWidth / 2,
+    centerY: containerHeight / 2,
+    ringWidth: Math.max(0.5, (Math.min(containerWidth, containerHeight) / 2 - 2) / (rings + 1)),
+  };
+};
+
+// wallAngle is the angle taken by the walls between the cells of ring r, so
+// that they are about as thick as the walls on its inner edge.
+const wallAngle = (ring: number, cellAngle: number) =>
+  Math.min(cellAngle * 0.3, WALL_DEPTH / (ring + 0.5));
+
+// polarSector returns the annular sector covered by tile p, or null for the
+// centre disc.
+const polarSector = (layout: PolarLayout, grid: Grid, p: Point): Sector | null => {
+  if (p.y === 0) {
+    return null;
+  }
+  const ring = Math.ceil(p.y / 2);
+  const inner = ring * layout.ringWidth;
+  const wall = inner + WALL_DEPTH * layout.ringWidth;
+  if (p.y % 2 === 1) {
+    const angle = TAU / grid[p.y].length;
+    return { inner, outer: wall, from: p.x * angle, to: (p.x + 1) * angle };
+  }
+
+  const angle = (2 * TAU) / grid[p.y].length;
+  const gap = wallAngle(ring, angle);
+  const cell = Math.floor(p.x / 2);
+  const outer = inner + layout.ringWidth;
+  if (p.x % 2 === 1) {
+    return { inner: wall, outer, from: (cell + 1) * angle - gap, to: (cell + 1) * angle };
+  }
+  return { inner: wall, outer, from: cell * angle, to: (cell + 1) * angle - gap };
+};
+
+// polarCenter returns the canvas position of the middle of tile p.
+export const polarCenter = (layout: PolarLayout, grid: Grid, p: Point): Pixel => {
+  const sector = polarSector(layout, grid, p);
+  if (!sector) {
+    return { x: layout.centerX, y: layout.centerY };
+  }
+  const radius = (sector.inner + sector.outer) / 2;
+  const angle = (sector.from + sector.to) / 2 - Math.PI / 2;
+  return { x: layout.centerX + radius * Math.cos(angle), y: layout.centerY + radius * Math.sin(angle) };
+};
+
+// tracePolar adds the outline of tile p, shrunk by scale around its middle, to
+// the current path of context.
+export const tracePolar = (
+  context: CanvasRenderingContext2D,
+  layout: PolarLayout,
+  grid: Grid,
+  p: Point,
+  scale = 1,
+) => {
+  const { centerX, centerY } = layout;
+  const sector = polarSector(layout, grid, p);
+  if (!sector) {
+    const radius = layout.ringWidth * scale;
+    context.moveTo(centerX + radius, centerY);
+    context.arc(centerX, centerY, radius, 0, TAU);
+    context.closePath();
+    return;
+  }
+
+  const radius = (sector.inner + sector.outer) / 2;
+  const depth = ((sector.outer - sector.inner) / 2) * scale;
+  const angle = (sector.from + sector.to) / 2 - Math.PI / 2;
+  const span = ((sector.to - sector.from) / 2) * scale;
+  const outer = radius + depth;
+  context.moveTo(centerX + outer * Math.cos(angle - span), centerY + outer * Math.sin(angle - span));
+  context.arc(centerX, centerY, outer, angle - span, angle + span);
+  context.arc(centerX, centerY, radius - depth, angle + span, angle - span, true);
+  context.closePath();
+};
+
+// polarAt returns the tile under the canvas position (x, y), or null outside
+// the outermost ring. Polar mazes have a single floor.
+export const polarAt = (layout: PolarLayout, grid: Grid, x: number, y: number): Point | null => {
+  const dx = x - layout.centerX;
+  const dy = y - layout.centerY;
+  const distance = Math.hypot(dx, dy) / layout.ringWidth;
+  const ring = Math.floor(distance);
+  if (ring === 0) {
+    return { x: 0, y: 0, z: 0 };
+  }
+  if (2 * ring >= grid.length) {
+    return null;
+  }
+
+  const angle = (Math.atan2(dy, dx) + Math.PI / 2 + TAU) % TAU;
+  if (distance - ring < WALL_DEPTH) {
+    const walls = grid[2 * ring - 1].length;
+    return { x: Math.floor((angle / TAU) * walls) % walls, y: 2 * ring - 1, z: 0 };
+  }
+
+  const cells = grid[2 * ring].length / 2;
+  const cellAngle = TAU / cells;
+  const cell = Math.floor(angle / cellAngle) % cells;
+  const past = angle - cell * cellAngle;
+  const onWall = past > cellAngle - wallAngle(ring, cellAngle);
+  return { x: 2 * cell + (onWall ? 1 : 0), y: 2 * ring, z: 0 };
+};
@@ -15,6 +15,7 @@ export default defineConfig({
     proxy: {
       "/maze": "http://localhost:8080",
       "/simulate": "http://localhost:8080",
+      "/planner": "http://localhost:8080",
     },
   },
 });